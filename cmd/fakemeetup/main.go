@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup"
+)
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	server, err := fakemeetup.InitHTTPServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Fake Meetup listening on %s", server.Addr)
+
+	if err = server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"os"
 	"time"
 
 	"sgf-meetup-api/pkg/importer"
@@ -25,5 +27,79 @@ func init() {
 }
 
 func main() {
-	lambda.Start(service.Import)
+	// AWS_LAMBDA_RUNTIME_API is set by Lambda and SAM, otherwise run once from the command line.
+	if _, ok := os.LookupEnv("AWS_LAMBDA_RUNTIME_API"); ok {
+		lambda.Start(service.ImportWithOptions)
+		return
+	}
+
+	var opts importer.ImportOptions
+	var backfillOpts importer.BackfillOptions
+	var backfill bool
+	var format string
+
+	flag.Func("group", "Group to import, may be repeated (default all enabled groups)",
+		func(group string) error {
+			opts.Groups = append(opts.Groups, group)
+			return nil
+		},
+	)
+	flag.BoolVar(&opts.DryRun, "dry-run", false, "Fetch and compare events without writing them")
+	flag.BoolVar(&backfill, "backfill", false, "Copy past events into the archive table")
+	flag.BoolVar(&backfillOpts.Restart, "restart", false, "Ignore saved backfill checkpoints")
+	flag.IntVar(&backfillOpts.MaxPages, "max-pages", 0,
+		"Most Meetup pages to backfill per group (default unlimited)")
+	flag.StringVar(&format, "format", "text", "Report format, text or json")
+	flag.Parse()
+
+	if format != "text" && format != "json" {
+		log.Fatalf("unknown report format %q, use text or json", format)
+	}
+
+	if backfill {
+		backfillOpts.Groups = opts.Groups
+		runBackfill(backfillOpts, format)
+		return
+	}
+
+	writeReport := (*importer.ImportReport).WriteText
+	if format == "json" {
+		writeReport = (*importer.ImportReport).WriteJSON
+	}
+
+	report, importErr := service.ImportWithOptions(context.Background(), opts)
+	if report != nil {
+		if err := writeReport(report, os.Stdout); err != nil {
+			log.Fatalf("writing report failed: %v", err)
+		}
+	}
+
+	if importErr != nil {
+		log.Fatalf("import failed: %v", importErr)
+	}
+}
+
+func runBackfill(opts importer.BackfillOptions, format string) {
+	ctx := context.Background()
+
+	backfiller, err := importer.InitBackfiller(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writeReport := (*importer.BackfillReport).WriteText
+	if format == "json" {
+		writeReport = (*importer.BackfillReport).WriteJSON
+	}
+
+	report, backfillErr := backfiller.Backfill(ctx, opts)
+	if report != nil {
+		if err := writeReport(report, os.Stdout); err != nil {
+			log.Fatalf("writing report failed: %v", err)
+		}
+	}
+
+	if backfillErr != nil {
+		log.Fatalf("backfill failed: %v", backfillErr)
+	}
 }
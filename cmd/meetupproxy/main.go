@@ -3,6 +3,9 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"sgf-meetup-api/pkg/meetupproxy"
@@ -10,19 +13,32 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 )
 
-var service *meetupproxy.Service
-
-func init() {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func main() {
+	initCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	newService, err := meetupproxy.InitService(ctx)
+
+	// AWS_LAMBDA_RUNTIME_API is set by Lambda and SAM, otherwise serve the proxy over HTTP.
+	if _, ok := os.LookupEnv("AWS_LAMBDA_RUNTIME_API"); ok {
+		service, err := meetupproxy.InitService(initCtx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		lambda.Start(service.HandleRequest)
+		return
+	}
+
+	server, err := meetupproxy.InitHTTPServer(initCtx)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	service = newService
-}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-func main() {
-	lambda.Start(service.HandleRequest)
+	log.Printf("Meetup proxy listening on %s", server.Addr)
+
+	if err = server.ListenAndServe(ctx); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -19,10 +19,17 @@ const (
 )
 
 func main() {
-	var clientID, clientSecret string
+	var clientID, clientSecret, scopes, origins string
 
 	flag.StringVar(&clientID, "clientId", "", "Client ID for the user (required)")
 	flag.StringVar(&clientSecret, "clientSecret", "", "Client Secret for the user (required)")
+	flag.StringVar(&scopes, "scopes", "", "Comma separated scopes to grant, e.g. admin")
+	flag.StringVar(
+		&origins,
+		"origins",
+		"",
+		"Comma separated browser origins allowed to use the user, e.g. https://opensgf.org",
+	)
 	flag.Usage = createUsageFunc()
 	flag.Parse()
 
@@ -44,6 +51,8 @@ func main() {
 		*infra.ApiUsersTableProps.TableName,
 		clientID,
 		clientSecret,
+		parseList(scopes),
+		parseList(origins),
 	); err != nil {
 		log.Fatalf("user upsert operation failed: %v", err)
 	}
@@ -51,14 +60,25 @@ func main() {
 	log.Printf("successfully upserted user %q", clientID)
 }
 
+func parseList(list string) []string {
+	var parsed []string
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			parsed = append(parsed, item)
+		}
+	}
+	return parsed
+}
+
 func createUsageFunc() func() {
 	return func() {
 		_, _ = fmt.Fprintf(
 			flag.CommandLine.Output(),
-			"Usage: %s -clientId <ID> -clientSecret <SECRET>\n\n",
+			"Usage: %s -clientId <ID> -clientSecret <SECRET> [-scopes <SCOPES>] "+
+				"[-origins <ORIGINS>]\n\n",
 			os.Args[0],
 		)
-		_, _ = fmt.Fprintln(flag.CommandLine.Output(), "Required flags:")
+		_, _ = fmt.Fprintln(flag.CommandLine.Output(), "Flags:")
 		flag.VisitAll(func(f *flag.Flag) {
 			_, _ = fmt.Fprintf(flag.CommandLine.Output(), "  -%-12s%s\n", f.Name, f.Usage)
 		})
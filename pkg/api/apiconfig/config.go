@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"sgf-meetup-api/pkg/shared/appconfig"
 
@@ -16,31 +18,68 @@ import (
 const (
 	eventsTableNameKey          = "EVENTS_TABLE_NAME"
 	apiUsersTableNameKey        = "API_USERS_TABLE_NAME"
+	importRunsTableNameKey      = "IMPORT_RUNS_TABLE_NAME"
+	importerFunctionNameKey     = "IMPORTER_FUNCTION_NAME"
 	groupIDDateTimeIndexNameKey = "GROUP_ID_DATE_TIME_INDEX_NAME"
+	eventSeriesTableNameKey     = "EVENT_SERIES_TABLE_NAME"
+	seriesIDDateTimeIndexKey    = "SERIES_ID_DATE_TIME_INDEX_NAME"
+	groupVersionsTableNameKey   = "GROUP_VERSIONS_TABLE_NAME"
 	jwtIssuerKey                = "JWT_ISSUER"
 	jwtSecretBase64Key          = "JWT_SECRET_BASE64"
 	jwtSecretKey                = "JWT_SECRET"
 	appUrlKey                   = "APP_URL"
+	corsAllowedOriginsKey       = "CORS_ALLOWED_ORIGINS"
+	corsAllowedHeadersKey       = "CORS_ALLOWED_HEADERS"
+	corsExposedHeadersKey       = "CORS_EXPOSED_HEADERS"
+	corsAllowCredentialsKey     = "CORS_ALLOW_CREDENTIALS"
+	corsMaxAgeKey               = "CORS_MAX_AGE"
 )
 
 var configKeys = []string{
 	jwtSecretBase64Key,
 	eventsTableNameKey,
 	apiUsersTableNameKey,
+	importRunsTableNameKey,
+	importerFunctionNameKey,
 	groupIDDateTimeIndexNameKey,
+	eventSeriesTableNameKey,
+	seriesIDDateTimeIndexKey,
+	groupVersionsTableNameKey,
 	jwtIssuerKey,
 	jwtSecretKey,
 	appUrlKey,
+	corsAllowedOriginsKey,
+	corsAllowedHeadersKey,
+	corsExposedHeadersKey,
+	corsAllowCredentialsKey,
+	corsMaxAgeKey,
 }
 
 type Config struct {
-	appconfig.Common         `mapstructure:",squash"`
-	EventsTableName          string  `mapstructure:"events_table_name"`
-	APIUsersTableName        string  `mapstructure:"api_users_table_name"`
-	GroupIDDateTimeIndexName string  `mapstructure:"group_id_date_time_index_name"`
-	JWTIssuer                string  `mapstructure:"jwt_issuer"`
-	JWTSecret                []byte  `mapstructure:"jwt_secret"`
-	AppURL                   url.URL `mapstructure:"app_url"`
+	appconfig.Common          `mapstructure:",squash"`
+	EventsTableName           string  `mapstructure:"events_table_name"`
+	APIUsersTableName         string  `mapstructure:"api_users_table_name"`
+	ImportRunsTableName       string  `mapstructure:"import_runs_table_name"`
+	ImporterFunctionName      string  `mapstructure:"importer_function_name"`
+	GroupIDDateTimeIndexName  string  `mapstructure:"group_id_date_time_index_name"`
+	EventSeriesTableName      string  `mapstructure:"event_series_table_name"`
+	SeriesIDDateTimeIndexName string  `mapstructure:"series_id_date_time_index_name"`
+	GroupVersionsTableName    string  `mapstructure:"group_versions_table_name"`
+	JWTIssuer                 string  `mapstructure:"jwt_issuer"`
+	JWTSecret                 []byte  `mapstructure:"jwt_secret"`
+	AppURL                    url.URL `mapstructure:"app_url"`
+	// CORSAllowedOrigins are the browser origins allowed to call the API, such as
+	// https://opensgf.org, or * for any. CORS is off when it's empty.
+	CORSAllowedOrigins []string `mapstructure:"cors_allowed_origins"`
+	// CORSAllowedHeaders are the request headers browsers may send, and CORSExposedHeaders the
+	// response headers scripts may read beyond the safelisted ones.
+	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
+	CORSExposedHeaders []string `mapstructure:"cors_exposed_headers"`
+	// CORSAllowCredentials lets browsers send cookies and HTTP authentication. Bearer tokens
+	// don't need it.
+	CORSAllowCredentials bool `mapstructure:"cors_allow_credentials"`
+	// CORSMaxAge is how long browsers may cache a preflight response.
+	CORSMaxAge time.Duration `mapstructure:"cors_max_age"`
 }
 
 func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager) (*Config, error) {
@@ -71,6 +110,13 @@ func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager)
 
 func setDefaults(_ context.Context, v *viper.Viper) error {
 	v.SetDefault(strings.ToLower(jwtIssuerKey), "sgf-meetup-api.opensgf.org")
+	v.SetDefault(
+		strings.ToLower(corsAllowedHeadersKey),
+		"Authorization,Content-Type,If-None-Match,If-Modified-Since",
+	)
+	v.SetDefault(strings.ToLower(corsExposedHeadersKey), "ETag")
+	v.SetDefault(strings.ToLower(corsAllowCredentialsKey), false)
+	v.SetDefault(strings.ToLower(corsMaxAgeKey), "10m")
 
 	jwtSecretBase64 := v.Get(strings.ToLower(jwtSecretBase64Key)).(string)
 	jwtSecret, err := base64.StdEncoding.DecodeString(jwtSecretBase64)
@@ -101,9 +147,24 @@ func (config *Config) validate() error {
 	if config.APIUsersTableName == "" {
 		missing = append(missing, apiUsersTableNameKey)
 	}
+	if config.ImportRunsTableName == "" {
+		missing = append(missing, importRunsTableNameKey)
+	}
+	if config.ImporterFunctionName == "" {
+		missing = append(missing, importerFunctionNameKey)
+	}
 	if config.GroupIDDateTimeIndexName == "" {
 		missing = append(missing, groupIDDateTimeIndexNameKey)
 	}
+	if config.EventSeriesTableName == "" {
+		missing = append(missing, eventSeriesTableNameKey)
+	}
+	if config.SeriesIDDateTimeIndexName == "" {
+		missing = append(missing, seriesIDDateTimeIndexKey)
+	}
+	if config.GroupVersionsTableName == "" {
+		missing = append(missing, groupVersionsTableNameKey)
+	}
 	if len(config.JWTSecret) == 0 {
 		missing = append(missing, jwtSecretKey)
 	}
@@ -112,9 +173,48 @@ func (config *Config) validate() error {
 		return fmt.Errorf("missing required env vars: %v", strings.Join(missing, ", "))
 	}
 
+	for _, origin := range config.CORSAllowedOrigins {
+		if !validOrigin(origin) {
+			return fmt.Errorf("%s has an invalid origin %q", corsAllowedOriginsKey, origin)
+		}
+	}
+
+	// Browsers reject credentialed responses allowing any origin.
+	if config.CORSAllowCredentials && slices.Contains(config.CORSAllowedOrigins, "*") {
+		return fmt.Errorf(
+			"%s can't be used with %s *",
+			corsAllowCredentialsKey,
+			corsAllowedOriginsKey,
+		)
+	}
+
+	if config.CORSMaxAge < 0 {
+		return fmt.Errorf("%s must not be negative", corsMaxAgeKey)
+	}
+
 	return nil
 }
 
+// validOrigin reports whether origin is * or a scheme, host and optional port, as browsers send
+// in the Origin header.
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") &&
+		u.Host != "" &&
+		u.Path == "" &&
+		u.User == nil &&
+		u.RawQuery == "" &&
+		u.Fragment == ""
+}
+
 var ConfigProviders = wire.NewSet(
 	appconfig.ConfigProviders,
 	wire.FieldsOf(new(*Config), "Common"),
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"sgf-meetup-api/pkg/shared/appconfig"
 
@@ -21,7 +22,12 @@ func TestNewConfig(t *testing.T) {
 		switchToTempTestDir(t)
 		t.Setenv(eventsTableNameKey, "test_events")
 		t.Setenv(apiUsersTableNameKey, "test_api_users")
+		t.Setenv(importRunsTableNameKey, "test_import_runs")
+		t.Setenv(importerFunctionNameKey, "test_importer")
 		t.Setenv(groupIDDateTimeIndexNameKey, "test_index")
+		t.Setenv(eventSeriesTableNameKey, "test_event_series")
+		t.Setenv(groupVersionsTableNameKey, "test_group_versions")
+		t.Setenv(seriesIDDateTimeIndexKey, "test_series_index")
 		t.Setenv(jwtSecretBase64Key, "dGVzdF9iYXNlNjQ=")
 		t.Setenv(appUrlKey, "https://test.example.com")
 
@@ -30,7 +36,12 @@ func TestNewConfig(t *testing.T) {
 
 		assert.Equal(t, "test_events", cfg.EventsTableName)
 		assert.Equal(t, "test_api_users", cfg.APIUsersTableName)
+		assert.Equal(t, "test_import_runs", cfg.ImportRunsTableName)
+		assert.Equal(t, "test_importer", cfg.ImporterFunctionName)
 		assert.Equal(t, "test_index", cfg.GroupIDDateTimeIndexName)
+		assert.Equal(t, "test_event_series", cfg.EventSeriesTableName)
+		assert.Equal(t, "test_group_versions", cfg.GroupVersionsTableName)
+		assert.Equal(t, "test_series_index", cfg.SeriesIDDateTimeIndexName)
 		assert.Equal(t, []byte("test_base64"), cfg.JWTSecret)
 		assert.Equal(t, "https://test.example.com", cfg.AppURL.String())
 	})
@@ -42,7 +53,12 @@ func TestNewConfig(t *testing.T) {
 		envContent := strings.Join([]string{
 			eventsTableNameKey + "=file_events",
 			apiUsersTableNameKey + "=file_api_users",
+			importRunsTableNameKey + "=file_import_runs",
+			importerFunctionNameKey + "=file_importer",
 			groupIDDateTimeIndexNameKey + "=file_index",
+			eventSeriesTableNameKey + "=file_event_series",
+			groupVersionsTableNameKey + "=file_group_versions",
+			seriesIDDateTimeIndexKey + "=file_series_index",
 			jwtSecretBase64Key + "=dGVzdF9iYXNlNjQ=",
 			appUrlKey + "=https://file.example.com",
 		}, "\n")
@@ -60,7 +76,12 @@ func TestNewConfig(t *testing.T) {
 
 		assert.Equal(t, "file_events", cfg.EventsTableName)
 		assert.Equal(t, "file_api_users", cfg.APIUsersTableName)
+		assert.Equal(t, "file_import_runs", cfg.ImportRunsTableName)
+		assert.Equal(t, "file_importer", cfg.ImporterFunctionName)
 		assert.Equal(t, "file_index", cfg.GroupIDDateTimeIndexName)
+		assert.Equal(t, "file_event_series", cfg.EventSeriesTableName)
+		assert.Equal(t, "file_group_versions", cfg.GroupVersionsTableName)
+		assert.Equal(t, "file_series_index", cfg.SeriesIDDateTimeIndexName)
 		assert.Equal(t, []byte("test_base64"), cfg.JWTSecret)
 		assert.Equal(t, "https://file.example.com", cfg.AppURL.String())
 	})
@@ -69,7 +90,12 @@ func TestNewConfig(t *testing.T) {
 		switchToTempTestDir(t)
 		t.Setenv(eventsTableNameKey, "default_events")
 		t.Setenv(apiUsersTableNameKey, "default_api_users")
+		t.Setenv(importRunsTableNameKey, "default_import_runs")
+		t.Setenv(importerFunctionNameKey, "default_importer")
 		t.Setenv(groupIDDateTimeIndexNameKey, "default_index")
+		t.Setenv(eventSeriesTableNameKey, "default_event_series")
+		t.Setenv(groupVersionsTableNameKey, "default_group_versions")
+		t.Setenv(seriesIDDateTimeIndexKey, "default_series_index")
 		t.Setenv(jwtSecretKey, "default_secret")
 
 		cfg, err := NewConfig(ctx, awsConfigManager)
@@ -77,6 +103,63 @@ func TestNewConfig(t *testing.T) {
 
 		assert.Equal(t, "sgf-meetup-api.opensgf.org", cfg.JWTIssuer)
 		assert.Equal(t, "https://sgf-meetup-api.opensgf.org", cfg.AppURL.String())
+		assert.Empty(t, cfg.CORSAllowedOrigins)
+		assert.Equal(
+			t,
+			[]string{"Authorization", "Content-Type", "If-None-Match", "If-Modified-Since"},
+			cfg.CORSAllowedHeaders,
+		)
+		assert.Equal(t, []string{"ETag"}, cfg.CORSExposedHeaders)
+		assert.False(t, cfg.CORSAllowCredentials)
+		assert.Equal(t, 10*time.Minute, cfg.CORSMaxAge)
+	})
+
+	t.Run("parses cors settings", func(t *testing.T) {
+		switchToTempTestDir(t)
+		setRequiredEnv(t)
+		t.Setenv(corsAllowedOriginsKey, "https://opensgf.org,http://localhost:3000")
+		t.Setenv(corsAllowedHeadersKey, "Authorization")
+		t.Setenv(corsExposedHeadersKey, "ETag,X-Request-Id")
+		t.Setenv(corsAllowCredentialsKey, "true")
+		t.Setenv(corsMaxAgeKey, "1h")
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]string{"https://opensgf.org", "http://localhost:3000"},
+			cfg.CORSAllowedOrigins,
+		)
+		assert.Equal(t, []string{"Authorization"}, cfg.CORSAllowedHeaders)
+		assert.Equal(t, []string{"ETag", "X-Request-Id"}, cfg.CORSExposedHeaders)
+		assert.True(t, cfg.CORSAllowCredentials)
+		assert.Equal(t, time.Hour, cfg.CORSMaxAge)
+	})
+
+	t.Run("cors origins must be origins", func(t *testing.T) {
+		for _, origin := range []string{"opensgf.org", "https://opensgf.org/events", "ftp://x"} {
+			t.Run(origin, func(t *testing.T) {
+				switchToTempTestDir(t)
+				setRequiredEnv(t)
+				t.Setenv(corsAllowedOriginsKey, origin)
+
+				_, err := NewConfig(ctx, awsConfigManager)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), corsAllowedOriginsKey)
+			})
+		}
+	})
+
+	t.Run("cors credentials can't be allowed for any origin", func(t *testing.T) {
+		switchToTempTestDir(t)
+		setRequiredEnv(t)
+		t.Setenv(corsAllowedOriginsKey, "*")
+		t.Setenv(corsAllowCredentialsKey, "true")
+
+		_, err := NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), corsAllowCredentialsKey)
 	})
 
 	t.Run("validation fails with missing fields", func(t *testing.T) {
@@ -85,13 +168,22 @@ func TestNewConfig(t *testing.T) {
 		_, err := NewConfig(ctx, awsConfigManager)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), eventsTableNameKey)
+		assert.Contains(t, err.Error(), importRunsTableNameKey)
+		assert.Contains(t, err.Error(), importerFunctionNameKey)
+		assert.Contains(t, err.Error(), eventSeriesTableNameKey)
+		assert.Contains(t, err.Error(), groupVersionsTableNameKey)
 	})
 
 	t.Run("invalid app URL format", func(t *testing.T) {
 		switchToTempTestDir(t)
 		t.Setenv(eventsTableNameKey, "invalid_url_events")
 		t.Setenv(apiUsersTableNameKey, "invalid_url_api_users")
+		t.Setenv(importRunsTableNameKey, "invalid_url_import_runs")
+		t.Setenv(importerFunctionNameKey, "invalid_url_importer")
 		t.Setenv(groupIDDateTimeIndexNameKey, "invalid_url_index")
+		t.Setenv(eventSeriesTableNameKey, "invalid_url_event_series")
+		t.Setenv(groupVersionsTableNameKey, "invalid_url_group_versions")
+		t.Setenv(seriesIDDateTimeIndexKey, "invalid_url_series_index")
 		t.Setenv(jwtSecretKey, "invalid_url_secret")
 		t.Setenv(appUrlKey, "://invalid.url")
 
@@ -101,6 +193,20 @@ func TestNewConfig(t *testing.T) {
 	})
 }
 
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv(eventsTableNameKey, "events")
+	t.Setenv(apiUsersTableNameKey, "api_users")
+	t.Setenv(importRunsTableNameKey, "import_runs")
+	t.Setenv(importerFunctionNameKey, "importer")
+	t.Setenv(groupIDDateTimeIndexNameKey, "index")
+	t.Setenv(eventSeriesTableNameKey, "event_series")
+	t.Setenv(groupVersionsTableNameKey, "group_versions")
+	t.Setenv(seriesIDDateTimeIndexKey, "series_index")
+	t.Setenv(jwtSecretKey, "secret")
+}
+
 func switchToTempTestDir(t *testing.T) {
 	t.Helper()
 
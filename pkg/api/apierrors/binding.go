@@ -0,0 +1,154 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// WriteBindingProblem writes an invalid-parameter problem for an error binding the request into
+// obj.
+func WriteBindingProblem(ctx *gin.Context, obj any, err error) {
+	WriteProblemDetails(ctx, NewInvalidParameterProblem(BindingErrors(ctx, obj, err)...))
+}
+
+// BindingErrors maps an error binding the request's query or JSON body into obj, a pointer to a
+// struct, to the fields at fault. Fields are named by their form or json tags.
+func BindingErrors(ctx *gin.Context, obj any, err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		objType := structType(obj)
+		fieldErrs := make([]FieldError, 0, len(validationErrs))
+		for _, validationErr := range validationErrs {
+			fieldErrs = append(fieldErrs, validationFieldError(objType, validationErr))
+		}
+		return fieldErrs
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return []FieldError{{
+			Field:   field,
+			Code:    CodeInvalid,
+			Message: fmt.Sprintf("%s can't be a %s", field, typeErr.Value),
+		}}
+	case errors.Is(err, io.EOF):
+		return []FieldError{{Field: "body", Code: CodeRequired, Message: "body is required"}}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return []FieldError{{Field: "body", Code: CodeInvalid, Message: "body must be valid JSON"}}
+	default:
+		return queryFieldErrors(ctx, structType(obj))
+	}
+}
+
+// queryFieldErrors finds the query parameters that don't parse by binding them one at a time,
+// since the form binding's errors don't name the parameter.
+func queryFieldErrors(ctx *gin.Context, objType reflect.Type) []FieldError {
+	query := ctx.Request.URL.Query()
+
+	var fieldErrs []FieldError
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		single := reflect.New(objType).Interface()
+		if binding.MapFormWithTag(single, map[string][]string{key: query[key]}, "form") == nil {
+			continue
+		}
+
+		message := key + " is invalid"
+		if field, ok := fieldByTag(objType, "form", key); ok {
+			message = key + " " + formatHint(field.Type)
+		}
+
+		fieldErrs = append(fieldErrs, FieldError{Field: key, Code: CodeInvalid, Message: message})
+	}
+
+	return fieldErrs
+}
+
+func formatHint(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		return "must be an RFC 3339 date-time"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return "must be an integer"
+	case t.Kind() == reflect.Bool:
+		return "must be true or false"
+	default:
+		return "is invalid"
+	}
+}
+
+func validationFieldError(objType reflect.Type, err validator.FieldError) FieldError {
+	name := err.Field()
+	if field, ok := objType.FieldByName(err.StructField()); ok {
+		name = tagName(field)
+	}
+
+	fieldErr := FieldError{Field: name, Code: CodeInvalid, Message: name + " is invalid"}
+
+	switch err.Tag() {
+	case "required":
+		fieldErr.Code = CodeRequired
+		fieldErr.Message = name + " is required"
+	case "min", "gte":
+		fieldErr.Code = CodeOutOfRange
+		fieldErr.Message = fmt.Sprintf("%s must be at least %s", name, err.Param())
+	case "max", "lte":
+		fieldErr.Code = CodeOutOfRange
+		fieldErr.Message = fmt.Sprintf("%s must be at most %s", name, err.Param())
+	case "oneof":
+		fieldErr.Message = fmt.Sprintf(
+			"%s must be one of %s",
+			name,
+			strings.ReplaceAll(err.Param(), " ", ", "),
+		)
+	}
+
+	return fieldErr
+}
+
+// tagName returns the name a client uses for a field, from its form or json tag.
+func tagName(field reflect.StructField) string {
+	for _, tag := range []string{"form", "json"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func fieldByTag(t reflect.Type, tag, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if tagged, _, _ := strings.Cut(field.Tag.Get(tag), ","); tagged == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func structType(obj any) reflect.Type {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
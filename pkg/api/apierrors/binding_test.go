@@ -0,0 +1,119 @@
+package apierrors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testQueryParams struct {
+	Before *time.Time `form:"before"`
+	Limit  *int       `form:"limit"  binding:"omitempty,min=1,max=50"`
+	Format string     `form:"format" binding:"omitempty,oneof=html text"`
+}
+
+type testBody struct {
+	Name   string   `json:"name"   binding:"required"`
+	Groups []string `json:"groups"`
+}
+
+func TestBindingErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bindQuery := func(t *testing.T, query string) []FieldError {
+		t.Helper()
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+
+		var params testQueryParams
+		err := c.ShouldBindQuery(&params)
+		require.Error(t, err)
+
+		return BindingErrors(c, &params, err)
+	}
+
+	bindJSON := func(t *testing.T, body string) []FieldError {
+		t.Helper()
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+		var request testBody
+		err := c.ShouldBindJSON(&request)
+		require.Error(t, err)
+
+		return BindingErrors(c, &request, err)
+	}
+
+	t.Run("names query parameters that don't parse", func(t *testing.T) {
+		assert.Equal(t, []FieldError{
+			{Field: "before", Code: CodeInvalid, Message: "before must be an RFC 3339 date-time"},
+			{Field: "limit", Code: CodeInvalid, Message: "limit must be an integer"},
+		}, bindQuery(t, "limit=ten&before=yesterday&format=html"))
+	})
+
+	t.Run("maps validation failures", func(t *testing.T) {
+		assert.Equal(t, []FieldError{
+			{Field: "limit", Code: CodeOutOfRange, Message: "limit must be at least 1"},
+			{Field: "format", Code: CodeInvalid, Message: "format must be one of html, text"},
+		}, bindQuery(t, "limit=-5&format=pdf"))
+
+		assert.Equal(t, []FieldError{
+			{Field: "limit", Code: CodeOutOfRange, Message: "limit must be at most 50"},
+		}, bindQuery(t, "limit=51"))
+	})
+
+	t.Run("names required JSON properties", func(t *testing.T) {
+		assert.Equal(t, []FieldError{
+			{Field: "name", Code: CodeRequired, Message: "name is required"},
+		}, bindJSON(t, `{"groups": []}`))
+	})
+
+	t.Run("names JSON properties of the wrong type", func(t *testing.T) {
+		assert.Equal(t, []FieldError{
+			{Field: "groups", Code: CodeInvalid, Message: "groups can't be a number"},
+		}, bindJSON(t, `{"name": "a", "groups": 5}`))
+	})
+
+	t.Run("reports bodies that aren't JSON", func(t *testing.T) {
+		assert.Equal(t, []FieldError{
+			{Field: "body", Code: CodeInvalid, Message: "body must be valid JSON"},
+		}, bindJSON(t, `{"name":`))
+
+		assert.Equal(t, []FieldError{
+			{Field: "body", Code: CodeRequired, Message: "body is required"},
+		}, bindJSON(t, ""))
+	})
+}
+
+func TestWriteBindingProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?limit=0", nil)
+
+	var params testQueryParams
+	err := c.ShouldBindQuery(&params)
+	require.Error(t, err)
+
+	WriteBindingProblem(c, &params, err)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "https://sgf-meetup-api.opensgf.org/problems/invalid-parameter",
+		"title": "Invalid parameter",
+		"status": 400,
+		"detail": "One or more request parameters are invalid.",
+		"errors": [
+			{"field": "limit", "code": "out_of_range", "message": "limit must be at least 1"}
+		]
+	}`, w.Body.String())
+}
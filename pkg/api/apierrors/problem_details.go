@@ -10,12 +10,40 @@ type ProblemDetailer interface {
 	GetStatus() int
 }
 
+// Problem types the API uses besides about:blank, which means the status code says it all.
+const (
+	TypeInvalidParameter   = "https://sgf-meetup-api.opensgf.org/problems/invalid-parameter"
+	TypeInvalidCursor      = "https://sgf-meetup-api.opensgf.org/problems/invalid-cursor"
+	TypeInvalidCredentials = "https://sgf-meetup-api.opensgf.org/problems/invalid-credentials"
+)
+
+// ProblemDetails is an RFC 9457 problem. Type is about:blank or one of the API's problem types:
+// invalid-parameter when a request's parameters or body fail validation, listed in Errors,
+// invalid-cursor when a pagination cursor is malformed, and invalid-credentials when a client
+// secret or token is wrong or expired.
 type ProblemDetails struct {
 	Type     string `json:"type"`
 	Title    string `json:"title"`
 	Status   int    `json:"status,omitempty"`
 	Detail   string `json:"detail,omitempty"`
 	Instance string `json:"instance,omitempty"`
+	// Errors is an extension member listing the fields of the request at fault.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// Codes of a FieldError.
+const (
+	CodeRequired   = "required"
+	CodeInvalid    = "invalid"
+	CodeOutOfRange = "out_of_range"
+)
+
+// FieldError is a problem with one field of a request. Field is named as the client sent it, a
+// query parameter or JSON property, or body for the request body as a whole.
+type FieldError struct {
+	Field   string `json:"field"   example:"limit"`
+	Code    string `json:"code"    enums:"required,invalid,out_of_range"`
+	Message string `json:"message" example:"limit must be at least 1"`
 }
 
 func NewProblemDetails(
@@ -43,6 +71,44 @@ func NewHTTPProblemDetails(statusCode int) *ProblemDetails {
 	return NewProblemDetails(statusCode, "", "", "", "")
 }
 
+func NewInvalidParameterProblem(errs ...FieldError) *ProblemDetails {
+	pd := NewProblemDetails(
+		http.StatusBadRequest,
+		TypeInvalidParameter,
+		"Invalid parameter",
+		"One or more request parameters are invalid.",
+		"",
+	)
+	pd.Errors = errs
+	return pd
+}
+
+func NewInvalidCursorProblem(field string) *ProblemDetails {
+	pd := NewProblemDetails(
+		http.StatusBadRequest,
+		TypeInvalidCursor,
+		"Invalid cursor",
+		"The cursor is malformed. Start again from the first page.",
+		"",
+	)
+	pd.Errors = []FieldError{{
+		Field:   field,
+		Code:    CodeInvalid,
+		Message: field + " is not a cursor returned by this endpoint",
+	}}
+	return pd
+}
+
+func NewInvalidCredentialsProblem() *ProblemDetails {
+	return NewProblemDetails(
+		http.StatusUnauthorized,
+		TypeInvalidCredentials,
+		"Invalid credentials",
+		"The credentials are wrong or have expired.",
+		"",
+	)
+}
+
 func (pd *ProblemDetails) GetStatus() int {
 	return pd.Status
 }
@@ -62,6 +62,42 @@ func TestNewHTTPProblemDetails(t *testing.T) {
 	})
 }
 
+func TestNewInvalidParameterProblem(t *testing.T) {
+	pd := NewInvalidParameterProblem(FieldError{
+		Field:   "limit",
+		Code:    CodeOutOfRange,
+		Message: "limit must be at least 1",
+	})
+
+	assert.Equal(t, TypeInvalidParameter, pd.Type)
+	assert.Equal(t, "Invalid parameter", pd.Title)
+	assert.Equal(t, http.StatusBadRequest, pd.Status)
+	assert.Equal(t, []FieldError{{
+		Field:   "limit",
+		Code:    CodeOutOfRange,
+		Message: "limit must be at least 1",
+	}}, pd.Errors)
+}
+
+func TestNewInvalidCursorProblem(t *testing.T) {
+	pd := NewInvalidCursorProblem("cursor")
+
+	assert.Equal(t, TypeInvalidCursor, pd.Type)
+	assert.Equal(t, http.StatusBadRequest, pd.Status)
+	require.Len(t, pd.Errors, 1)
+	assert.Equal(t, "cursor", pd.Errors[0].Field)
+	assert.Equal(t, CodeInvalid, pd.Errors[0].Code)
+}
+
+func TestNewInvalidCredentialsProblem(t *testing.T) {
+	pd := NewInvalidCredentialsProblem()
+
+	assert.Equal(t, TypeInvalidCredentials, pd.Type)
+	assert.Equal(t, "Invalid credentials", pd.Title)
+	assert.Equal(t, http.StatusUnauthorized, pd.Status)
+	assert.Empty(t, pd.Errors)
+}
+
 func TestWriteProblemDetails(t *testing.T) {
 	t.Run("sets proper headers and body", func(t *testing.T) {
 		gin.SetMode(gin.TestMode)
@@ -84,6 +120,28 @@ func TestWriteProblemDetails(t *testing.T) {
 			"status": 418
 		}`, w.Body.String())
 	})
+
+	t.Run("includes field errors", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		WriteProblemDetails(c, &ProblemDetails{
+			Type:   TypeInvalidParameter,
+			Title:  "Invalid parameter",
+			Status: http.StatusBadRequest,
+			Errors: []FieldError{
+				{Field: "before", Code: CodeInvalid, Message: "before is invalid"},
+			},
+		})
+
+		assert.JSONEq(t, `{
+			"type": "https://sgf-meetup-api.opensgf.org/problems/invalid-parameter",
+			"title": "Invalid parameter",
+			"status": 400,
+			"errors": [{"field": "before", "code": "invalid", "message": "before is invalid"}]
+		}`, w.Body.String())
+	})
 }
 
 func TestWriteProblemDetailsFromStatus(t *testing.T) {
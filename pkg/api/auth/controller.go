@@ -39,7 +39,7 @@ func (c *Controller) auth(ctx *gin.Context) {
 	requestDTO := authRequestDTO{}
 
 	if err := ctx.ShouldBindJSON(&requestDTO); err != nil {
-		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
+		apierrors.WriteBindingProblem(ctx, &requestDTO, err)
 		return
 	}
 
@@ -50,7 +50,7 @@ func (c *Controller) auth(ctx *gin.Context) {
 	)
 
 	if errors.Is(err, ErrInvalidCredentials) {
-		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusUnauthorized)
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidCredentialsProblem())
 		return
 	}
 
@@ -81,14 +81,14 @@ func (c *Controller) refresh(ctx *gin.Context) {
 	requestDTO := refreshTokenRequestDTO{}
 
 	if err := ctx.ShouldBindJSON(&requestDTO); err != nil {
-		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
+		apierrors.WriteBindingProblem(ctx, &requestDTO, err)
 		return
 	}
 
 	result, err := c.service.RefreshCredentials(ctx, requestDTO.RefreshToken)
 
 	if errors.Is(err, ErrInvalidCredentials) {
-		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusUnauthorized)
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidCredentialsProblem())
 		return
 	}
 
@@ -112,4 +112,6 @@ var Providers = wire.NewSet(
 	NewService,
 	NewController,
 	NewMiddleware,
+	NewScopeMiddleware,
+	NewOriginMiddleware,
 )
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"sgf-meetup-api/pkg/api/apierrors"
 	"sgf-meetup-api/pkg/infra"
 	"sgf-meetup-api/pkg/shared/clock"
 	"sgf-meetup-api/pkg/shared/db"
@@ -98,7 +99,10 @@ func TestController_Integration(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		var problem apierrors.ProblemDetails
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, apierrors.TypeInvalidCredentials, problem.Type)
 	})
 
 	t.Run("POST /auth handles invalid client secret for valid client id", func(t *testing.T) {
@@ -127,6 +131,22 @@ func TestController_Integration(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
+	t.Run("POST /auth names missing fields", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/auth", bytes.NewBufferString(`{"clientId":"id"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var problem apierrors.ProblemDetails
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, apierrors.TypeInvalidParameter, problem.Type)
+		assert.Equal(t, []apierrors.FieldError{{
+			Field:   "clientSecret",
+			Code:    apierrors.CodeRequired,
+			Message: "clientSecret is required",
+		}}, problem.Errors)
+	})
+
 	t.Run("POST /auth/refresh refreshes token", func(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 
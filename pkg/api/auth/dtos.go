@@ -3,12 +3,12 @@ package auth
 import "time"
 
 type authRequestDTO struct {
-	ClientID     string `json:"clientId"`
-	ClientSecret string `json:"clientSecret"`
+	ClientID     string `json:"clientId"     binding:"required"`
+	ClientSecret string `json:"clientSecret" binding:"required"`
 }
 
 type refreshTokenRequestDTO struct {
-	RefreshToken string `json:"refreshToken"`
+	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
 type authResponseDTO struct {
@@ -6,12 +6,17 @@ import (
 	"strings"
 
 	"sgf-meetup-api/pkg/api/apierrors"
+	"sgf-meetup-api/pkg/shared/models"
 
 	"github.com/gin-gonic/gin"
 )
 
 const ClientIDKey = "clientId"
 
+// apiUserKey holds the authenticated API user once a middleware has loaded it, so the user is
+// read once per request however many middleware need it.
+const apiUserKey = "apiUser"
+
 type Middleware struct {
 	tokenValidator TokenManager
 }
@@ -40,7 +45,7 @@ func (m *Middleware) Handler(ctx *gin.Context) {
 	token, err := m.tokenValidator.Validate(tokenParts[1])
 
 	if errors.Is(err, ErrInvalidCredentials) {
-		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusUnauthorized)
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidCredentialsProblem())
 		ctx.Abort()
 		return
 	}
@@ -54,3 +59,39 @@ func (m *Middleware) Handler(ctx *gin.Context) {
 	ctx.Set(ClientIDKey, token.ClientID)
 	ctx.Next()
 }
+
+// requireAPIUser returns the authenticated request's API user, loading it on first use. It
+// writes the problem response and aborts when there isn't one. Middleware.Handler must run
+// first.
+func requireAPIUser(
+	ctx *gin.Context,
+	apiUserRepository APIUserRepository,
+) (*models.APIUser, bool) {
+	if user, ok := ctx.Get(apiUserKey); ok {
+		return user.(*models.APIUser), true
+	}
+
+	clientID := ctx.GetString(ClientIDKey)
+	if clientID == "" {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusUnauthorized)
+		ctx.Abort()
+		return nil, false
+	}
+
+	user, err := apiUserRepository.GetAPIUser(ctx, clientID)
+
+	if errors.Is(err, ErrAPIUserNotFound) {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusUnauthorized)
+		ctx.Abort()
+		return nil, false
+	}
+
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		ctx.Abort()
+		return nil, false
+	}
+
+	ctx.Set(apiUserKey, user)
+	return user, true
+}
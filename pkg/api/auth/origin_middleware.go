@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/http"
+
+	"sgf-meetup-api/pkg/api/apierrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OriginMiddleware rejects browser requests from origins the API user isn't allowed to use,
+// which keeps a site's public credentials from being reused by other sites. Requests without
+// an Origin header are let through, since only browsers send one. The user is shared with
+// ScopeMiddleware through the request context. It must run after Middleware.Handler.
+type OriginMiddleware struct {
+	apiUserRepository APIUserRepository
+}
+
+func NewOriginMiddleware(apiUserRepository APIUserRepository) *OriginMiddleware {
+	return &OriginMiddleware{
+		apiUserRepository: apiUserRepository,
+	}
+}
+
+func (m *OriginMiddleware) Handler(ctx *gin.Context) {
+	origin := ctx.GetHeader("Origin")
+	if origin == "" {
+		ctx.Next()
+		return
+	}
+
+	user, ok := requireAPIUser(ctx, m.apiUserRepository)
+	if !ok {
+		return
+	}
+
+	if !user.AllowsOrigin(origin) {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusForbidden)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginMiddleware_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &stubAPIUserRepository{users: map[string]*models.APIUser{
+		"website":  {ClientID: "website", AllowedOrigins: []string{"https://opensgf.org"}},
+		"consumer": {ClientID: "consumer"},
+	}}
+	middleware := NewOriginMiddleware(repo)
+
+	serve := func(
+		middleware *OriginMiddleware,
+		clientID, origin string,
+	) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if origin != "" {
+			c.Request.Header.Set("Origin", origin)
+		}
+		if clientID != "" {
+			c.Set(ClientIDKey, clientID)
+		}
+		middleware.Handler(c)
+		return w, c
+	}
+
+	t.Run("should allow the user's origins", func(t *testing.T) {
+		w, c := serve(middleware, "website", "https://opensgf.org")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.IsAborted())
+	})
+
+	t.Run("should return 403 for other origins", func(t *testing.T) {
+		w, c := serve(middleware, "website", "https://example.com")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("should allow any origin for users without origins", func(t *testing.T) {
+		w, c := serve(middleware, "consumer", "https://example.com")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.IsAborted())
+	})
+
+	t.Run("should allow requests without an origin", func(t *testing.T) {
+		failing := NewOriginMiddleware(&stubAPIUserRepository{err: errors.New("db error")})
+
+		w, c := serve(failing, "website", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.IsAborted())
+	})
+
+	t.Run("should return 401 when user does not exist", func(t *testing.T) {
+		w, c := serve(middleware, "deleted", "https://opensgf.org")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("should share the user with the scope middleware", func(t *testing.T) {
+		repo := &stubAPIUserRepository{users: map[string]*models.APIUser{
+			"admin": {
+				ClientID:       "admin",
+				AllowedOrigins: []string{"https://opensgf.org"},
+				Scopes:         []string{models.APIUserScopeAdmin},
+			},
+		}}
+
+		w, c := serve(NewOriginMiddleware(repo), "admin", "https://opensgf.org")
+		NewScopeMiddleware(repo).Require(models.APIUserScopeAdmin)(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.IsAborted())
+		assert.Equal(t, 1, repo.reads)
+	})
+
+	t.Run("should return 500 when lookup fails", func(t *testing.T) {
+		failing := NewOriginMiddleware(&stubAPIUserRepository{err: errors.New("db error")})
+
+		w, c := serve(failing, "website", "https://opensgf.org")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+}
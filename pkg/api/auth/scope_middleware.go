@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"net/http"
+
+	"sgf-meetup-api/pkg/api/apierrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScopeMiddleware restricts routes to API users granted a scope. Scopes are read from the user
+// record on each request rather than the token, so revoking a scope takes effect immediately.
+// The record is shared with the other middleware through the request context. It must run
+// after Middleware.Handler.
+type ScopeMiddleware struct {
+	apiUserRepository APIUserRepository
+}
+
+func NewScopeMiddleware(apiUserRepository APIUserRepository) *ScopeMiddleware {
+	return &ScopeMiddleware{
+		apiUserRepository: apiUserRepository,
+	}
+}
+
+func (m *ScopeMiddleware) Require(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := requireAPIUser(ctx, m.apiUserRepository)
+		if !ok {
+			return
+		}
+
+		if !user.HasScope(scope) {
+			apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusForbidden)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
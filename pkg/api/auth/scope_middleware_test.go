@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAPIUserRepository struct {
+	users map[string]*models.APIUser
+	err   error
+	reads int
+}
+
+func (r *stubAPIUserRepository) GetAPIUser(
+	_ context.Context,
+	clientID string,
+) (*models.APIUser, error) {
+	r.reads++
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	user, ok := r.users[clientID]
+	if !ok {
+		return nil, ErrAPIUserNotFound
+	}
+
+	return user, nil
+}
+
+func TestScopeMiddleware_Require(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &stubAPIUserRepository{users: map[string]*models.APIUser{
+		"admin":    {ClientID: "admin", Scopes: []string{models.APIUserScopeAdmin}},
+		"consumer": {ClientID: "consumer"},
+	}}
+	handler := NewScopeMiddleware(repo).Require(models.APIUserScopeAdmin)
+
+	serve := func(clientID string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if clientID != "" {
+			c.Set(ClientIDKey, clientID)
+		}
+		handler(c)
+		return w, c
+	}
+
+	t.Run("should allow users with the scope", func(t *testing.T) {
+		w, c := serve("admin")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.IsAborted())
+	})
+
+	t.Run("should return 403 when user lacks the scope", func(t *testing.T) {
+		w, c := serve("consumer")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("should return 401 when user does not exist", func(t *testing.T) {
+		w, c := serve("deleted")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("should return 401 when client ID is not set", func(t *testing.T) {
+		w, c := serve("")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+
+	t.Run("should reuse the user loaded earlier in the request", func(t *testing.T) {
+		repo := &stubAPIUserRepository{users: map[string]*models.APIUser{
+			"admin": {ClientID: "admin", Scopes: []string{models.APIUserScopeAdmin}},
+		}}
+		middleware := NewScopeMiddleware(repo)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Set(ClientIDKey, "admin")
+		middleware.Require(models.APIUserScopeAdmin)(c)
+		middleware.Require(models.APIUserScopeAdmin)(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, repo.reads)
+	})
+
+	t.Run("should return 500 when lookup fails", func(t *testing.T) {
+		failingHandler := NewScopeMiddleware(
+			&stubAPIUserRepository{err: errors.New("db error")},
+		).Require(models.APIUserScopeAdmin)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Set(ClientIDKey, "admin")
+		failingHandler(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.True(t, c.IsAborted())
+	})
+}
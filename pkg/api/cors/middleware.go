@@ -0,0 +1,149 @@
+package cors
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+	"sgf-meetup-api/pkg/api/apierrors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// allowedMethods are the methods the API's routes use.
+var allowedMethods = []string{http.MethodGet, http.MethodPost}
+
+type MiddlewareConfig struct {
+	AllowedOrigins   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+func NewMiddlewareConfig(config *apiconfig.Config) MiddlewareConfig {
+	return MiddlewareConfig{
+		AllowedOrigins:   config.CORSAllowedOrigins,
+		AllowedHeaders:   config.CORSAllowedHeaders,
+		ExposedHeaders:   config.CORSExposedHeaders,
+		AllowCredentials: config.CORSAllowCredentials,
+		MaxAge:           config.CORSMaxAge,
+	}
+}
+
+// Middleware adds CORS headers for allowed origins and answers preflight requests. It has to be
+// used on the engine rather than a group, so it also runs for OPTIONS requests, which match no
+// route. API Gateway's proxy integration passes those through to the function as well.
+type Middleware struct {
+	config MiddlewareConfig
+}
+
+func NewMiddleware(config MiddlewareConfig) *Middleware {
+	return &Middleware{
+		config: config,
+	}
+}
+
+func (m *Middleware) Handler(ctx *gin.Context) {
+	if len(m.config.AllowedOrigins) == 0 {
+		ctx.Next()
+		return
+	}
+
+	header := ctx.Writer.Header()
+	preflight := ctx.Request.Method == http.MethodOptions &&
+		ctx.GetHeader("Access-Control-Request-Method") != ""
+
+	if !m.anyOrigin() {
+		header.Add("Vary", "Origin")
+	}
+	if preflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	origin := ctx.GetHeader("Origin")
+	if origin == "" {
+		ctx.Next()
+		return
+	}
+
+	if !m.allowsOrigin(origin) {
+		if preflight {
+			apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusForbidden)
+			ctx.Abort()
+			return
+		}
+
+		// Without CORS headers the browser hides the response, while other clients are unaffected.
+		ctx.Next()
+		return
+	}
+
+	if m.anyOrigin() {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if m.config.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if len(m.config.ExposedHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(m.config.ExposedHeaders, ", "))
+		}
+		ctx.Next()
+		return
+	}
+
+	if !slices.Contains(allowedMethods, ctx.GetHeader("Access-Control-Request-Method")) ||
+		!m.allowsHeaders(ctx.GetHeader("Access-Control-Request-Headers")) {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusForbidden)
+		ctx.Abort()
+		return
+	}
+
+	header.Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ", "))
+	if len(m.config.AllowedHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(m.config.AllowedHeaders, ", "))
+	}
+	header.Set("Access-Control-Max-Age", strconv.Itoa(int(m.config.MaxAge.Seconds())))
+
+	ctx.AbortWithStatus(http.StatusNoContent)
+}
+
+func (m *Middleware) anyOrigin() bool {
+	return slices.Contains(m.config.AllowedOrigins, "*")
+}
+
+func (m *Middleware) allowsOrigin(origin string) bool {
+	return m.anyOrigin() || slices.Contains(m.config.AllowedOrigins, origin)
+}
+
+// allowsHeaders reports whether every header in a preflight's Access-Control-Request-Headers
+// is allowed. Header names are case-insensitive.
+func (m *Middleware) allowsHeaders(requested string) bool {
+	for name := range strings.SplitSeq(requested, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if !slices.ContainsFunc(m.config.AllowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, name)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+var Providers = wire.NewSet(
+	NewMiddlewareConfig,
+	NewMiddleware,
+)
@@ -0,0 +1,167 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMiddlewareConfig(t *testing.T) {
+	cfg := &apiconfig.Config{
+		CORSAllowedOrigins:   []string{"https://opensgf.org"},
+		CORSAllowedHeaders:   []string{"Authorization"},
+		CORSExposedHeaders:   []string{"ETag"},
+		CORSAllowCredentials: true,
+		CORSMaxAge:           time.Hour,
+	}
+
+	middlewareConfig := NewMiddlewareConfig(cfg)
+
+	assert.Equal(t, cfg.CORSAllowedOrigins, middlewareConfig.AllowedOrigins)
+	assert.Equal(t, cfg.CORSAllowedHeaders, middlewareConfig.AllowedHeaders)
+	assert.Equal(t, cfg.CORSExposedHeaders, middlewareConfig.ExposedHeaders)
+	assert.Equal(t, cfg.CORSAllowCredentials, middlewareConfig.AllowCredentials)
+	assert.Equal(t, cfg.CORSMaxAge, middlewareConfig.MaxAge)
+}
+
+func TestMiddleware_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	config := MiddlewareConfig{
+		AllowedOrigins: []string{"https://opensgf.org"},
+		AllowedHeaders: []string{"Authorization", "If-None-Match"},
+		ExposedHeaders: []string{"ETag"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	newRouter := func(config MiddlewareConfig) *gin.Engine {
+		router := gin.New()
+		router.Use(NewMiddleware(config).Handler)
+		router.GET("/events", func(ctx *gin.Context) {
+			ctx.Status(http.StatusOK)
+		})
+		return router
+	}
+	router := newRouter(config)
+
+	serve := func(
+		router *gin.Engine,
+		method string,
+		headers map[string]string,
+	) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/events", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	preflight := func(origin, method, headers string) map[string]string {
+		return map[string]string{
+			"Origin":                         origin,
+			"Access-Control-Request-Method":  method,
+			"Access-Control-Request-Headers": headers,
+		}
+	}
+
+	t.Run("answers preflight requests from allowed origins", func(t *testing.T) {
+		w := serve(router, http.MethodOptions, preflight(
+			"https://opensgf.org",
+			http.MethodGet,
+			"authorization, if-none-match",
+		))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://opensgf.org", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(
+			t,
+			"Authorization, If-None-Match",
+			w.Header().Get("Access-Control-Allow-Headers"),
+		)
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Contains(t, w.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("rejects preflight requests it can't allow", func(t *testing.T) {
+		tests := map[string]map[string]string{
+			"origin":  preflight("https://example.com", http.MethodGet, ""),
+			"method":  preflight("https://opensgf.org", http.MethodDelete, ""),
+			"headers": preflight("https://opensgf.org", http.MethodGet, "X-Custom"),
+		}
+
+		for name, headers := range tests {
+			t.Run(name, func(t *testing.T) {
+				w := serve(router, http.MethodOptions, headers)
+
+				assert.Equal(t, http.StatusForbidden, w.Code)
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+			})
+		}
+	})
+
+	t.Run("adds headers to requests from allowed origins", func(t *testing.T) {
+		w := serve(router, http.MethodGet, map[string]string{"Origin": "https://opensgf.org"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://opensgf.org", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "ETag", w.Header().Get("Access-Control-Expose-Headers"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("leaves out headers for other origins", func(t *testing.T) {
+		w := serve(router, http.MethodGet, map[string]string{"Origin": "https://example.com"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("allows credentials when configured", func(t *testing.T) {
+		credentialsConfig := config
+		credentialsConfig.AllowCredentials = true
+
+		w := serve(
+			newRouter(credentialsConfig),
+			http.MethodGet,
+			map[string]string{"Origin": "https://opensgf.org"},
+		)
+
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("allows any origin with *", func(t *testing.T) {
+		anyConfig := config
+		anyConfig.AllowedOrigins = []string{"*"}
+
+		w := serve(
+			newRouter(anyConfig),
+			http.MethodOptions,
+			preflight("https://example.com", http.MethodGet, "Authorization"),
+		)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.NotContains(t, w.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("does nothing without allowed origins", func(t *testing.T) {
+		w := serve(
+			newRouter(MiddlewareConfig{}),
+			http.MethodOptions,
+			preflight("https://opensgf.org", http.MethodGet, ""),
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
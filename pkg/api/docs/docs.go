@@ -15,6 +15,127 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/v1/admin/imports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get recent import runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of runs, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/importruns.importRunsResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts an import in the background. Omit groups to import every configured group.\nImports of unknown or disabled groups fail and show in the import history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Trigger an import",
+                "parameters": [
+                    {
+                        "description": "Groups to import",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/importruns.triggerImportRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/importruns.triggerImportResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/auth": {
             "post": {
                 "consumes": [
@@ -159,6 +280,20 @@ const docTemplate = `{
                         "name": "after",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter events ending before this timestamp",
+                        "name": "endsBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter events ending after this timestamp",
+                        "name": "endsAfter",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Pagination cursor",
@@ -170,6 +305,29 @@ const docTemplate = `{
                         "description": "Maximum number of results",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Description format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of cached copies",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -177,6 +335,37 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/groupevents.groupEventsResponseDTO"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
                         }
                     },
                     "400": {
@@ -225,6 +414,29 @@ const docTemplate = `{
                         "name": "groupId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Description format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of cached copies",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -232,6 +444,37 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/groupevents.eventDTO"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
                         }
                     },
                     "400": {
@@ -293,6 +536,29 @@ const docTemplate = `{
                         "name": "eventId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Description format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of cached copies",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -300,6 +566,37 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/groupevents.eventDTO"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
                         }
                     },
                     "400": {
@@ -328,68 +625,423 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apierrors.ProblemDetails": {
-            "type": "object",
-            "properties": {
-                "detail": {
-                    "type": "string"
-                },
-                "instance": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "type": {
-                    "type": "string"
-                }
-            }
-        },
-        "auth.authRequestDTO": {
-            "type": "object",
-            "properties": {
-                "clientId": {
-                    "type": "string"
-                },
-                "clientSecret": {
-                    "type": "string"
-                }
-            }
         },
-        "auth.authResponseDTO": {
-            "type": "object",
-            "properties": {
-                "accessToken": {
-                    "type": "string"
-                },
-                "accessTokenExpiresAt": {
-                    "type": "string"
-                },
-                "refreshToken": {
-                    "type": "string"
-                },
-                "refreshTokenExpiresAt": {
-                    "type": "string"
+        "/v1/groups/{groupId}/sync-status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports when the group's events were last refreshed from Meetup.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "groupevents"
+                ],
+                "summary": "Get group sync status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/importruns.syncStatusDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    }
                 }
             }
         },
-        "auth.refreshTokenRequestDTO": {
-            "type": "object",
-            "properties": {
-                "refreshToken": {
-                    "type": "string"
+        "/v1/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "groupevents"
+                ],
+                "summary": "Get event series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return series of this group",
+                        "name": "groupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of cached copies",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/groupevents.seriesResponseDTO"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/series/{seriesId}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "groupevents"
+                ],
+                "summary": "Get events of a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "seriesId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter events before this timestamp",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter events after this timestamp",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter events ending before this timestamp",
+                        "name": "endsBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter events ending after this timestamp",
+                        "name": "endsAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Description format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of cached copies",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/groupevents.groupEventsResponseDTO"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "How long the response may be reused"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the response"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the import that last changed the group, left out of upcoming events"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierrors.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apierrors.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "enum": [
+                        "required",
+                        "invalid",
+                        "out_of_range"
+                    ]
+                },
+                "field": {
+                    "type": "string",
+                    "example": "limit"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "limit must be at least 1"
+                }
+            }
+        },
+        "apierrors.ProblemDetails": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Errors is an extension member listing the fields of the request at fault.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apierrors.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.authRequestDTO": {
+            "type": "object",
+            "required": [
+                "clientId",
+                "clientSecret"
+            ],
+            "properties": {
+                "clientId": {
+                    "type": "string"
+                },
+                "clientSecret": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.authResponseDTO": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "accessTokenExpiresAt": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "refreshTokenExpiresAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.refreshTokenRequestDTO": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
                 }
             }
         },
         "groupevents.eventDTO": {
             "type": "object",
             "properties": {
+                "attendance": {
+                    "type": "string",
+                    "enum": [
+                        "in_person",
+                        "online",
+                        "hybrid"
+                    ]
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "coHosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
                 "dateTime": {
                     "type": "string"
                 },
@@ -399,15 +1051,30 @@ const docTemplate = `{
                 "duration": {
                     "type": "string"
                 },
+                "durationMinutes": {
+                    "type": "integer"
+                },
+                "endDateTime": {
+                    "type": "string"
+                },
                 "eventUrl": {
                     "type": "string"
                 },
+                "fee": {
+                    "$ref": "#/definitions/groupevents.feeDTO"
+                },
                 "group": {
                     "$ref": "#/definitions/groupevents.groupDTO"
                 },
                 "host": {
                     "$ref": "#/definitions/groupevents.hostDTO"
                 },
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/groupevents.hostDTO"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
@@ -417,14 +1084,55 @@ const docTemplate = `{
                         "$ref": "#/definitions/groupevents.imageDTO"
                     }
                 },
+                "isOnline": {
+                    "type": "boolean"
+                },
+                "onlineUrl": {
+                    "type": "string"
+                },
+                "rsvpCount": {
+                    "type": "integer"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string"
                 },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
                 "venue": {
                     "$ref": "#/definitions/groupevents.venueDTO"
                 }
             }
         },
+        "groupevents.feeDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
         "groupevents.groupDTO": {
             "type": "object",
             "properties": {
@@ -466,6 +1174,54 @@ const docTemplate = `{
                 },
                 "preview": {
                     "type": "string"
+                },
+                "sizes": {
+                    "description": "Sizes maps thumbnail, small, medium and large to URLs of the image at that size.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "groupevents.seriesDTO": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "$ref": "#/definitions/groupevents.groupDTO"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "meetup",
+                        "detected"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "venueName": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "string"
+                }
+            }
+        },
+        "groupevents.seriesResponseDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/groupevents.seriesDTO"
+                    }
                 }
             }
         },
@@ -478,6 +1234,12 @@ const docTemplate = `{
                 "city": {
                     "type": "string"
                 },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -488,6 +1250,173 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "importruns.groupImportRunDTO": {
+            "type": "object",
+            "properties": {
+                "anomalies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importruns.importAnomalyDTO"
+                    }
+                },
+                "archived": {
+                    "type": "integer"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fetched": {
+                    "type": "integer"
+                },
+                "groupId": {
+                    "type": "string"
+                },
+                "meetupPages": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/importruns.importRunStatus"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "upserted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importruns.importAnomalyDTO": {
+            "type": "object",
+            "properties": {
+                "eventIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "importruns.importRunDTO": {
+            "type": "object",
+            "properties": {
+                "anomalies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importruns.importAnomalyDTO"
+                    }
+                },
+                "archived": {
+                    "type": "integer"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fetched": {
+                    "type": "integer"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importruns.groupImportRunDTO"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meetupPages": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/importruns.importRunStatus"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "upserted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importruns.importRunStatus": {
+            "type": "string",
+            "enum": [
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "importRunStatusSucceeded",
+                "importRunStatusFailed"
+            ]
+        },
+        "importruns.importRunsResponseDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importruns.importRunDTO"
+                    }
+                }
+            }
+        },
+        "importruns.syncStatusDTO": {
+            "type": "object",
+            "properties": {
+                "groupId": {
+                    "type": "string"
+                },
+                "lastAttemptStatus": {
+                    "$ref": "#/definitions/importruns.importRunStatus"
+                },
+                "lastAttemptedAt": {
+                    "type": "string"
+                },
+                "lastRefreshedAt": {
+                    "description": "LastRefreshedAt is when the group's data was last successfully imported. It is null when\nnone of the recent imports succeeded.",
+                    "type": "string"
+                }
+            }
+        },
+        "importruns.triggerImportRequestDTO": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "importruns.triggerImportResponseDTO": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
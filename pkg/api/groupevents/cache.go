@@ -0,0 +1,151 @@
+package groupevents
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/api/httpcache"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagFormat is part of every version ETag, so a change to what responses hold can invalidate
+// cached copies before the next import does.
+const etagFormat = "1"
+
+// groupCache is what decides whether a client's copy of a group's response is current. Upcoming
+// responses also depend on the time, since events drop out of them once they start.
+type groupCache struct {
+	version  *models.GroupVersion
+	upcoming bool
+}
+
+// versionETag identifies a response by the group version, the request's path and query, and
+// for upcoming responses the time it stops being valid. That time is kept after the hash so a
+// later request can tell whether the tag has expired without querying the events.
+func versionETag(version *models.GroupVersion, r *http.Request, validUntil time.Time) string {
+	var expires int64
+	if !validUntil.IsZero() {
+		expires = validUntil.Unix()
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{
+		etagFormat,
+		version.Version,
+		r.URL.Path,
+		r.URL.Query().Encode(),
+		strconv.FormatInt(expires, 10),
+	} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + "." + strconv.FormatInt(expires, 10) + `"`
+}
+
+// matchVersionETag returns the tag in If-None-Match that versionETag would still give the
+// request, skipping those that have expired by now.
+func matchVersionETag(r *http.Request, version *models.GroupVersion, now time.Time) (string, bool) {
+	for _, tag := range httpcache.IfNoneMatch(r) {
+		tag = strings.TrimPrefix(tag, "W/")
+
+		_, expiresStr, ok := strings.Cut(strings.Trim(tag, `"`), ".")
+		if !ok {
+			continue
+		}
+
+		expires, err := strconv.ParseInt(expiresStr, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		var validUntil time.Time
+		if expires != 0 {
+			validUntil = time.Unix(expires, 0)
+			if !now.Before(validUntil) {
+				continue
+			}
+		}
+
+		if tag == versionETag(version, r, validUntil) {
+			return tag, true
+		}
+	}
+
+	return "", false
+}
+
+// upcomingOnly reports whether the filters fall back to upcoming events.
+func (f PaginatedEventsFilters) upcomingOnly() bool {
+	return f.Before == nil && f.After == nil && f.EndsBefore == nil && f.EndsAfter == nil
+}
+
+// groupVersion returns nil when the group has no version, leaving the response to the ETag
+// httpcache.Middleware derives from its body.
+func (c *Controller) groupVersion(ctx *gin.Context, groupID string) (*models.GroupVersion, error) {
+	version, err := c.groupVersionRepo.GroupVersion(ctx, groupID)
+	if errors.Is(err, ErrGroupVersionNotFound) {
+		return nil, nil
+	}
+	return version, err
+}
+
+// notModified answers with a 304 when the group version shows the client's copy is current,
+// which saves querying the events.
+func (c *Controller) notModified(ctx *gin.Context, cache groupCache) bool {
+	if cache.version == nil {
+		return false
+	}
+
+	if cache.upcoming {
+		tag, ok := matchVersionETag(ctx.Request, cache.version, c.timeSource.Now())
+		if ok {
+			httpcache.WriteNotModified(ctx, httpcache.Validators{ETag: tag})
+		}
+		return ok
+	}
+
+	validators := c.validators(ctx, cache, time.Time{})
+	if httpcache.NotModified(ctx.Request, validators) {
+		httpcache.WriteNotModified(ctx, validators)
+		return true
+	}
+	return false
+}
+
+// setValidators sets the ETag and Last-Modified of a response built from the group's events.
+// Upcoming responses go without Last-Modified, as they change without an import.
+func (c *Controller) setValidators(ctx *gin.Context, cache groupCache, validUntil time.Time) {
+	if cache.version == nil {
+		return
+	}
+	c.validators(ctx, cache, validUntil).Set(ctx)
+}
+
+func (c *Controller) validators(
+	ctx *gin.Context,
+	cache groupCache,
+	validUntil time.Time,
+) httpcache.Validators {
+	validators := httpcache.Validators{ETag: versionETag(cache.version, ctx.Request, validUntil)}
+	if !cache.upcoming {
+		validators.LastModified = cache.version.UpdatedAt
+	}
+	return validators
+}
+
+// upcomingValidUntil returns when the first of the upcoming events starts and drops out of the
+// response.
+func upcomingValidUntil(events []models.MeetupEvent) time.Time {
+	if len(events) == 0 || events[0].DateTime == nil {
+		return time.Time{}
+	}
+	return events[0].DateTime.Time
+}
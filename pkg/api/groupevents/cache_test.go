@@ -0,0 +1,73 @@
+package groupevents
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionETag(t *testing.T) {
+	version := &models.GroupVersion{GroupID: "group", Version: "run1"}
+	validUntil := time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC)
+	req := httptest.NewRequest(http.MethodGet, "/groups/group/events?limit=5", nil)
+
+	etag := versionETag(version, req, validUntil)
+
+	assert.Regexp(t, `^"[0-9a-f]{32}\.1744480800"$`, etag)
+	assert.Equal(t, etag, versionETag(version, req, validUntil))
+	assert.NotEqual(t, etag, versionETag(&models.GroupVersion{Version: "run2"}, req, validUntil))
+	assert.NotEqual(t, etag, versionETag(version, req, time.Time{}))
+
+	other := httptest.NewRequest(http.MethodGet, "/groups/group/events?limit=10", nil)
+	assert.NotEqual(t, etag, versionETag(version, other, validUntil))
+}
+
+func TestMatchVersionETag(t *testing.T) {
+	version := &models.GroupVersion{GroupID: "group", Version: "run1"}
+	validUntil := time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC)
+
+	request := func(ifNoneMatch string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/groups/group/events", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		return req
+	}
+
+	etag := versionETag(version, request(""), validUntil)
+
+	t.Run("matches until the ETag expires", func(t *testing.T) {
+		tag, ok := matchVersionETag(request(`"other", `+etag), version, validUntil.Add(-time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, etag, tag)
+
+		_, ok = matchVersionETag(request(etag), version, validUntil)
+		assert.False(t, ok)
+	})
+
+	t.Run("matches weak ETags", func(t *testing.T) {
+		tag, ok := matchVersionETag(request("W/"+etag), version, validUntil.Add(-time.Hour))
+		assert.True(t, ok)
+		assert.Equal(t, etag, tag)
+	})
+
+	t.Run("ETags without an expiry don't expire", func(t *testing.T) {
+		etag := versionETag(version, request(""), time.Time{})
+
+		_, ok := matchVersionETag(request(etag), version, validUntil.AddDate(1, 0, 0))
+		assert.True(t, ok)
+	})
+
+	t.Run("ignores other versions and malformed ETags", func(t *testing.T) {
+		other := &models.GroupVersion{GroupID: "group", Version: "run2"}
+
+		_, ok := matchVersionETag(request(etag), other, validUntil.Add(-time.Hour))
+		assert.False(t, ok)
+
+		_, ok = matchVersionETag(request(`"abc", "abc.def", *`), version, validUntil)
+		assert.False(t, ok)
+	})
+}
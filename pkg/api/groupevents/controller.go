@@ -10,6 +10,8 @@ import (
 
 	"sgf-meetup-api/pkg/api/apiconfig"
 	"sgf-meetup-api/pkg/api/apierrors"
+	"sgf-meetup-api/pkg/api/httpcache"
+	"sgf-meetup-api/pkg/shared/clock"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
@@ -26,30 +28,52 @@ func NewControllerConfig(config *apiconfig.Config) ControllerConfig {
 }
 
 type Controller struct {
-	config         ControllerConfig
-	groupEventRepo GroupEventRepository
+	config           ControllerConfig
+	groupEventRepo   GroupEventRepository
+	seriesRepo       SeriesRepository
+	groupVersionRepo GroupVersionRepository
+	timeSource       clock.TimeSource
 }
 
 const (
-	groupIDKey = "groupId"
-	eventIDKey = "eventId"
-	cursorKey  = "cursor"
-	limitKey   = "limit"
-	beforeKey  = "before"
-	afterKey   = "after"
+	groupIDKey    = "groupId"
+	eventIDKey    = "eventId"
+	seriesIDKey   = "seriesId"
+	cursorKey     = "cursor"
+	limitKey      = "limit"
+	beforeKey     = "before"
+	afterKey      = "after"
+	endsBeforeKey = "endsBefore"
+	endsAfterKey  = "endsAfter"
+	formatKey     = "format"
 )
 
-func NewController(config ControllerConfig, groupEventRepo GroupEventRepository) *Controller {
+func NewController(
+	config ControllerConfig,
+	groupEventRepo GroupEventRepository,
+	seriesRepo SeriesRepository,
+	groupVersionRepo GroupVersionRepository,
+	timeSource clock.TimeSource,
+) *Controller {
 	return &Controller{
-		config:         config,
-		groupEventRepo: groupEventRepo,
+		config:           config,
+		groupEventRepo:   groupEventRepo,
+		seriesRepo:       seriesRepo,
+		groupVersionRepo: groupVersionRepo,
+		timeSource:       timeSource,
 	}
 }
 
 func (c *Controller) RegisterRoutes(r gin.IRouter) {
-	r.GET("/groups/:"+groupIDKey+"/events", c.groupEvents)
-	r.GET("/groups/:"+groupIDKey+"/events/next", c.nextGroupEvent)
-	r.GET("/groups/:"+groupIDKey+"/events/:"+eventIDKey, c.groupEventByID)
+	// Listings change as events start, so clients revalidate them sooner.
+	listings := httpcache.Middleware(httpcache.Policy{MaxAge: time.Minute})
+	details := httpcache.Middleware(httpcache.Policy{MaxAge: 5 * time.Minute})
+
+	r.GET("/groups/:"+groupIDKey+"/events", listings, c.groupEvents)
+	r.GET("/groups/:"+groupIDKey+"/events/next", listings, c.nextGroupEvent)
+	r.GET("/groups/:"+groupIDKey+"/events/:"+eventIDKey, details, c.groupEventByID)
+	r.GET("/series", details, c.series)
+	r.GET("/series/:"+seriesIDKey+"/events", listings, c.seriesEvents)
 }
 
 // @Summary	Get group events
@@ -57,15 +81,24 @@ func (c *Controller) RegisterRoutes(r gin.IRouter) {
 // @Security	BearerAuth
 // @Accept		json
 // @Produce	json,application/problem+json
-// @Param		groupId	path		string	true	"Group ID"
-// @Param		before	query		string	false	"Filter events before this timestamp"	Format(date-time)
-// @Param		after	query		string	false	"Filter events after this timestamp"	Format(date-time)
-// @Param		cursor	query		string	false	"Pagination cursor"
-// @Param		limit	query		integer	false	"Maximum number of results"
-// @Success	200		{object}	groupEventsResponseDTO
-// @Failure	400		{object}	apierrors.ProblemDetails	"Invalid input"
-// @Failure	401		{object}	apierrors.ProblemDetails	"Unauthorized"
-// @Failure	500		{object}	apierrors.ProblemDetails	"Server error"
+// @Param		groupId				path		string	true	"Group ID"
+// @Param		before				query		string	false	"Filter events before this timestamp"			Format(date-time)
+// @Param		after				query		string	false	"Filter events after this timestamp"			Format(date-time)
+// @Param		endsBefore			query		string	false	"Filter events ending before this timestamp"	Format(date-time)
+// @Param		endsAfter			query		string	false	"Filter events ending after this timestamp"		Format(date-time)
+// @Param		cursor				query		string	false	"Pagination cursor"
+// @Param		limit				query		integer	false	"Maximum number of results"
+// @Param		format				query		string	false	"Description format"	Enums(markdown, html, text)
+// @Param		If-None-Match		header		string	false	"ETags of cached copies"
+// @Param		If-Modified-Since	header		string	false	"Last-Modified of a cached copy"
+// @Success	200					{object}	groupEventsResponseDTO
+// @Success	304					"Not modified"
+// @Header		200,304				{string}	ETag						"Entity tag of the response"
+// @Header		200,304				{string}	Last-Modified				"Time of the import that last changed the group, left out of upcoming events"
+// @Header		200,304				{string}	Cache-Control				"How long the response may be reused"
+// @Failure	400					{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure	401					{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure	500					{object}	apierrors.ProblemDetails	"Server error"
 // @Router		/v1/groups/{groupId}/events [get]
 func (c *Controller) groupEvents(ctx *gin.Context) {
 	ctx.FullPath()
@@ -78,23 +111,50 @@ func (c *Controller) groupEvents(ctx *gin.Context) {
 
 	var queryParams groupEventsQueryParams
 	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
-		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
+		apierrors.WriteBindingProblem(ctx, &queryParams, err)
+		return
+	}
+
+	format, ok := parseDescriptionFormat(ctx.Query(formatKey))
+	if !ok {
+		writeInvalidFormat(ctx)
 		return
 	}
 
-	events, nextFilters, err := c.groupEventRepo.PaginatedEvents(
-		ctx,
-		groupID,
-		queryParamsToGroupEventArgs(queryParams),
-	)
+	filters := queryParamsToGroupEventArgs(queryParams)
+
+	version, err := c.groupVersion(ctx, groupID)
 	if err != nil {
 		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
 		return
 	}
 
+	cache := groupCache{version: version, upcoming: filters.upcomingOnly()}
+	if c.notModified(ctx, cache) {
+		return
+	}
+
+	events, nextFilters, err := c.groupEventRepo.PaginatedEvents(ctx, groupID, filters)
+
+	if errors.Is(err, ErrInvalidCursor) {
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidCursorProblem(cursorKey))
+		return
+	}
+
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	var validUntil time.Time
+	if cache.upcoming {
+		validUntil = upcomingValidUntil(events)
+	}
+	c.setValidators(ctx, cache, validUntil)
+
 	ctx.JSON(http.StatusOK, groupEventsResponseDTO{
-		Items:       meetupEventsToDTOs(events),
-		NextPageURL: c.createNextURL(ctx, groupID, nextFilters),
+		Items:       meetupEventsToDTOs(events, format),
+		NextPageURL: c.createNextURL(ctx, nextFilters),
 	})
 }
 
@@ -103,21 +163,45 @@ func (c *Controller) groupEvents(ctx *gin.Context) {
 // @Security	BearerAuth
 // @Accept		json
 // @Produce	json,application/problem+json
-// @Param		groupId	path		string	true	"Group ID"
-// @Success	200		{object}	eventDTO
-// @Failure	400		{object}	apierrors.ProblemDetails	"Invalid input"
-// @Failure	401		{object}	apierrors.ProblemDetails	"Unauthorized"
-// @Failure	404		{object}	apierrors.ProblemDetails	"Not found"
-// @Failure	500		{object}	apierrors.ProblemDetails	"Server error"
+// @Param		groupId				path		string	true	"Group ID"
+// @Param		format				query		string	false	"Description format"	Enums(markdown, html, text)
+// @Param		If-None-Match		header		string	false	"ETags of cached copies"
+// @Param		If-Modified-Since	header		string	false	"Last-Modified of a cached copy"
+// @Success	200					{object}	eventDTO
+// @Success	304					"Not modified"
+// @Header		200,304				{string}	ETag						"Entity tag of the response"
+// @Header		200,304				{string}	Last-Modified				"Time of the import that last changed the group, left out of upcoming events"
+// @Header		200,304				{string}	Cache-Control				"How long the response may be reused"
+// @Failure	400					{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure	401					{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure	404					{object}	apierrors.ProblemDetails	"Not found"
+// @Failure	500					{object}	apierrors.ProblemDetails	"Server error"
 // @Router		/v1/groups/{groupId}/events/next [get]
 func (c *Controller) nextGroupEvent(ctx *gin.Context) {
 	groupID := ctx.Param(groupIDKey)
+	format, ok := parseDescriptionFormat(ctx.Query(formatKey))
 
 	if groupID == "" {
 		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
 		return
 	}
 
+	if !ok {
+		writeInvalidFormat(ctx)
+		return
+	}
+
+	version, err := c.groupVersion(ctx, groupID)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	cache := groupCache{version: version, upcoming: true}
+	if c.notModified(ctx, cache) {
+		return
+	}
+
 	event, err := c.groupEventRepo.NextEvent(ctx, groupID)
 
 	if errors.Is(err, ErrEventNotFound) {
@@ -130,7 +214,12 @@ func (c *Controller) nextGroupEvent(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, meetupEventToDTO(event))
+	var validUntil time.Time
+	if event.DateTime != nil {
+		validUntil = event.DateTime.Time
+	}
+	c.setValidators(ctx, cache, validUntil)
+	ctx.JSON(http.StatusOK, meetupEventToDTO(event, format))
 }
 
 // @Summary	Get group event by ID
@@ -138,23 +227,47 @@ func (c *Controller) nextGroupEvent(ctx *gin.Context) {
 // @Security	BearerAuth
 // @Accept		json
 // @Produce	json,application/problem+json
-// @Param		groupId	path		string	true	"Group ID"
-// @Param		eventId	path		string	true	"Event ID"
-// @Success	200		{object}	eventDTO
-// @Failure	400		{object}	apierrors.ProblemDetails	"Invalid input"
-// @Failure	401		{object}	apierrors.ProblemDetails	"Unauthorized"
-// @Failure	404		{object}	apierrors.ProblemDetails	"Not found"
-// @Failure	500		{object}	apierrors.ProblemDetails	"Server error"
+// @Param		groupId				path		string	true	"Group ID"
+// @Param		eventId				path		string	true	"Event ID"
+// @Param		format				query		string	false	"Description format"	Enums(markdown, html, text)
+// @Param		If-None-Match		header		string	false	"ETags of cached copies"
+// @Param		If-Modified-Since	header		string	false	"Last-Modified of a cached copy"
+// @Success	200					{object}	eventDTO
+// @Success	304					"Not modified"
+// @Header		200,304				{string}	ETag						"Entity tag of the response"
+// @Header		200,304				{string}	Last-Modified				"Time of the import that last changed the group, left out of upcoming events"
+// @Header		200,304				{string}	Cache-Control				"How long the response may be reused"
+// @Failure	400					{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure	401					{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure	404					{object}	apierrors.ProblemDetails	"Not found"
+// @Failure	500					{object}	apierrors.ProblemDetails	"Server error"
 // @Router		/v1/groups/{groupId}/events/{eventId} [get]
 func (c *Controller) groupEventByID(ctx *gin.Context) {
 	groupID := ctx.Param(groupIDKey)
 	eventID := ctx.Param(eventIDKey)
+	format, ok := parseDescriptionFormat(ctx.Query(formatKey))
 
 	if groupID == "" || eventID == "" {
 		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
 		return
 	}
 
+	if !ok {
+		writeInvalidFormat(ctx)
+		return
+	}
+
+	version, err := c.groupVersion(ctx, groupID)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	cache := groupCache{version: version}
+	if c.notModified(ctx, cache) {
+		return
+	}
+
 	event, err := c.groupEventRepo.EventByID(ctx, groupID, eventID)
 
 	if errors.Is(err, ErrEventNotFound) || errors.Is(err, ErrGroupNotFound) {
@@ -167,18 +280,158 @@ func (c *Controller) groupEventByID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, meetupEventToDTO(event))
+	c.setValidators(ctx, cache, time.Time{})
+	ctx.JSON(http.StatusOK, meetupEventToDTO(event, format))
+}
+
+// @Summary	Get event series
+// @Tags		groupevents
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json,application/problem+json
+// @Param		groupId			query		string	false	"Only return series of this group"
+// @Param		If-None-Match	header		string	false	"ETags of cached copies"
+// @Success	200				{object}	seriesResponseDTO
+// @Success	304				"Not modified"
+// @Header		200,304			{string}	ETag						"Entity tag of the response"
+// @Header		200,304			{string}	Cache-Control				"How long the response may be reused"
+// @Failure	400				{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure	401				{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure	500				{object}	apierrors.ProblemDetails	"Server error"
+// @Router		/v1/series [get]
+func (c *Controller) series(ctx *gin.Context) {
+	var queryParams seriesQueryParams
+	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
+		apierrors.WriteBindingProblem(ctx, &queryParams, err)
+		return
+	}
+
+	series, err := c.seriesRepo.AllSeries(ctx, queryParams.GroupID)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, seriesResponseDTO{
+		Items: eventSeriesToDTOs(series),
+	})
+}
+
+// @Summary	Get events of a series
+// @Tags		groupevents
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json,application/problem+json
+// @Param		seriesId			path		string	true	"Series ID"
+// @Param		before				query		string	false	"Filter events before this timestamp"			Format(date-time)
+// @Param		after				query		string	false	"Filter events after this timestamp"			Format(date-time)
+// @Param		endsBefore			query		string	false	"Filter events ending before this timestamp"	Format(date-time)
+// @Param		endsAfter			query		string	false	"Filter events ending after this timestamp"		Format(date-time)
+// @Param		cursor				query		string	false	"Pagination cursor"
+// @Param		limit				query		integer	false	"Maximum number of results"
+// @Param		format				query		string	false	"Description format"	Enums(markdown, html, text)
+// @Param		If-None-Match		header		string	false	"ETags of cached copies"
+// @Param		If-Modified-Since	header		string	false	"Last-Modified of a cached copy"
+// @Success	200					{object}	groupEventsResponseDTO
+// @Success	304					"Not modified"
+// @Header		200,304				{string}	ETag						"Entity tag of the response"
+// @Header		200,304				{string}	Last-Modified				"Time of the import that last changed the group, left out of upcoming events"
+// @Header		200,304				{string}	Cache-Control				"How long the response may be reused"
+// @Failure	400					{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure	401					{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure	404					{object}	apierrors.ProblemDetails	"Not found"
+// @Failure	500					{object}	apierrors.ProblemDetails	"Server error"
+// @Router		/v1/series/{seriesId}/events [get]
+func (c *Controller) seriesEvents(ctx *gin.Context) {
+	seriesID := ctx.Param(seriesIDKey)
+
+	if seriesID == "" {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
+		return
+	}
+
+	var queryParams groupEventsQueryParams
+	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
+		apierrors.WriteBindingProblem(ctx, &queryParams, err)
+		return
+	}
+
+	format, ok := parseDescriptionFormat(ctx.Query(formatKey))
+	if !ok {
+		writeInvalidFormat(ctx)
+		return
+	}
+
+	series, err := c.seriesRepo.SeriesByID(ctx, seriesID)
+
+	if errors.Is(err, ErrSeriesNotFound) {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	filters := queryParamsToGroupEventArgs(queryParams)
+
+	version, err := c.groupVersion(ctx, series.GroupID)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	cache := groupCache{version: version, upcoming: filters.upcomingOnly()}
+	if c.notModified(ctx, cache) {
+		return
+	}
+
+	events, nextFilters, err := c.groupEventRepo.PaginatedSeriesEvents(ctx, seriesID, filters)
+
+	if errors.Is(err, ErrInvalidCursor) {
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidCursorProblem(cursorKey))
+		return
+	}
+
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	var validUntil time.Time
+	if cache.upcoming {
+		validUntil = upcomingValidUntil(events)
+	}
+	c.setValidators(ctx, cache, validUntil)
+
+	ctx.JSON(http.StatusOK, groupEventsResponseDTO{
+		Items:       meetupEventsToDTOs(events, format),
+		NextPageURL: c.createNextURL(ctx, nextFilters),
+	})
+}
+
+func writeInvalidFormat(ctx *gin.Context) {
+	apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidParameterProblem(
+		apierrors.FieldError{
+			Field:   formatKey,
+			Code:    apierrors.CodeInvalid,
+			Message: formatKey + " must be one of markdown, html, text",
+		},
+	))
 }
 
 func (c *Controller) createNextURL(
 	ctx *gin.Context,
-	groupID string,
 	filters *PaginatedEventsFilters,
 ) *string {
 	if filters == nil {
 		return nil
 	}
-	path := strings.ReplaceAll(ctx.FullPath(), ":"+groupIDKey, groupID)
+	path := ctx.FullPath()
+	for _, param := range ctx.Params {
+		path = strings.ReplaceAll(path, ":"+param.Key, param.Value)
+	}
 	newURL := c.config.AppURL.JoinPath(path)
 
 	query := url.Values{}
@@ -194,6 +447,15 @@ func (c *Controller) createNextURL(
 	if filters.After != nil {
 		query.Add(afterKey, filters.After.Format(time.RFC3339))
 	}
+	if filters.EndsBefore != nil {
+		query.Add(endsBeforeKey, filters.EndsBefore.Format(time.RFC3339))
+	}
+	if filters.EndsAfter != nil {
+		query.Add(endsAfterKey, filters.EndsAfter.Format(time.RFC3339))
+	}
+	if format := ctx.Query(formatKey); format != "" {
+		query.Add(formatKey, format)
+	}
 
 	newURL.RawQuery = query.Encode()
 
@@ -203,6 +465,8 @@ func (c *Controller) createNextURL(
 
 var Providers = wire.NewSet(
 	GroupEventRepositoryProviders,
+	SeriesRepositoryProviders,
+	GroupVersionRepositoryProviders,
 	NewControllerConfig,
 	NewController,
 )
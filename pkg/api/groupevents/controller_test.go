@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"sgf-meetup-api/pkg/api/apiconfig"
+	"sgf-meetup-api/pkg/api/apierrors"
 	"sgf-meetup-api/pkg/infra"
 	"sgf-meetup-api/pkg/shared/clock"
 	"sgf-meetup-api/pkg/shared/db"
@@ -48,10 +49,23 @@ func TestController_Integration(t *testing.T) {
 	require.NoError(t, err)
 	timeSource := clock.NewMockTimeSource(time.Now().UTC())
 	groupEventRepo := NewDynamoDBGroupEventRepository(DynamoDBGroupEventRepositoryConfig{
-		EventsTableName:    *infra.EventsTableProps.TableName,
-		GroupDateIndexName: *infra.GroupIdDateTimeIndex.IndexName,
+		EventsTableName:     *infra.EventsTableProps.TableName,
+		GroupDateIndexName:  *infra.GroupIdDateTimeIndex.IndexName,
+		SeriesDateIndexName: *infra.SeriesIdDateTimeIndex.IndexName,
 	}, timeSource, testDB.Client)
-	controller := NewController(ControllerConfig{AppURL: *u}, groupEventRepo)
+	seriesRepo := NewDynamoDBSeriesRepository(DynamoDBSeriesRepositoryConfig{
+		EventSeriesTableName: *infra.EventSeriesTableProps.TableName,
+	}, testDB.Client)
+	groupVersionRepo := NewDynamoDBGroupVersionRepository(DynamoDBGroupVersionRepositoryConfig{
+		GroupVersionsTableName: *infra.GroupVersionsTableProps.TableName,
+	}, testDB.Client)
+	controller := NewController(
+		ControllerConfig{AppURL: *u},
+		groupEventRepo,
+		seriesRepo,
+		groupVersionRepo,
+		timeSource,
+	)
 
 	router := gin.New()
 	controller.RegisterRoutes(router)
@@ -108,6 +122,47 @@ func TestController_Integration(t *testing.T) {
 		assert.Nil(t, nextResponseDTO.NextPageURL)
 	})
 
+	t.Run("GET /groups/:groupId/events rejects invalid parameters", func(t *testing.T) {
+		tests := map[string]struct {
+			query string
+			field apierrors.FieldError
+		}{
+			"cursor": {
+				query: "cursor=not-a-cursor",
+				field: apierrors.FieldError{
+					Field:   "cursor",
+					Code:    apierrors.CodeInvalid,
+					Message: "cursor is not a cursor returned by this endpoint",
+				},
+			},
+			"limit": {
+				query: "limit=-1",
+				field: apierrors.FieldError{
+					Field:   "limit",
+					Code:    apierrors.CodeOutOfRange,
+					Message: "limit must be at least 1",
+				},
+			},
+			"before": {
+				query: "before=yesterday",
+				field: apierrors.FieldError{
+					Field:   "before",
+					Code:    apierrors.CodeInvalid,
+					Message: "before must be an RFC 3339 date-time",
+				},
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				w := makeRequest(router, "GET", "/groups/test-group/events?"+tt.query, nil)
+				problem := getDTOWhenStatus[apierrors.ProblemDetails](t, w, http.StatusBadRequest)
+
+				assert.Equal(t, []apierrors.FieldError{tt.field}, problem.Errors)
+			})
+		}
+	})
+
 	t.Run("GET /groups/:groupId/events handles", func(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 		group := "test-group"
@@ -179,6 +234,73 @@ func TestController_Integration(t *testing.T) {
 		})
 	})
 
+	t.Run("GET /groups/:groupId/events returns events happening now", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+		group := "test-group"
+
+		createEvent := func(start time.Duration, duration string) models.MeetupEvent {
+			event := meetupFaker.CreateEvent(group, timeSource.Now().Add(start))
+			event.Duration = duration
+			require.NoError(t, event.NormalizeDuration())
+			return event
+		}
+
+		events := []models.MeetupEvent{
+			createEvent(time.Hour*-3, "PT1H"),
+			createEvent(time.Hour*-1, "PT2H"),
+			createEvent(time.Hour*1, "PT2H"),
+		}
+
+		testDB.InsertTestItems(ctx, *infra.EventsTableProps.TableName, events)
+
+		now := url.QueryEscape(timeSource.Now().UTC().Format(time.RFC3339))
+		w := makeRequest(
+			router,
+			"GET",
+			"/groups/"+group+"/events?before="+now+"&endsAfter="+now,
+			nil,
+		)
+		responseDTO := getDTOWhenStatus[groupEventsResponseDTO](t, w, http.StatusOK)
+
+		require.Len(t, responseDTO.Items, 1)
+		assert.Equal(t, events[1].ID, responseDTO.Items[0].ID)
+		require.NotNil(t, responseDTO.Items[0].DurationMinutes)
+		assert.Equal(t, 120, *responseDTO.Items[0].DurationMinutes)
+	})
+
+	t.Run("GET /groups/:groupId/events returns co-hosted events once", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("owner-group", timeSource.Now().Add(time.Hour*1))
+		event.AddCoHost("co-host-group")
+		event.SeriesID = "series-1"
+		testDB.InsertTestItems(ctx, *infra.EventsTableProps.TableName, []models.MeetupEvent{event})
+		testDB.InsertTestItems(ctx, *infra.EventsTableProps.TableName, event.Listings())
+		testDB.InsertTestItems(ctx, *infra.EventSeriesTableProps.TableName, []models.EventSeries{
+			{SeriesID: "series-1", GroupID: "owner-group", Title: "Co-hosted", Source: "detected"},
+		})
+
+		for _, group := range []string{"owner-group", "co-host-group"} {
+			w := makeRequest(router, "GET", "/groups/"+group+"/events", nil)
+			responseDTO := getDTOWhenStatus[groupEventsResponseDTO](t, w, http.StatusOK)
+
+			require.Len(t, responseDTO.Items, 1, group)
+			assert.Equal(t, event.ID, responseDTO.Items[0].ID)
+			assert.Equal(t, "owner-group", responseDTO.Items[0].Group.URLName)
+			assert.Equal(t, []string{"co-host-group"}, responseDTO.Items[0].CoHosts)
+
+			w = makeRequest(router, "GET", "/groups/"+group+"/events/next", nil)
+			assert.Equal(t, event.ID, getDTOWhenStatus[eventDTO](t, w, http.StatusOK).ID)
+
+			w = makeRequest(router, "GET", "/groups/"+group+"/events/"+event.ID, nil)
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+
+		w := makeRequest(router, "GET", "/series/series-1/events", nil)
+		responseDTO := getDTOWhenStatus[groupEventsResponseDTO](t, w, http.StatusOK)
+		assert.Len(t, responseDTO.Items, 1)
+	})
+
 	t.Run("GET /groups/:groupId/events/next return next event", func(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 		group := "test-group"
@@ -227,6 +349,18 @@ func TestController_Integration(t *testing.T) {
 			assert.Equal(t, events[1].ID, dto.ID)
 		})
 
+		t.Run("renders the description in the requested format", func(t *testing.T) {
+			path := "/groups/" + group + "/events/" + events[1].ID
+			w := makeRequest(router, "GET", path+"?format=text", nil)
+			dto := getDTOWhenStatus[eventDTO](t, w, http.StatusOK)
+
+			assert.NotEmpty(t, dto.Description)
+			assert.NotEmpty(t, dto.Summary)
+
+			w = makeRequest(router, "GET", path+"?format=pdf", nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+
 		t.Run("return 404 for invalid event id", func(t *testing.T) {
 			w := makeRequest(router, "GET", "/groups/"+group+"/events/invalid", nil)
 
@@ -239,6 +373,186 @@ func TestController_Integration(t *testing.T) {
 			assert.Equal(t, http.StatusNotFound, w.Code)
 		})
 	})
+
+	t.Run("GET /series", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		series := []models.EventSeries{
+			{SeriesID: "b", GroupID: "test-group", Title: "Second", Source: "detected"},
+			{SeriesID: "a", GroupID: "test-group", Title: "First", Source: "meetup"},
+			{SeriesID: "c", GroupID: "other-group", Title: "Other", Source: "detected"},
+		}
+		testDB.InsertTestItems(ctx, *infra.EventSeriesTableProps.TableName, series)
+
+		t.Run("returns all series", func(t *testing.T) {
+			w := makeRequest(router, "GET", "/series", nil)
+			responseDTO := getDTOWhenStatus[seriesResponseDTO](t, w, http.StatusOK)
+
+			require.Len(t, responseDTO.Items, 3)
+			assert.Equal(t, "c", responseDTO.Items[0].ID)
+			assert.Equal(t, "a", responseDTO.Items[1].ID)
+			assert.Equal(t, "b", responseDTO.Items[2].ID)
+		})
+
+		t.Run("filters by group", func(t *testing.T) {
+			w := makeRequest(router, "GET", "/series?groupId=test-group", nil)
+			responseDTO := getDTOWhenStatus[seriesResponseDTO](t, w, http.StatusOK)
+
+			require.Len(t, responseDTO.Items, 2)
+			assert.Equal(t, "a", responseDTO.Items[0].ID)
+		})
+	})
+
+	t.Run("GET /series/:seriesId/events", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+		group := "test-group"
+		seriesID := "series-1"
+
+		testDB.InsertTestItems(ctx, *infra.EventSeriesTableProps.TableName, []models.EventSeries{
+			{SeriesID: seriesID, GroupID: group, Title: "Monthly", Source: "detected"},
+		})
+
+		events := make([]models.MeetupEvent, 4)
+		for i := range events {
+			events[i] = meetupFaker.CreateEvent(
+				group,
+				timeSource.Now().Add(time.Hour*time.Duration(i-1)),
+			)
+		}
+		for i := range events[:3] {
+			events[i].SeriesID = seriesID
+		}
+		testDB.InsertTestItems(ctx, *infra.EventsTableProps.TableName, events)
+
+		t.Run("returns upcoming events of the series", func(t *testing.T) {
+			w := makeRequest(router, "GET", "/series/"+seriesID+"/events?limit=1", nil)
+			responseDTO := getDTOWhenStatus[groupEventsResponseDTO](t, w, http.StatusOK)
+
+			require.Len(t, responseDTO.Items, 1)
+			assert.Equal(t, events[1].ID, responseDTO.Items[0].ID)
+			require.NotNil(t, responseDTO.NextPageURL)
+			assert.Contains(t, *responseDTO.NextPageURL, "/series/"+seriesID+"/events")
+
+			w = makeRequest(router, "GET", *responseDTO.NextPageURL, nil)
+			nextResponseDTO := getDTOWhenStatus[groupEventsResponseDTO](t, w, http.StatusOK)
+
+			require.Len(t, nextResponseDTO.Items, 1)
+			assert.Equal(t, events[2].ID, nextResponseDTO.Items[0].ID)
+		})
+
+		t.Run("returns 404 for unknown series", func(t *testing.T) {
+			w := makeRequest(router, "GET", "/series/invalid/events", nil)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+		})
+	})
+
+	t.Run("conditional requests", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+		group := "test-group"
+
+		events := []models.MeetupEvent{
+			meetupFaker.CreateEvent(group, timeSource.Now().Add(time.Hour*-1)),
+			meetupFaker.CreateEvent(group, timeSource.Now().Add(time.Hour*1)),
+			meetupFaker.CreateEvent(group, timeSource.Now().Add(time.Hour*2)),
+		}
+		testDB.InsertTestItems(ctx, *infra.EventsTableProps.TableName, events)
+
+		importedAt := timeSource.Now().Add(-time.Hour).Truncate(time.Second)
+		testDB.InsertTestItems(ctx, *infra.GroupVersionsTableProps.TableName, []models.GroupVersion{
+			{GroupID: group, Version: "run1", UpdatedAt: importedAt},
+		})
+
+		conditionalRequest := func(url, header, value string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set(header, value)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		t.Run("upcoming events revalidate until the next event starts", func(t *testing.T) {
+			defer timeSource.Reset()
+			url := "/groups/" + group + "/events"
+
+			w := makeRequest(router, "GET", url, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			etag := w.Header().Get("ETag")
+			require.NotEmpty(t, etag)
+			assert.Empty(t, w.Header().Get("Last-Modified"))
+			assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+
+			w = conditionalRequest(url, "If-None-Match", etag)
+			assert.Equal(t, http.StatusNotModified, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			assert.Empty(t, w.Body.Bytes())
+
+			timeSource.SetTime(timeSource.Now().Add(time.Hour + time.Minute))
+			w = conditionalRequest(url, "If-None-Match", etag)
+			responseDTO := getDTOWhenStatus[groupEventsResponseDTO](t, w, http.StatusOK)
+			require.Len(t, responseDTO.Items, 1)
+			assert.Equal(t, events[2].ID, responseDTO.Items[0].ID)
+			assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		})
+
+		t.Run("the query is part of the ETag", func(t *testing.T) {
+			url := "/groups/" + group + "/events"
+
+			w := makeRequest(router, "GET", url, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			w = conditionalRequest(url+"?format=text", "If-None-Match", w.Header().Get("ETag"))
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+
+		t.Run("event by ID honors If-Modified-Since", func(t *testing.T) {
+			url := "/groups/" + group + "/events/" + events[0].ID
+
+			w := makeRequest(router, "GET", url, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			lastModified := w.Header().Get("Last-Modified")
+			assert.Equal(t, importedAt.Format(http.TimeFormat), lastModified)
+			assert.Equal(t, "private, max-age=300", w.Header().Get("Cache-Control"))
+
+			w = conditionalRequest(url, "If-Modified-Since", lastModified)
+			assert.Equal(t, http.StatusNotModified, w.Code)
+
+			earlier := importedAt.Add(-time.Minute).Format(http.TimeFormat)
+			w = conditionalRequest(url, "If-Modified-Since", earlier)
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+
+		t.Run("a new version invalidates the ETag", func(t *testing.T) {
+			url := "/groups/" + group + "/events/next"
+
+			w := makeRequest(router, "GET", url, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			etag := w.Header().Get("ETag")
+
+			testDB.InsertTestItems(
+				ctx,
+				*infra.GroupVersionsTableProps.TableName,
+				[]models.GroupVersion{
+					{GroupID: group, Version: "run2", UpdatedAt: timeSource.Now()},
+				},
+			)
+
+			w = conditionalRequest(url, "If-None-Match", etag)
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+
+		t.Run("groups without a version get an ETag from the response", func(t *testing.T) {
+			url := "/groups/other-group/events"
+
+			w := makeRequest(router, "GET", url, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			etag := w.Header().Get("ETag")
+			require.NotEmpty(t, etag)
+
+			w = conditionalRequest(url, "If-None-Match", etag)
+			assert.Equal(t, http.StatusNotModified, w.Code)
+		})
+	})
 }
 
 func makeRequest(
@@ -0,0 +1,181 @@
+package groupevents
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// descriptionFormat is how event descriptions are returned. Meetup descriptions are markdown,
+// which is returned unchanged.
+type descriptionFormat string
+
+const (
+	descriptionFormatMarkdown descriptionFormat = "markdown"
+	descriptionFormatHTML     descriptionFormat = "html"
+	descriptionFormatText     descriptionFormat = "text"
+)
+
+func parseDescriptionFormat(s string) (descriptionFormat, bool) {
+	switch format := descriptionFormat(s); format {
+	case "":
+		return descriptionFormatMarkdown, true
+	case descriptionFormatMarkdown, descriptionFormatHTML, descriptionFormatText:
+		return format, true
+	default:
+		return "", false
+	}
+}
+
+// maxSummaryLength is the most runes kept in an event summary, not counting the ellipsis.
+const maxSummaryLength = 200
+
+// descriptionMarkdown renders Meetup's line breaks as written and links bare URLs.
+var descriptionMarkdown = goldmark.New(
+	goldmark.WithExtensions(extension.Linkify, extension.Strikethrough),
+	goldmark.WithRendererOptions(html.WithHardWraps()),
+)
+
+// descriptionPolicy is the allowlist rendered HTML is sanitized with. Raw HTML in descriptions
+// is already dropped by the markdown renderer, so this guards against anything it lets through.
+var descriptionPolicy = newDescriptionPolicy()
+
+func newDescriptionPolicy() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements(
+		"p", "br", "hr", "strong", "em", "del", "code", "pre", "blockquote",
+		"ul", "ol", "li", "h1", "h2", "h3", "h4", "h5", "h6",
+	)
+	policy.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	policy.AllowAttrs("href").OnElements("a")
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireParseableURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return policy
+}
+
+// renderDescription returns the description in the given format along with a short plain text
+// summary of it for list views.
+func renderDescription(description string, format descriptionFormat) (string, string) {
+	source := []byte(description)
+	doc := descriptionMarkdown.Parser().Parse(text.NewReader(source))
+
+	summary := summarize(markdownToText(doc, source, false))
+
+	switch format {
+	case descriptionFormatHTML:
+		var buf bytes.Buffer
+		if err := descriptionMarkdown.Renderer().Render(&buf, source, doc); err != nil {
+			return "", summary
+		}
+		return strings.TrimSpace(descriptionPolicy.Sanitize(buf.String())), summary
+	case descriptionFormatText:
+		return markdownToText(doc, source, true), summary
+	default:
+		return description, summary
+	}
+}
+
+var (
+	extraNewlines = regexp.MustCompile(`\n{3,}`)
+	linkSchemes   = regexp.MustCompile(`^(?i)(https?|mailto):`)
+)
+
+// markdownToText flattens a parsed description to plain text, keeping paragraphs and list items
+// on their own lines. With includeURLs set, links are followed by their URL unless the link
+// text already is the URL or it uses a scheme the HTML allowlist would drop.
+func markdownToText(doc ast.Node, source []byte, includeURLs bool) string {
+	var b strings.Builder
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch n := node.(type) {
+		case *ast.Text:
+			if entering {
+				b.Write(unescape(n.Value(source)))
+				if n.SoftLineBreak() || n.HardLineBreak() {
+					b.WriteString("\n")
+				}
+			}
+		case *ast.String:
+			if entering {
+				b.Write(n.Value)
+			}
+		case *ast.AutoLink:
+			if entering {
+				b.Write(n.URL(source))
+			}
+		case *ast.Link:
+			if !entering && includeURLs {
+				destination := string(n.Destination)
+				isLinkText := strings.HasSuffix(b.String(), destination)
+				if linkSchemes.MatchString(destination) && !isLinkText {
+					b.WriteString(" (" + destination + ")")
+				}
+			}
+		case *ast.RawHTML, *ast.HTMLBlock:
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			if entering {
+				lines := n.Lines()
+				for i := range lines.Len() {
+					segment := lines.At(i)
+					b.Write(segment.Value(source))
+				}
+				b.WriteString("\n\n")
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.ListItem:
+			if entering {
+				b.WriteString("- ")
+			}
+		case *ast.TextBlock:
+			if !entering {
+				b.WriteString("\n")
+			}
+		case *ast.Paragraph, *ast.Heading, *ast.ThematicBreak:
+			if !entering {
+				b.WriteString("\n\n")
+			}
+		case *ast.List:
+			if !entering {
+				b.WriteString("\n")
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return strings.TrimSpace(extraNewlines.ReplaceAllString(b.String(), "\n\n"))
+}
+
+func unescape(value []byte) []byte {
+	value = util.UnescapePunctuations(value)
+	value = util.ResolveNumericReferences(value)
+	return util.ResolveEntityNames(value)
+}
+
+// summarize collapses plain text to a single line, cut at a word boundary once it's longer than
+// maxSummaryLength.
+func summarize(plainText string) string {
+	summary := strings.Join(strings.Fields(plainText), " ")
+	if utf8.RuneCountInString(summary) <= maxSummaryLength {
+		return summary
+	}
+
+	runes := []rune(summary)
+	cut := string(runes[:maxSummaryLength])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " .,;:-") + "…"
+}
@@ -0,0 +1,91 @@
+package groupevents
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDescriptionFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected descriptionFormat
+		ok       bool
+	}{
+		{"", descriptionFormatMarkdown, true},
+		{"markdown", descriptionFormatMarkdown, true},
+		{"html", descriptionFormatHTML, true},
+		{"text", descriptionFormatText, true},
+		{"pdf", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			format, ok := parseDescriptionFormat(tt.input)
+			assert.Equal(t, tt.expected, format)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestRenderDescription(t *testing.T) {
+	description := "**Code & Demo Night** is back\\!\n" +
+		"RSVP at [our site](https://example.com/rsvp) or https://meetup.com/open-sgf\n\n" +
+		"- Pizza\n- Demos\n\n" +
+		"<script>alert(1)</script>\n\n" +
+		"[click me](javascript:alert(1))"
+
+	t.Run("markdown is returned unchanged", func(t *testing.T) {
+		rendered, _ := renderDescription(description, descriptionFormatMarkdown)
+
+		assert.Equal(t, description, rendered)
+	})
+
+	t.Run("html is sanitized", func(t *testing.T) {
+		rendered, _ := renderDescription(description, descriptionFormatHTML)
+
+		assert.Contains(t, rendered, "<strong>Code &amp; Demo Night</strong> is back!<br>")
+		assert.Contains(
+			t,
+			rendered,
+			`<a href="https://example.com/rsvp" rel="nofollow noopener" target="_blank">our site</a>`,
+		)
+		assert.Contains(t, rendered, `<a href="https://meetup.com/open-sgf"`)
+		assert.Contains(t, rendered, "<ul>\n<li>Pizza</li>\n<li>Demos</li>\n</ul>")
+		assert.NotContains(t, rendered, "<script")
+		assert.NotContains(t, rendered, "javascript:")
+		assert.Contains(t, rendered, "click me")
+	})
+
+	t.Run("text keeps urls", func(t *testing.T) {
+		rendered, _ := renderDescription(description, descriptionFormatText)
+
+		assert.Equal(
+			t,
+			"Code & Demo Night is back!\n"+
+				"RSVP at our site (https://example.com/rsvp) or https://meetup.com/open-sgf\n\n"+
+				"- Pizza\n- Demos\n\n"+
+				"click me",
+			rendered,
+		)
+	})
+
+	t.Run("summary is plain text on one line", func(t *testing.T) {
+		_, summary := renderDescription(description, descriptionFormatHTML)
+
+		assert.Equal(
+			t,
+			"Code & Demo Night is back! RSVP at our site or https://meetup.com/open-sgf "+
+				"- Pizza - Demos click me",
+			summary,
+		)
+	})
+
+	t.Run("long summaries are cut at a word", func(t *testing.T) {
+		_, summary := renderDescription(strings.Repeat("word ", 100), descriptionFormatMarkdown)
+
+		assert.True(t, strings.HasSuffix(summary, "word…"))
+		assert.LessOrEqual(t, len([]rune(summary)), maxSummaryLength+1)
+	})
+}
@@ -5,10 +5,33 @@ import (
 )
 
 type groupEventsQueryParams struct {
-	Before *time.Time `form:"before"`
-	After  *time.Time `form:"after"`
-	Cursor string     `form:"cursor"`
-	Limit  *int       `form:"limit"`
+	Before     *time.Time `form:"before"`
+	After      *time.Time `form:"after"`
+	Cursor     string     `form:"cursor"`
+	Limit      *int       `form:"limit"      binding:"omitempty,min=1"`
+	EndsBefore *time.Time `form:"endsBefore"`
+	EndsAfter  *time.Time `form:"endsAfter"`
+}
+
+type seriesQueryParams struct {
+	GroupID string `form:"groupId"`
+}
+
+type seriesResponseDTO struct {
+	Items []seriesDTO `json:"items"`
+}
+
+// seriesDTO is a recurring event. Source is meetup when Meetup itself links the occurrences and
+// detected when they were grouped by title, weekday and venue. Title, VenueName and Weekday follow
+// the most recent occurrence.
+type seriesDTO struct {
+	ID        string    `json:"id"`
+	Group     groupDTO  `json:"group"`
+	Title     string    `json:"title"`
+	VenueName string    `json:"venueName"`
+	Weekday   string    `json:"weekday"`
+	Source    string    `json:"source"    enums:"meetup,detected"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 type groupEventsResponseDTO struct {
@@ -16,17 +39,39 @@ type groupEventsResponseDTO struct {
 	NextPageURL *string    `json:"nextPageUrl"`
 }
 
+// eventDTO is an event as returned by the API. Capacity is null when the event has no RSVP
+// limit, and Attendance is empty for events imported before it was recorded. EndDateTime and
+// DurationMinutes are null when Meetup didn't report a usable duration.
+//
+// Group is the group owning the event and CoHosts lists the URL names of any other groups
+// hosting it. Description is rendered in the requested format, and Summary is a short plain
+// text excerpt of it.
 type eventDTO struct {
-	ID          string     `json:"id"`
-	Group       groupDTO   `json:"group"`
-	Title       string     `json:"title"`
-	EventURL    string     `json:"eventUrl"`
-	Description string     `json:"description"`
-	DateTime    *time.Time `json:"dateTime"`
-	Duration    string     `json:"duration"`
-	Venue       *venueDTO  `json:"venue"`
-	Host        *hostDTO   `json:"host"`
-	Images      []imageDTO `json:"images"`
+	ID              string     `json:"id"`
+	Group           groupDTO   `json:"group"`
+	CoHosts         []string   `json:"coHosts"`
+	Title           string     `json:"title"`
+	EventURL        string     `json:"eventUrl"`
+	Description     string     `json:"description"`
+	Summary         string     `json:"summary"`
+	DateTime        *time.Time `json:"dateTime"`
+	Duration        string     `json:"duration"`
+	EndDateTime     *time.Time `json:"endDateTime"`
+	DurationMinutes *int       `json:"durationMinutes"`
+	Venue           *venueDTO  `json:"venue"`
+	Host            *hostDTO   `json:"host"`
+	Images          []imageDTO `json:"images"`
+	Tags            []string   `json:"tags,omitempty"`
+	Hosts           []hostDTO  `json:"hosts"`
+	RSVPCount       int        `json:"rsvpCount"`
+	Capacity        *int       `json:"capacity"`
+	Attendance      string     `json:"attendance"      enums:"in_person,online,hybrid"`
+	IsOnline        bool       `json:"isOnline"`
+	OnlineURL       *string    `json:"onlineUrl"`
+	Fee             *feeDTO    `json:"fee"`
+	Topics          []string   `json:"topics"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
 }
 
 type groupDTO struct {
@@ -35,11 +80,13 @@ type groupDTO struct {
 }
 
 type venueDTO struct {
-	Name       string `json:"name"`
-	Address    string `json:"address"`
-	City       string `json:"city"`
-	State      string `json:"state"`
-	PostalCode string `json:"postalCode"`
+	Name       string   `json:"name"`
+	Address    string   `json:"address"`
+	City       string   `json:"city"`
+	State      string   `json:"state"`
+	PostalCode string   `json:"postalCode"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
 }
 
 type hostDTO struct {
@@ -49,4 +96,12 @@ type hostDTO struct {
 type imageDTO struct {
 	BaseURL string `json:"baseUrl"`
 	Preview string `json:"preview"`
+	// Sizes maps thumbnail, small, medium and large to URLs of the image at that size.
+	Sizes map[string]string `json:"sizes,omitempty"`
+}
+
+type feeDTO struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Required bool    `json:"required"`
 }
@@ -20,11 +20,16 @@ import (
 	"github.com/google/wire"
 )
 
+// PaginatedEventsFilters narrows events by start time with Before and After, and by end time
+// with EndsBefore and EndsAfter. Events happening at a time t are those with Before and
+// EndsAfter both set to t. Without any time filter only upcoming events are returned.
 type PaginatedEventsFilters struct {
-	Before *time.Time
-	After  *time.Time
-	Cursor string
-	Limit  *int
+	Before     *time.Time
+	After      *time.Time
+	Cursor     string
+	Limit      *int
+	EndsBefore *time.Time
+	EndsAfter  *time.Time
 }
 
 type GroupEventRepository interface {
@@ -35,19 +40,27 @@ type GroupEventRepository interface {
 	) ([]models.MeetupEvent, *PaginatedEventsFilters, error)
 	NextEvent(ctx context.Context, groupID string) (*models.MeetupEvent, error)
 	EventByID(ctx context.Context, groupID, eventID string) (*models.MeetupEvent, error)
+	// PaginatedSeriesEvents returns the occurrences of a series, filtered like PaginatedEvents.
+	PaginatedSeriesEvents(
+		ctx context.Context,
+		seriesID string,
+		filters PaginatedEventsFilters,
+	) ([]models.MeetupEvent, *PaginatedEventsFilters, error)
 }
 
 type DynamoDBGroupEventRepositoryConfig struct {
-	EventsTableName    string
-	GroupDateIndexName string
+	EventsTableName     string
+	GroupDateIndexName  string
+	SeriesDateIndexName string
 }
 
 func NewDynamoDBGroupEventRepositoryConfig(
 	config *apiconfig.Config,
 ) DynamoDBGroupEventRepositoryConfig {
 	return DynamoDBGroupEventRepositoryConfig{
-		EventsTableName:    config.EventsTableName,
-		GroupDateIndexName: config.GroupIDDateTimeIndexName,
+		EventsTableName:     config.EventsTableName,
+		GroupDateIndexName:  config.GroupIDDateTimeIndexName,
+		SeriesDateIndexName: config.SeriesIDDateTimeIndexName,
 	}
 }
 
@@ -74,8 +87,27 @@ func (r *DynamoDBGroupEventRepository) PaginatedEvents(
 	groupID string,
 	filters PaginatedEventsFilters,
 ) ([]models.MeetupEvent, *PaginatedEventsFilters, error) {
-	keyCond := expression.Key("groupId").
-		Equal(expression.Value(groupID))
+	return r.paginatedEvents(ctx, r.config.GroupDateIndexName, "groupId", groupID, filters)
+}
+
+func (r *DynamoDBGroupEventRepository) PaginatedSeriesEvents(
+	ctx context.Context,
+	seriesID string,
+	filters PaginatedEventsFilters,
+) ([]models.MeetupEvent, *PaginatedEventsFilters, error) {
+	return r.paginatedEvents(ctx, r.config.SeriesDateIndexName, "seriesId", seriesID, filters)
+}
+
+// paginatedEvents queries an index partitioned by partitionKey and sorted by dateTime.
+func (r *DynamoDBGroupEventRepository) paginatedEvents(
+	ctx context.Context,
+	indexName string,
+	partitionKey string,
+	partitionValue string,
+	filters PaginatedEventsFilters,
+) ([]models.MeetupEvent, *PaginatedEventsFilters, error) {
+	keyCond := expression.Key(partitionKey).
+		Equal(expression.Value(partitionValue))
 
 	switch {
 	case filters.After != nil && filters.Before != nil:
@@ -91,26 +123,34 @@ func (r *DynamoDBGroupEventRepository) PaginatedEvents(
 		keyCond = keyCond.And(
 			expression.Key("dateTime").LessThan(expression.Value(*filters.Before)),
 		)
+	case filters.EndsAfter != nil || filters.EndsBefore != nil:
+		// End time filters replace the upcoming-only default so past events can match
 	default:
 		now := r.timeSource.Now().UTC()
 		keyCond = keyCond.And(expression.Key("dateTime").GreaterThan(expression.Value(now)))
 	}
 
-	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	builder := expression.NewBuilder().WithKeyCondition(keyCond)
+	if filterCond, ok := endDateTimeCondition(filters); ok {
+		builder = builder.WithFilter(filterCond)
+	}
+
+	expr, err := builder.Build()
 	if err != nil {
 		return nil, nil, err
 	}
 
 	queryInput := &dynamodb.QueryInput{
 		TableName:                 aws.String(r.config.EventsTableName),
-		IndexName:                 aws.String(r.config.GroupDateIndexName),
+		IndexName:                 aws.String(indexName),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
 	}
 
 	if filters.Cursor != "" {
-		startKey, err := r.decodeCursor(filters.Cursor)
+		startKey, err := r.decodeCursor(filters.Cursor, partitionKey)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -126,28 +166,65 @@ func (r *DynamoDBGroupEventRepository) PaginatedEvents(
 		return nil, nil, err
 	}
 
+	items, err := r.resolveListings(ctx, result.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var events []models.MeetupEvent
-	if err := attributevalue.UnmarshalListOfMaps(result.Items, &events); err != nil {
+	if err := attributevalue.UnmarshalListOfMaps(items, &events); err != nil {
 		return nil, nil, err
 	}
 
 	var nextCursor *PaginatedEventsFilters
 	if result.LastEvaluatedKey != nil {
-		cursorStr, err := r.encodeCursor(result.LastEvaluatedKey)
+		cursorStr, err := r.encodeCursor(result.LastEvaluatedKey, partitionKey)
 		if err != nil {
 			return nil, nil, err
 		}
 		nextCursor = &PaginatedEventsFilters{
-			Before: filters.Before,
-			After:  filters.After,
-			Limit:  filters.Limit,
-			Cursor: cursorStr,
+			Before:     filters.Before,
+			After:      filters.After,
+			Limit:      filters.Limit,
+			Cursor:     cursorStr,
+			EndsBefore: filters.EndsBefore,
+			EndsAfter:  filters.EndsAfter,
 		}
 	}
 
 	return events, nextCursor, nil
 }
 
+// endDateTimeCondition filters on the stored end time. It's a filter rather than a key
+// condition, so DynamoDB applies the limit first and a page may hold fewer events than asked
+// for. Events without a known end never match.
+func endDateTimeCondition(filters PaginatedEventsFilters) (expression.ConditionBuilder, bool) {
+	endDateTime := expression.Name("endDateTime")
+
+	switch {
+	case filters.EndsAfter != nil && filters.EndsBefore != nil:
+		return endDateTime.Between(
+			expression.Value(formatEndDateTime(*filters.EndsAfter)),
+			expression.Value(formatEndDateTime(*filters.EndsBefore)),
+		), true
+	case filters.EndsAfter != nil:
+		return endDateTime.GreaterThan(
+			expression.Value(formatEndDateTime(*filters.EndsAfter)),
+		), true
+	case filters.EndsBefore != nil:
+		return endDateTime.LessThan(
+			expression.Value(formatEndDateTime(*filters.EndsBefore)),
+		), true
+	default:
+		return expression.ConditionBuilder{}, false
+	}
+}
+
+// formatEndDateTime matches how models.CustomTime stores end times so they compare as strings.
+func formatEndDateTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
 func (r *DynamoDBGroupEventRepository) NextEvent(
 	ctx context.Context,
 	groupID string,
@@ -178,12 +255,17 @@ func (r *DynamoDBGroupEventRepository) NextEvent(
 		return nil, err
 	}
 
-	if len(result.Items) == 0 {
+	items, err := r.resolveListings(ctx, result.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
 		return nil, ErrEventNotFound
 	}
 
 	var event models.MeetupEvent
-	if err := attributevalue.UnmarshalMap(result.Items[0], &event); err != nil {
+	if err := attributevalue.UnmarshalMap(items[0], &event); err != nil {
 		return nil, err
 	}
 
@@ -215,23 +297,81 @@ func (r *DynamoDBGroupEventRepository) EventByID(
 		return nil, err
 	}
 
-	if event.GroupID != groupID {
+	if !event.HostedBy(groupID) {
 		return nil, ErrGroupNotFound
 	}
 
 	return &event, nil
 }
 
+// resolveListings replaces the listings co-hosts have in the group index with the events they
+// point to, so a co-hosted event is stored and returned as a single record. Listings of events
+// that have since been archived are dropped.
+func (r *DynamoDBGroupEventRepository) resolveListings(
+	ctx context.Context,
+	items []map[string]types.AttributeValue,
+) ([]map[string]types.AttributeValue, error) {
+	var keys []map[string]types.AttributeValue
+	for _, item := range items {
+		if id, ok := models.ListedEventID(item); ok {
+			keys = append(keys, map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: id},
+			})
+		}
+	}
+
+	if len(keys) == 0 {
+		return items, nil
+	}
+
+	listedItems, err := db.BatchGetItems(
+		ctx,
+		r.db,
+		db.DefaultRetryPolicy,
+		r.config.EventsTableName,
+		keys,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listedByID := make(map[string]map[string]types.AttributeValue, len(listedItems))
+	for _, item := range listedItems {
+		var id string
+		if err := attributevalue.Unmarshal(item["id"], &id); err != nil {
+			return nil, err
+		}
+		listedByID[id] = item
+	}
+
+	resolved := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, item := range items {
+		id, ok := models.ListedEventID(item)
+		if !ok {
+			resolved = append(resolved, item)
+			continue
+		}
+
+		if listed, ok := listedByID[id]; ok {
+			resolved = append(resolved, listed)
+		}
+	}
+
+	return resolved, nil
+}
+
+// encodeCursor encodes the last key of a page from an index partitioned by partitionKey.
 func (r *DynamoDBGroupEventRepository) encodeCursor(
 	lastKey map[string]types.AttributeValue,
+	partitionKey string,
 ) (string, error) {
 	var id string
 	if err := attributevalue.Unmarshal(lastKey["id"], &id); err != nil {
 		return "", err
 	}
 
-	var groupID string
-	if err := attributevalue.Unmarshal(lastKey["groupId"], &groupID); err != nil {
+	var partitionValue string
+	if err := attributevalue.Unmarshal(lastKey[partitionKey], &partitionValue); err != nil {
 		return "", err
 	}
 
@@ -241,13 +381,14 @@ func (r *DynamoDBGroupEventRepository) encodeCursor(
 	}
 
 	encodedID := base64.URLEncoding.EncodeToString([]byte(id))
-	encodedGroup := base64.URLEncoding.EncodeToString([]byte(groupID))
+	encodedPartition := base64.URLEncoding.EncodeToString([]byte(partitionValue))
 	encodedTime := base64.URLEncoding.EncodeToString([]byte(dateTime))
-	return encodedID + "." + encodedGroup + "." + encodedTime, nil
+	return encodedID + "." + encodedPartition + "." + encodedTime, nil
 }
 
 func (r *DynamoDBGroupEventRepository) decodeCursor(
 	cursorStr string,
+	partitionKey string,
 ) (map[string]types.AttributeValue, error) {
 	parts := strings.Split(cursorStr, ".")
 	if len(parts) != 3 {
@@ -259,7 +400,7 @@ func (r *DynamoDBGroupEventRepository) decodeCursor(
 		return nil, ErrInvalidCursor
 	}
 
-	groupIDBytes, err := base64.URLEncoding.DecodeString(parts[1])
+	partitionValueBytes, err := base64.URLEncoding.DecodeString(parts[1])
 	if err != nil {
 		return nil, ErrInvalidCursor
 	}
@@ -270,9 +411,9 @@ func (r *DynamoDBGroupEventRepository) decodeCursor(
 	}
 
 	return map[string]types.AttributeValue{
-		"id":       &types.AttributeValueMemberS{Value: string(idBytes)},
-		"groupId":  &types.AttributeValueMemberS{Value: string(groupIDBytes)},
-		"dateTime": &types.AttributeValueMemberS{Value: string(dateTimeBytes)},
+		"id":         &types.AttributeValueMemberS{Value: string(idBytes)},
+		partitionKey: &types.AttributeValueMemberS{Value: string(partitionValueBytes)},
+		"dateTime":   &types.AttributeValueMemberS{Value: string(dateTimeBytes)},
 	}, nil
 }
 
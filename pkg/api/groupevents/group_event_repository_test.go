@@ -10,12 +10,34 @@ import (
 
 func TestNewDynamoDBGroupEventRepositoryConfig(t *testing.T) {
 	cfg := &apiconfig.Config{
-		EventsTableName:          "events",
-		GroupIDDateTimeIndexName: "groupIndex",
+		EventsTableName:           "events",
+		GroupIDDateTimeIndexName:  "groupIndex",
+		SeriesIDDateTimeIndexName: "seriesIndex",
 	}
 
 	repoConfig := NewDynamoDBGroupEventRepositoryConfig(cfg)
 
 	assert.Equal(t, cfg.EventsTableName, repoConfig.EventsTableName)
 	assert.Equal(t, cfg.GroupIDDateTimeIndexName, repoConfig.GroupDateIndexName)
+	assert.Equal(t, cfg.SeriesIDDateTimeIndexName, repoConfig.SeriesDateIndexName)
+}
+
+func TestNewDynamoDBSeriesRepositoryConfig(t *testing.T) {
+	cfg := &apiconfig.Config{
+		EventSeriesTableName: "series",
+	}
+
+	repoConfig := NewDynamoDBSeriesRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.EventSeriesTableName, repoConfig.EventSeriesTableName)
+}
+
+func TestNewDynamoDBGroupVersionRepositoryConfig(t *testing.T) {
+	cfg := &apiconfig.Config{
+		GroupVersionsTableName: "groupVersions",
+	}
+
+	repoConfig := NewDynamoDBGroupVersionRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.GroupVersionsTableName, repoConfig.GroupVersionsTableName)
 }
@@ -0,0 +1,83 @@
+package groupevents
+
+import (
+	"context"
+	"errors"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type GroupVersionRepository interface {
+	// GroupVersion returns the version the importer last gave the group, or
+	// ErrGroupVersionNotFound when it hasn't imported the group since versions were added.
+	GroupVersion(ctx context.Context, groupID string) (*models.GroupVersion, error)
+}
+
+type DynamoDBGroupVersionRepositoryConfig struct {
+	GroupVersionsTableName string
+}
+
+func NewDynamoDBGroupVersionRepositoryConfig(
+	config *apiconfig.Config,
+) DynamoDBGroupVersionRepositoryConfig {
+	return DynamoDBGroupVersionRepositoryConfig{
+		GroupVersionsTableName: config.GroupVersionsTableName,
+	}
+}
+
+type DynamoDBGroupVersionRepository struct {
+	config DynamoDBGroupVersionRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBGroupVersionRepository(
+	config DynamoDBGroupVersionRepositoryConfig,
+	db *db.Client,
+) *DynamoDBGroupVersionRepository {
+	return &DynamoDBGroupVersionRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (r *DynamoDBGroupVersionRepository) GroupVersion(
+	ctx context.Context,
+	groupID string,
+) (*models.GroupVersion, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.config.GroupVersionsTableName),
+		Key: map[string]types.AttributeValue{
+			"groupId": &types.AttributeValueMemberS{Value: groupID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, ErrGroupVersionNotFound
+	}
+
+	var version models.GroupVersion
+	if err := attributevalue.UnmarshalMap(result.Item, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+var ErrGroupVersionNotFound = errors.New("group version not found")
+
+var GroupVersionRepositoryProviders = wire.NewSet(
+	wire.Bind(new(GroupVersionRepository), new(*DynamoDBGroupVersionRepository)),
+	NewDynamoDBGroupVersionRepositoryConfig,
+	NewDynamoDBGroupVersionRepository,
+)
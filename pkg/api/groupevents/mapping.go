@@ -6,38 +6,134 @@ import (
 	"sgf-meetup-api/pkg/shared/models"
 )
 
-func meetupEventToDTO(meetupEvent *models.MeetupEvent) *eventDTO {
+func meetupEventToDTO(meetupEvent *models.MeetupEvent, format descriptionFormat) *eventDTO {
 	if meetupEvent == nil {
 		return nil
 	}
 
+	description, summary := renderDescription(meetupEvent.Description, format)
+
 	var date *time.Time
 	if meetupEvent.DateTime != nil {
 		date = &meetupEvent.DateTime.Time
 	}
 
+	duration := meetupEvent.Duration
+	var endDateTime *time.Time
+	var durationMinutes *int
+	if d, ok := meetupEvent.EventDuration(); ok {
+		duration = models.FormatDuration(d)
+		minutes := int(d.Round(time.Minute) / time.Minute)
+		durationMinutes = &minutes
+
+		// Computed from the start rather than the stored end so both share a time zone
+		if date != nil {
+			end := date.Add(d)
+			endDateTime = &end
+		}
+	}
+
 	return &eventDTO{
 		ID: meetupEvent.ID,
 		Group: groupDTO{
 			URLName: meetupEvent.GroupID,
 			Name:    meetupEvent.GroupName,
 		},
-		Title:       meetupEvent.Title,
-		EventURL:    meetupEvent.EventURL,
-		Description: meetupEvent.Description,
-		DateTime:    date,
-		Duration:    meetupEvent.Duration,
-		Venue:       meetupVenueToDTO(meetupEvent.Venue),
-		Host:        meetupHostToDTO(meetupEvent.Host),
-		Images:      meetupImagesToDTOs(meetupEvent.Images),
+		CoHosts:         nonNil(meetupEvent.CoHosts()),
+		Title:           meetupEvent.Title,
+		EventURL:        meetupEvent.EventURL,
+		Description:     description,
+		Summary:         summary,
+		DateTime:        date,
+		Duration:        duration,
+		EndDateTime:     endDateTime,
+		DurationMinutes: durationMinutes,
+		Venue:           meetupVenueToDTO(meetupEvent.Venue),
+		Host:            meetupHostToDTO(meetupEvent.Host),
+		Images:          meetupImagesToDTOs(meetupEvent.Images),
+		Tags:            meetupEvent.Tags,
+		Hosts:           meetupHostsToDTOs(eventHosts(meetupEvent)),
+		RSVPCount:       meetupEvent.Going,
+		Capacity:        meetupEvent.Capacity,
+		Attendance:      meetupEventTypeToAttendance(meetupEvent.EventType),
+		IsOnline:        meetupEvent.IsOnline(),
+		OnlineURL:       optionalString(meetupEvent.OnlineURL),
+		Fee:             meetupFeeToDTO(meetupEvent.Fee),
+		Topics:          nonNil(meetupEvent.Topics),
+		CreatedAt:       customTimeToTime(meetupEvent.CreatedTime),
+		UpdatedAt:       customTimeToTime(meetupEvent.UpdatedTime),
+	}
+}
+
+// eventHosts falls back to the single host for events imported before every host was recorded.
+func eventHosts(meetupEvent *models.MeetupEvent) []models.MeetupHost {
+	if len(meetupEvent.Hosts) == 0 && meetupEvent.Host != nil {
+		return []models.MeetupHost{*meetupEvent.Host}
 	}
+	return meetupEvent.Hosts
 }
 
-func meetupEventsToDTOs(meetupEvents []models.MeetupEvent) []eventDTO {
+func meetupEventTypeToAttendance(eventType models.MeetupEventType) string {
+	switch eventType {
+	case models.MeetupEventTypePhysical:
+		return "in_person"
+	case models.MeetupEventTypeOnline:
+		return "online"
+	case models.MeetupEventTypeHybrid:
+		return "hybrid"
+	default:
+		return ""
+	}
+}
+
+func meetupFeeToDTO(meetupFee *models.MeetupFee) *feeDTO {
+	if meetupFee == nil {
+		return nil
+	}
+
+	return &feeDTO{
+		Amount:   meetupFee.Amount,
+		Currency: meetupFee.Currency,
+		Required: meetupFee.Required,
+	}
+}
+
+func meetupHostsToDTOs(meetupHosts []models.MeetupHost) []hostDTO {
+	dtos := make([]hostDTO, len(meetupHosts))
+
+	for i := range meetupHosts {
+		dtos[i] = *meetupHostToDTO(&meetupHosts[i])
+	}
+
+	return dtos
+}
+
+func customTimeToTime(customTime *models.CustomTime) *time.Time {
+	if customTime == nil {
+		return nil
+	}
+	return &customTime.Time
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func meetupEventsToDTOs(meetupEvents []models.MeetupEvent, format descriptionFormat) []eventDTO {
 	dtos := make([]eventDTO, len(meetupEvents))
 
 	for i := range meetupEvents {
-		dtos[i] = *meetupEventToDTO(&meetupEvents[i])
+		dtos[i] = *meetupEventToDTO(&meetupEvents[i], format)
 	}
 	return dtos
 }
@@ -53,6 +149,8 @@ func meetupVenueToDTO(meetupVenue *models.MeetupVenue) *venueDTO {
 		City:       meetupVenue.City,
 		State:      meetupVenue.State,
 		PostalCode: meetupVenue.PostalCode,
+		Latitude:   meetupVenue.Lat,
+		Longitude:  meetupVenue.Lng,
 	}
 }
 
@@ -74,6 +172,7 @@ func meetupImageToDTO(meetupImage *models.MeetupImage) *imageDTO {
 	return &imageDTO{
 		BaseURL: meetupImage.BaseUrl,
 		Preview: meetupImage.Preview,
+		Sizes:   meetupImage.Sizes,
 	}
 }
 
@@ -87,6 +186,34 @@ func meetupImagesToDTOs(meetupImages []models.MeetupImage) []imageDTO {
 	return dtos
 }
 
+func eventSeriesToDTO(series *models.EventSeries) *seriesDTO {
+	if series == nil {
+		return nil
+	}
+
+	return &seriesDTO{
+		ID: series.SeriesID,
+		Group: groupDTO{
+			URLName: series.GroupID,
+			Name:    series.GroupName,
+		},
+		Title:     series.Title,
+		VenueName: series.VenueName,
+		Weekday:   series.Weekday,
+		Source:    string(series.Source),
+		UpdatedAt: series.UpdatedAt,
+	}
+}
+
+func eventSeriesToDTOs(series []models.EventSeries) []seriesDTO {
+	dtos := make([]seriesDTO, len(series))
+
+	for i := range series {
+		dtos[i] = *eventSeriesToDTO(&series[i])
+	}
+	return dtos
+}
+
 func queryParamsToGroupEventArgs(queryParams groupEventsQueryParams) PaginatedEventsFilters {
 	return PaginatedEventsFilters(queryParams)
 }
@@ -0,0 +1,130 @@
+package groupevents
+
+import (
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetupEventToDTO(t *testing.T) {
+	t.Run("maps the richer event fields", func(t *testing.T) {
+		capacity := 60
+		lat, lng := 37.2103, -93.2923
+		createdTime := time.Date(2023, 12, 20, 9, 15, 0, 0, time.UTC)
+
+		dto := meetupEventToDTO(&models.MeetupEvent{
+			ID:          "1",
+			Host:        &models.MeetupHost{Name: "Host One"},
+			Hosts:       []models.MeetupHost{{Name: "Host One"}, {Name: "Host Two"}},
+			Going:       42,
+			Capacity:    &capacity,
+			EventType:   models.MeetupEventTypeOnline,
+			OnlineURL:   "https://meet.example.com",
+			Fee:         &models.MeetupFee{Amount: 5, Currency: "USD", Required: true},
+			Topics:      []string{"Go"},
+			Venue:       &models.MeetupVenue{Name: "efactory", Lat: &lat, Lng: &lng},
+			CreatedTime: &models.CustomTime{Time: createdTime},
+		}, descriptionFormatMarkdown)
+
+		assert.Equal(t, &hostDTO{Name: "Host One"}, dto.Host)
+		assert.Equal(t, []hostDTO{{Name: "Host One"}, {Name: "Host Two"}}, dto.Hosts)
+		assert.Equal(t, 42, dto.RSVPCount)
+		assert.Equal(t, &capacity, dto.Capacity)
+		assert.Equal(t, "online", dto.Attendance)
+		assert.True(t, dto.IsOnline)
+		require.NotNil(t, dto.OnlineURL)
+		assert.Equal(t, "https://meet.example.com", *dto.OnlineURL)
+		assert.Equal(t, &feeDTO{Amount: 5, Currency: "USD", Required: true}, dto.Fee)
+		assert.Equal(t, []string{"Go"}, dto.Topics)
+		assert.Equal(t, &lat, dto.Venue.Latitude)
+		assert.Equal(t, &lng, dto.Venue.Longitude)
+		assert.Equal(t, &createdTime, dto.CreatedAt)
+		assert.Nil(t, dto.UpdatedAt)
+	})
+
+	t.Run("keeps events imported before the richer fields usable", func(t *testing.T) {
+		dto := meetupEventToDTO(&models.MeetupEvent{
+			ID:   "1",
+			Host: &models.MeetupHost{Name: "Host One"},
+		}, descriptionFormatMarkdown)
+
+		assert.Equal(t, []hostDTO{{Name: "Host One"}}, dto.Hosts)
+		assert.Nil(t, dto.Capacity)
+		assert.Empty(t, dto.Attendance)
+		assert.False(t, dto.IsOnline)
+		assert.Nil(t, dto.OnlineURL)
+		assert.Nil(t, dto.Fee)
+		assert.Equal(t, []string{}, dto.Topics)
+		assert.Equal(t, []string{}, dto.CoHosts)
+	})
+
+	t.Run("lists co-hosts apart from the owning group", func(t *testing.T) {
+		dto := meetupEventToDTO(&models.MeetupEvent{
+			ID:        "1",
+			GroupID:   "open-sgf",
+			GroupName: "Open SGF",
+			GroupIDs:  []string{"open-sgf", "sgf-devs"},
+		}, descriptionFormatMarkdown)
+
+		assert.Equal(t, groupDTO{URLName: "open-sgf", Name: "Open SGF"}, dto.Group)
+		assert.Equal(t, []string{"sgf-devs"}, dto.CoHosts)
+	})
+
+	t.Run("computes the end time and duration", func(t *testing.T) {
+		start := time.Date(2025, 4, 25, 18, 0, 0, 0, time.FixedZone("CDT", -5*60*60))
+
+		dto := meetupEventToDTO(&models.MeetupEvent{
+			ID:       "1",
+			DateTime: &models.CustomTime{Time: start},
+			Duration: "2h30m",
+		}, descriptionFormatMarkdown)
+
+		assert.Equal(t, "PT2H30M", dto.Duration)
+		require.NotNil(t, dto.DurationMinutes)
+		assert.Equal(t, 150, *dto.DurationMinutes)
+		require.NotNil(t, dto.EndDateTime)
+		assert.Equal(t, start.Add(150*time.Minute), *dto.EndDateTime)
+	})
+
+	t.Run("leaves the end time empty without a usable duration", func(t *testing.T) {
+		dto := meetupEventToDTO(&models.MeetupEvent{
+			ID:       "1",
+			DateTime: &models.CustomTime{Time: time.Now()},
+			Duration: "P1M",
+		}, descriptionFormatMarkdown)
+
+		assert.Equal(t, "P1M", dto.Duration)
+		assert.Nil(t, dto.DurationMinutes)
+		assert.Nil(t, dto.EndDateTime)
+	})
+}
+
+func TestEventSeriesToDTO(t *testing.T) {
+	updatedAt := time.Date(2025, 4, 25, 12, 0, 0, 0, time.UTC)
+
+	dto := eventSeriesToDTO(&models.EventSeries{
+		SeriesID:  "abc123",
+		GroupID:   "open-sgf",
+		GroupName: "Open SGF",
+		Title:     "Code & Coffee",
+		VenueName: "efactory",
+		Weekday:   "Tuesday",
+		Source:    models.EventSeriesSourceDetected,
+		UpdatedAt: updatedAt,
+	})
+
+	assert.Equal(t, &seriesDTO{
+		ID:        "abc123",
+		Group:     groupDTO{URLName: "open-sgf", Name: "Open SGF"},
+		Title:     "Code & Coffee",
+		VenueName: "efactory",
+		Weekday:   "Tuesday",
+		Source:    "detected",
+		UpdatedAt: updatedAt,
+	}, dto)
+	assert.Nil(t, eventSeriesToDTO(nil))
+}
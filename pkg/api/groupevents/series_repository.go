@@ -0,0 +1,133 @@
+package groupevents
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type SeriesRepository interface {
+	// AllSeries returns every series ordered by group and title, only those of groupID when it
+	// isn't empty.
+	AllSeries(ctx context.Context, groupID string) ([]models.EventSeries, error)
+	SeriesByID(ctx context.Context, seriesID string) (*models.EventSeries, error)
+}
+
+type DynamoDBSeriesRepositoryConfig struct {
+	EventSeriesTableName string
+}
+
+func NewDynamoDBSeriesRepositoryConfig(config *apiconfig.Config) DynamoDBSeriesRepositoryConfig {
+	return DynamoDBSeriesRepositoryConfig{
+		EventSeriesTableName: config.EventSeriesTableName,
+	}
+}
+
+type DynamoDBSeriesRepository struct {
+	config DynamoDBSeriesRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBSeriesRepository(
+	config DynamoDBSeriesRepositoryConfig,
+	db *db.Client,
+) *DynamoDBSeriesRepository {
+	return &DynamoDBSeriesRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+// AllSeries scans the series table, which stays small since it holds one item per recurring
+// event rather than one per occurrence.
+func (r *DynamoDBSeriesRepository) AllSeries(
+	ctx context.Context,
+	groupID string,
+) ([]models.EventSeries, error) {
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(r.config.EventSeriesTableName),
+	}
+
+	if groupID != "" {
+		filter := expression.Name("groupId").Equal(expression.Value(groupID))
+		expr, err := expression.NewBuilder().WithFilter(filter).Build()
+		if err != nil {
+			return nil, err
+		}
+
+		scanInput.ExpressionAttributeNames = expr.Names()
+		scanInput.ExpressionAttributeValues = expr.Values()
+		scanInput.FilterExpression = expr.Filter()
+	}
+
+	paginator := dynamodb.NewScanPaginator(r.db, scanInput)
+
+	series := make([]models.EventSeries, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageSeries []models.EventSeries
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageSeries); err != nil {
+			return nil, err
+		}
+		series = append(series, pageSeries...)
+	}
+
+	slices.SortFunc(series, func(a, b models.EventSeries) int {
+		if c := strings.Compare(a.GroupID, b.GroupID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+
+	return series, nil
+}
+
+func (r *DynamoDBSeriesRepository) SeriesByID(
+	ctx context.Context,
+	seriesID string,
+) (*models.EventSeries, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.config.EventSeriesTableName),
+		Key: map[string]types.AttributeValue{
+			"seriesId": &types.AttributeValueMemberS{Value: seriesID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	var series models.EventSeries
+	if err := attributevalue.UnmarshalMap(result.Item, &series); err != nil {
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+var ErrSeriesNotFound = errors.New("series not found")
+
+var SeriesRepositoryProviders = wire.NewSet(
+	wire.Bind(new(SeriesRepository), new(*DynamoDBSeriesRepository)),
+	NewDynamoDBSeriesRepositoryConfig,
+	NewDynamoDBSeriesRepository,
+)
@@ -0,0 +1,215 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Policy is the Cache-Control of a route's successful responses.
+type Policy struct {
+	// MaxAge is how long a client may reuse a response before revalidating it. Zero makes it
+	// revalidate every time.
+	MaxAge time.Duration
+}
+
+// CacheControl returns the header value. Responses are only for the caller that authenticated,
+// so they're private to its own cache.
+func (p Policy) CacheControl() string {
+	if p.MaxAge <= 0 {
+		return "private, no-cache"
+	}
+	return fmt.Sprintf("private, max-age=%d", int(p.MaxAge.Seconds()))
+}
+
+// Validators identify a version of a response for conditional requests. A zero LastModified is
+// left out of the response, so If-Modified-Since can't match it.
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+}
+
+func (v Validators) Set(ctx *gin.Context) {
+	if v.ETag != "" {
+		ctx.Header("ETag", v.ETag)
+	}
+	if !v.LastModified.IsZero() {
+		ctx.Header("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+func validatorsFromHeader(header http.Header) Validators {
+	v := Validators{ETag: header.Get("ETag")}
+	if lastModified, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		v.LastModified = lastModified
+	}
+	return v
+}
+
+// NotModified reports whether the request's preconditions show the client already has the
+// response v identifies. If-Modified-Since is only used without If-None-Match, as in RFC 9110.
+func NotModified(r *http.Request, v Validators) bool {
+	if tags := IfNoneMatch(r); len(tags) > 0 {
+		if v.ETag == "" {
+			return false
+		}
+		for _, tag := range tags {
+			if tag == "*" || weakETag(tag) == weakETag(v.ETag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if v.LastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	// Last-Modified is sent with a precision of seconds.
+	return !v.LastModified.Truncate(time.Second).After(since)
+}
+
+// IfNoneMatch returns the entity tags listed in the request's If-None-Match headers.
+func IfNoneMatch(r *http.Request) []string {
+	var tags []string
+	for _, header := range r.Header.Values("If-None-Match") {
+		for header = strings.TrimSpace(header); header != ""; {
+			tag, rest := cutETag(header)
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+			header = strings.TrimLeft(rest, ", \t")
+		}
+	}
+	return tags
+}
+
+// cutETag splits the first entity tag from a list. Commas are allowed within the quotes.
+func cutETag(list string) (tag, rest string) {
+	start := 0
+	if strings.HasPrefix(list, "W/") {
+		start = 2
+	}
+
+	if !strings.HasPrefix(list[start:], `"`) {
+		tag, rest, _ = strings.Cut(list, ",")
+		return strings.TrimSpace(tag), rest
+	}
+
+	end := strings.IndexByte(list[start+1:], '"')
+	if end < 0 {
+		return "", ""
+	}
+	end += start + 2
+
+	return list[:end], list[end:]
+}
+
+// weakETag strips the weak indicator, since If-None-Match compares tags weakly.
+func weakETag(tag string) string {
+	return strings.TrimPrefix(tag, "W/")
+}
+
+// ContentETag returns a strong ETag derived from a response body.
+func ContentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// WriteNotModified ends the request with a 304 carrying the validators a 200 would have had.
+func WriteNotModified(ctx *gin.Context, v Validators) {
+	v.Set(ctx)
+	ctx.AbortWithStatus(http.StatusNotModified)
+}
+
+// Middleware applies policy to a route's GET responses and answers conditional requests. A
+// handler that can tell a client's copy is current without building the response should call
+// WriteNotModified, and set the validators it used with Validators.Set on its 200s. When it
+// doesn't set an ETag the response is buffered and one is derived from the body.
+func Middleware(policy Policy) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet {
+			ctx.Next()
+			return
+		}
+
+		original := ctx.Writer
+		w := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		ctx.Writer = w
+		defer func() { ctx.Writer = original }()
+
+		ctx.Next()
+
+		header := original.Header()
+		status := w.status
+
+		if status == http.StatusOK {
+			if header.Get("ETag") == "" {
+				header.Set("ETag", ContentETag(w.body.Bytes()))
+			}
+			if NotModified(ctx.Request, validatorsFromHeader(header)) {
+				status = http.StatusNotModified
+			}
+		}
+
+		if status == http.StatusOK || status == http.StatusNotModified {
+			header.Set("Cache-Control", policy.CacheControl())
+		}
+
+		if status == http.StatusNotModified {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			original.WriteHeader(status)
+			original.WriteHeaderNow()
+			return
+		}
+
+		original.WriteHeader(status)
+		original.WriteHeaderNow()
+		_, _ = original.Write(w.body.Bytes())
+	}
+}
+
+// bufferedWriter holds a response back until the middleware knows whether to send it.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
@@ -0,0 +1,135 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_CacheControl(t *testing.T) {
+	assert.Equal(t, "private, max-age=300", Policy{MaxAge: 5 * time.Minute}.CacheControl())
+	assert.Equal(t, "private, no-cache", Policy{}.CacheControl())
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("If-None-Match", `"a", W/"b,c" , "d"`)
+	req.Header.Add("If-None-Match", "*")
+
+	assert.Equal(t, []string{`"a"`, `W/"b,c"`, `"d"`, "*"}, IfNoneMatch(req))
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2025, 4, 12, 10, 0, 0, 500, time.UTC)
+	validators := Validators{ETag: `"abc"`, LastModified: lastModified}
+
+	request := func(header, value string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header, value)
+		return req
+	}
+
+	t.Run("matches ETags weakly", func(t *testing.T) {
+		assert.True(t, NotModified(request("If-None-Match", `"xyz", "abc"`), validators))
+		assert.True(t, NotModified(request("If-None-Match", `W/"abc"`), validators))
+		assert.True(t, NotModified(request("If-None-Match", "*"), validators))
+		assert.False(t, NotModified(request("If-None-Match", `"xyz"`), validators))
+	})
+
+	t.Run("compares If-Modified-Since to the second", func(t *testing.T) {
+		since := lastModified.Format(http.TimeFormat)
+		before := lastModified.Add(-time.Second).Format(http.TimeFormat)
+
+		assert.True(t, NotModified(request("If-Modified-Since", since), validators))
+		assert.False(t, NotModified(request("If-Modified-Since", before), validators))
+		assert.False(t, NotModified(request("If-Modified-Since", "yesterday"), validators))
+	})
+
+	t.Run("If-None-Match takes precedence over If-Modified-Since", func(t *testing.T) {
+		req := request("If-None-Match", `"xyz"`)
+		req.Header.Set("If-Modified-Since", lastModified.Format(http.TimeFormat))
+
+		assert.False(t, NotModified(req, validators))
+	})
+
+	t.Run("If-Modified-Since needs a Last-Modified", func(t *testing.T) {
+		since := lastModified.Format(http.TimeFormat)
+
+		assert.False(t, NotModified(request("If-Modified-Since", since), Validators{ETag: `"abc"`}))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	policy := Policy{MaxAge: time.Minute}
+	router.GET("/derived", Middleware(policy), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"name": "derived"})
+	})
+	router.GET("/validated", Middleware(policy), func(ctx *gin.Context) {
+		validators := Validators{ETag: `"v1"`}
+		if NotModified(ctx.Request, validators) {
+			WriteNotModified(ctx, validators)
+			return
+		}
+		validators.Set(ctx)
+		ctx.JSON(http.StatusOK, gin.H{"name": "validated"})
+	})
+	router.GET("/missing", Middleware(policy), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusNotFound, gin.H{"name": "missing"})
+	})
+
+	serve := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("derives an ETag from the body", func(t *testing.T) {
+		w := serve("/derived", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"name":"derived"}`, w.Body.String())
+		assert.Equal(t, ContentETag(w.Body.Bytes()), w.Header().Get("ETag"))
+		assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+
+		w = serve("/derived", w.Header().Get("ETag"))
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Empty(t, w.Header().Get("Content-Type"))
+		assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("keeps the handler's ETag", func(t *testing.T) {
+		w := serve("/validated", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+
+		w = serve("/validated", `"v1"`)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("passes errors through uncached", func(t *testing.T) {
+		w := serve("/missing", "*")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"name":"missing"}`, w.Body.String())
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+	})
+}
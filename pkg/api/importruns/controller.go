@@ -0,0 +1,179 @@
+package importruns
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"sgf-meetup-api/pkg/api/apierrors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+type Controller struct {
+	importRunRepo ImportRunRepository
+	importTrigger ImportTrigger
+}
+
+const (
+	groupIDKey = "groupId"
+
+	defaultRunsLimit = 20
+	maxRunsLimit     = 100
+	// syncStatusRunsLimit bounds how far back sync status looks for a successful import.
+	syncStatusRunsLimit = 25
+)
+
+func NewController(importRunRepo ImportRunRepository, importTrigger ImportTrigger) *Controller {
+	return &Controller{
+		importRunRepo: importRunRepo,
+		importTrigger: importTrigger,
+	}
+}
+
+func (c *Controller) RegisterRoutes(r gin.IRouter) {
+	r.GET("/groups/:"+groupIDKey+"/sync-status", c.groupSyncStatus)
+}
+
+func (c *Controller) RegisterAdminRoutes(r gin.IRouter) {
+	r.GET("/imports", c.importRuns)
+	r.POST("/imports", c.triggerImport)
+}
+
+// @Summary	Get recent import runs
+// @Tags		admin
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json,application/problem+json
+// @Param		limit	query		integer	false	"Maximum number of runs, up to 100"
+// @Success	200		{object}	importRunsResponseDTO
+// @Failure	400		{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure	401		{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure	403		{object}	apierrors.ProblemDetails	"Forbidden"
+// @Failure	500		{object}	apierrors.ProblemDetails	"Server error"
+// @Router		/v1/admin/imports [get]
+func (c *Controller) importRuns(ctx *gin.Context) {
+	var queryParams importRunsQueryParams
+	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
+		apierrors.WriteBindingProblem(ctx, &queryParams, err)
+		return
+	}
+
+	limit := defaultRunsLimit
+	if queryParams.Limit != nil {
+		limit = *queryParams.Limit
+	}
+
+	if limit < 1 || limit > maxRunsLimit {
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidParameterProblem(
+			apierrors.FieldError{
+				Field:   "limit",
+				Code:    apierrors.CodeOutOfRange,
+				Message: fmt.Sprintf("limit must be between 1 and %d", maxRunsLimit),
+			},
+		))
+		return
+	}
+
+	runs, err := c.importRunRepo.RecentRuns(ctx, limit)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	groupRuns, err := c.importRunRepo.GroupRuns(ctx, runs)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, importRunsResponseDTO{
+		Items: importRunsToDTOs(runs, groupRuns),
+	})
+}
+
+// @Summary		Trigger an import
+// @Description	Starts an import in the background. Omit groups to import every configured group.
+// @Description	Imports of unknown or disabled groups fail and show in the import history.
+// @Tags			admin
+// @Security		BearerAuth
+// @Accept			json
+// @Produce		json,application/problem+json
+// @Param			request	body		triggerImportRequestDTO	false	"Groups to import"
+// @Success		202		{object}	triggerImportResponseDTO
+// @Failure		400		{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure		401		{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure		403		{object}	apierrors.ProblemDetails	"Forbidden"
+// @Failure		500		{object}	apierrors.ProblemDetails	"Server error"
+// @Router			/v1/admin/imports [post]
+func (c *Controller) triggerImport(ctx *gin.Context) {
+	var request triggerImportRequestDTO
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+			apierrors.WriteBindingProblem(ctx, &request, err)
+			return
+		}
+	}
+
+	if slices.Contains(request.Groups, "") {
+		apierrors.WriteProblemDetails(ctx, apierrors.NewInvalidParameterProblem(
+			apierrors.FieldError{
+				Field:   "groups",
+				Code:    apierrors.CodeInvalid,
+				Message: "groups can't contain empty names",
+			},
+		))
+		return
+	}
+
+	if err := c.importTrigger.TriggerImport(ctx, request.Groups); err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, triggerImportResponseDTO(request))
+}
+
+// @Summary		Get group sync status
+// @Description	Reports when the group's events were last refreshed from Meetup.
+// @Tags			groupevents
+// @Security		BearerAuth
+// @Accept			json
+// @Produce		json,application/problem+json
+// @Param			groupId	path		string	true	"Group ID"
+// @Success		200		{object}	syncStatusDTO
+// @Failure		400		{object}	apierrors.ProblemDetails	"Invalid input"
+// @Failure		401		{object}	apierrors.ProblemDetails	"Unauthorized"
+// @Failure		404		{object}	apierrors.ProblemDetails	"Not found"
+// @Failure		500		{object}	apierrors.ProblemDetails	"Server error"
+// @Router			/v1/groups/{groupId}/sync-status [get]
+func (c *Controller) groupSyncStatus(ctx *gin.Context) {
+	groupID := ctx.Param(groupIDKey)
+
+	if groupID == "" {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusBadRequest)
+		return
+	}
+
+	groupRuns, err := c.importRunRepo.RecentGroupRuns(ctx, groupID, syncStatusRunsLimit)
+	if err != nil {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	if len(groupRuns) == 0 {
+		apierrors.WriteProblemDetailsFromStatus(ctx, http.StatusNotFound)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groupRunsToSyncStatus(groupID, groupRuns))
+}
+
+var Providers = wire.NewSet(
+	ImportRunRepositoryProviders,
+	ImportTriggerProviders,
+	NewController,
+)
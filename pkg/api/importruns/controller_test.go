@@ -0,0 +1,222 @@
+package importruns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+	"sgf-meetup-api/pkg/api/apierrors"
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLambdaImportTriggerConfig(t *testing.T) {
+	cfg := &apiconfig.Config{ImporterFunctionName: "importer"}
+
+	triggerConfig := NewLambdaImportTriggerConfig(cfg)
+
+	assert.Equal(t, cfg.ImporterFunctionName, triggerConfig.ImporterFunctionName)
+}
+
+func TestNewDynamoDBImportRunRepositoryConfig(t *testing.T) {
+	cfg := &apiconfig.Config{ImportRunsTableName: "importRuns"}
+
+	repoConfig := NewDynamoDBImportRunRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.ImportRunsTableName, repoConfig.ImportRunsTableName)
+}
+
+func TestController_Integration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	tableName := *infra.ImportRunsTableProps.TableName
+	importRunRepo := NewDynamoDBImportRunRepository(
+		DynamoDBImportRunRepositoryConfig{ImportRunsTableName: tableName},
+		testDB.Client,
+	)
+	controller := NewController(importRunRepo, &stubImportTrigger{})
+
+	router := gin.New()
+	controller.RegisterRoutes(router)
+	controller.RegisterAdminRoutes(router.Group("/admin"))
+
+	startedAt := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	newRuns := func(runID string, startedAt time.Time, groupErr string) []models.ImportRun {
+		groupRun := models.ImportRun{
+			Scope:     "group1",
+			RunID:     runID,
+			StartedAt: startedAt,
+			EndedAt:   startedAt.Add(time.Minute),
+			Fetched:   3,
+			Error:     groupErr,
+		}
+		run := groupRun
+		run.Scope = models.ImportRunScopeAll
+		run.Groups = []string{"group1"}
+		return []models.ImportRun{groupRun, run}
+	}
+
+	t.Run("GET /admin/imports returns newest runs with group records", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		testDB.InsertTestItems(ctx, tableName, newRuns("20250412T100000.000Z-a", startedAt, ""))
+		testDB.InsertTestItems(
+			ctx,
+			tableName,
+			newRuns("20250412T120000.000Z-b", startedAt.Add(2*time.Hour), "boom"),
+		)
+
+		req, _ := http.NewRequest("GET", "/admin/imports?limit=1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		responseDTO := getDTOWhenStatus[importRunsResponseDTO](t, w, http.StatusOK)
+
+		require.Len(t, responseDTO.Items, 1)
+		assert.Equal(t, "20250412T120000.000Z-b", responseDTO.Items[0].ID)
+		assert.Equal(t, importRunStatusFailed, responseDTO.Items[0].Status)
+		require.Len(t, responseDTO.Items[0].Groups, 1)
+		assert.Equal(t, "group1", responseDTO.Items[0].Groups[0].GroupID)
+		assert.Equal(t, 3, responseDTO.Items[0].Groups[0].Fetched)
+	})
+
+	t.Run("GET /admin/imports rejects invalid limit", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/admin/imports?limit=1000", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		problem := getDTOWhenStatus[apierrors.ProblemDetails](t, w, http.StatusBadRequest)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "limit", problem.Errors[0].Field)
+		assert.Equal(t, apierrors.CodeOutOfRange, problem.Errors[0].Code)
+	})
+
+	t.Run("GET /groups/:groupId/sync-status reports last successful refresh", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		testDB.InsertTestItems(ctx, tableName, newRuns("20250412T100000.000Z-a", startedAt, ""))
+		testDB.InsertTestItems(
+			ctx,
+			tableName,
+			newRuns("20250412T120000.000Z-b", startedAt.Add(2*time.Hour), "boom"),
+		)
+
+		req, _ := http.NewRequest("GET", "/groups/group1/sync-status", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		responseDTO := getDTOWhenStatus[syncStatusDTO](t, w, http.StatusOK)
+
+		assert.Equal(t, "group1", responseDTO.GroupID)
+		assert.Equal(t, importRunStatusFailed, responseDTO.LastAttemptStatus)
+		assert.True(t, startedAt.Add(2*time.Hour).Equal(responseDTO.LastAttemptedAt))
+		require.NotNil(t, responseDTO.LastRefreshedAt)
+		assert.True(t, startedAt.Add(time.Minute).Equal(*responseDTO.LastRefreshedAt))
+	})
+
+	t.Run("GET /groups/:groupId/sync-status returns 404 for unknown group", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/groups/unknown/sync-status", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+type stubImportTrigger struct {
+	groups [][]string
+	err    error
+}
+
+func (t *stubImportTrigger) TriggerImport(_ context.Context, groups []string) error {
+	t.groups = append(t.groups, groups)
+	return t.err
+}
+
+func TestController_TriggerImport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(trigger ImportTrigger) *gin.Engine {
+		router := gin.New()
+		NewController(nil, trigger).RegisterAdminRoutes(router)
+		return router
+	}
+
+	t.Run("triggers requested groups", func(t *testing.T) {
+		trigger := &stubImportTrigger{}
+
+		req, _ := http.NewRequest(
+			"POST",
+			"/imports",
+			strings.NewReader(`{"groups":["group1","group2"]}`),
+		)
+		w := httptest.NewRecorder()
+		newRouter(trigger).ServeHTTP(w, req)
+
+		responseDTO := getDTOWhenStatus[triggerImportResponseDTO](t, w, http.StatusAccepted)
+
+		assert.Equal(t, []string{"group1", "group2"}, responseDTO.Groups)
+		assert.Equal(t, [][]string{{"group1", "group2"}}, trigger.groups)
+	})
+
+	t.Run("triggers all groups when body is empty", func(t *testing.T) {
+		trigger := &stubImportTrigger{}
+
+		req, _ := http.NewRequest("POST", "/imports", nil)
+		w := httptest.NewRecorder()
+		newRouter(trigger).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		require.Len(t, trigger.groups, 1)
+		assert.Empty(t, trigger.groups[0])
+	})
+
+	t.Run("rejects invalid body", func(t *testing.T) {
+		trigger := &stubImportTrigger{}
+
+		req, _ := http.NewRequest("POST", "/imports", strings.NewReader(`{"groups":[""]}`))
+		w := httptest.NewRecorder()
+		newRouter(trigger).ServeHTTP(w, req)
+
+		problem := getDTOWhenStatus[apierrors.ProblemDetails](t, w, http.StatusBadRequest)
+		assert.Equal(t, []apierrors.FieldError{{
+			Field:   "groups",
+			Code:    apierrors.CodeInvalid,
+			Message: "groups can't contain empty names",
+		}}, problem.Errors)
+		assert.Empty(t, trigger.groups)
+	})
+
+	t.Run("returns 500 when trigger fails", func(t *testing.T) {
+		trigger := &stubImportTrigger{err: errors.New("invoke failed")}
+
+		req, _ := http.NewRequest("POST", "/imports", nil)
+		w := httptest.NewRecorder()
+		newRouter(trigger).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func getDTOWhenStatus[T any](t *testing.T, w *httptest.ResponseRecorder, status int) T {
+	require.Equal(t, status, w.Code)
+	var dto T
+	err := json.Unmarshal(w.Body.Bytes(), &dto)
+	require.NoError(t, err)
+	return dto
+}
@@ -0,0 +1,72 @@
+package importruns
+
+import (
+	"time"
+)
+
+type importRunsQueryParams struct {
+	Limit *int `form:"limit"`
+}
+
+type importRunsResponseDTO struct {
+	Items []importRunDTO `json:"items"`
+}
+
+type triggerImportRequestDTO struct {
+	Groups []string `json:"groups"`
+}
+
+type triggerImportResponseDTO struct {
+	Groups []string `json:"groups"`
+}
+
+type importRunStatus string
+
+const (
+	importRunStatusSucceeded importRunStatus = "succeeded"
+	importRunStatusFailed    importRunStatus = "failed"
+)
+
+type importRunDTO struct {
+	ID          string              `json:"id"`
+	Status      importRunStatus     `json:"status"`
+	StartedAt   time.Time           `json:"startedAt"`
+	EndedAt     time.Time           `json:"endedAt"`
+	Error       *string             `json:"error"`
+	Fetched     int                 `json:"fetched"`
+	Upserted    int                 `json:"upserted"`
+	Unchanged   int                 `json:"unchanged"`
+	Archived    int                 `json:"archived"`
+	MeetupPages int                 `json:"meetupPages"`
+	Anomalies   []importAnomalyDTO  `json:"anomalies"`
+	Groups      []groupImportRunDTO `json:"groups"`
+}
+
+type groupImportRunDTO struct {
+	GroupID     string             `json:"groupId"`
+	Status      importRunStatus    `json:"status"`
+	StartedAt   time.Time          `json:"startedAt"`
+	EndedAt     time.Time          `json:"endedAt"`
+	Error       *string            `json:"error"`
+	Fetched     int                `json:"fetched"`
+	Upserted    int                `json:"upserted"`
+	Unchanged   int                `json:"unchanged"`
+	Archived    int                `json:"archived"`
+	MeetupPages int                `json:"meetupPages"`
+	Anomalies   []importAnomalyDTO `json:"anomalies"`
+}
+
+type importAnomalyDTO struct {
+	Type     string   `json:"type"`
+	Message  string   `json:"message"`
+	EventIDs []string `json:"eventIds"`
+}
+
+type syncStatusDTO struct {
+	GroupID string `json:"groupId"`
+	// LastRefreshedAt is when the group's data was last successfully imported. It is null when
+	// none of the recent imports succeeded.
+	LastRefreshedAt   *time.Time      `json:"lastRefreshedAt"`
+	LastAttemptedAt   time.Time       `json:"lastAttemptedAt"`
+	LastAttemptStatus importRunStatus `json:"lastAttemptStatus"`
+}
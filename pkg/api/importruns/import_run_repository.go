@@ -0,0 +1,138 @@
+package importruns
+
+import (
+	"context"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type ImportRunRepository interface {
+	// RecentRuns returns the most recent import invocations, newest first.
+	RecentRuns(ctx context.Context, limit int) ([]models.ImportRun, error)
+	// GroupRuns returns the per-group records written by the given invocations.
+	GroupRuns(ctx context.Context, runs []models.ImportRun) ([]models.ImportRun, error)
+	// RecentGroupRuns returns the most recent import records for a group, newest first.
+	RecentGroupRuns(ctx context.Context, groupID string, limit int) ([]models.ImportRun, error)
+}
+
+type DynamoDBImportRunRepositoryConfig struct {
+	ImportRunsTableName string
+}
+
+func NewDynamoDBImportRunRepositoryConfig(
+	config *apiconfig.Config,
+) DynamoDBImportRunRepositoryConfig {
+	return DynamoDBImportRunRepositoryConfig{
+		ImportRunsTableName: config.ImportRunsTableName,
+	}
+}
+
+type DynamoDBImportRunRepository struct {
+	config DynamoDBImportRunRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBImportRunRepository(
+	config DynamoDBImportRunRepositoryConfig,
+	db *db.Client,
+) *DynamoDBImportRunRepository {
+	return &DynamoDBImportRunRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (r *DynamoDBImportRunRepository) RecentRuns(
+	ctx context.Context,
+	limit int,
+) ([]models.ImportRun, error) {
+	return r.queryScope(ctx, models.ImportRunScopeAll, limit)
+}
+
+func (r *DynamoDBImportRunRepository) RecentGroupRuns(
+	ctx context.Context,
+	groupID string,
+	limit int,
+) ([]models.ImportRun, error) {
+	return r.queryScope(ctx, groupID, limit)
+}
+
+func (r *DynamoDBImportRunRepository) GroupRuns(
+	ctx context.Context,
+	runs []models.ImportRun,
+) ([]models.ImportRun, error) {
+	var keys []map[string]types.AttributeValue
+	for _, run := range runs {
+		for _, group := range run.Groups {
+			keys = append(keys, map[string]types.AttributeValue{
+				"scope": &types.AttributeValueMemberS{Value: group},
+				"runId": &types.AttributeValueMemberS{Value: run.RunID},
+			})
+		}
+	}
+
+	items, err := db.BatchGetItems(
+		ctx,
+		r.db,
+		db.DefaultRetryPolicy,
+		r.config.ImportRunsTableName,
+		keys,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	groupRuns := make([]models.ImportRun, 0, len(items))
+	if err := attributevalue.UnmarshalListOfMaps(items, &groupRuns); err != nil {
+		return nil, err
+	}
+
+	return groupRuns, nil
+}
+
+func (r *DynamoDBImportRunRepository) queryScope(
+	ctx context.Context,
+	scope string,
+	limit int,
+) ([]models.ImportRun, error) {
+	keyCond := expression.Key("scope").Equal(expression.Value(scope))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.config.ImportRunsTableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(int32(limit)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]models.ImportRun, 0, len(result.Items))
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &runs); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+var ImportRunRepositoryProviders = wire.NewSet(
+	wire.Bind(new(ImportRunRepository), new(*DynamoDBImportRunRepository)),
+	NewDynamoDBImportRunRepositoryConfig,
+	NewDynamoDBImportRunRepository,
+)
@@ -0,0 +1,70 @@
+package importruns
+
+import (
+	"context"
+	"encoding/json"
+
+	"sgf-meetup-api/pkg/api/apiconfig"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/google/wire"
+)
+
+type ImportTrigger interface {
+	// TriggerImport starts an import without waiting for it to finish. An empty groups list
+	// imports every configured group.
+	TriggerImport(ctx context.Context, groups []string) error
+}
+
+type LambdaImportTriggerConfig struct {
+	ImporterFunctionName string
+}
+
+func NewLambdaImportTriggerConfig(config *apiconfig.Config) LambdaImportTriggerConfig {
+	return LambdaImportTriggerConfig{
+		ImporterFunctionName: config.ImporterFunctionName,
+	}
+}
+
+type LambdaImportTrigger struct {
+	config LambdaImportTriggerConfig
+	client *lambda.Client
+}
+
+func NewLambdaImportTrigger(
+	config LambdaImportTriggerConfig,
+	awsConfig *aws.Config,
+) *LambdaImportTrigger {
+	return &LambdaImportTrigger{
+		config: config,
+		client: lambda.NewFromConfig(*awsConfig),
+	}
+}
+
+// importRequest mirrors importer.ImportOptions, the importer Lambda's payload.
+type importRequest struct {
+	Groups []string `json:"groups,omitempty"`
+}
+
+func (t *LambdaImportTrigger) TriggerImport(ctx context.Context, groups []string) error {
+	payload, err := json.Marshal(importRequest{Groups: groups})
+	if err != nil {
+		return err
+	}
+
+	_, err = t.client.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(t.config.ImporterFunctionName),
+		InvocationType: types.InvocationTypeEvent,
+		Payload:        payload,
+	})
+
+	return err
+}
+
+var ImportTriggerProviders = wire.NewSet(
+	wire.Bind(new(ImportTrigger), new(*LambdaImportTrigger)),
+	NewLambdaImportTriggerConfig,
+	NewLambdaImportTrigger,
+)
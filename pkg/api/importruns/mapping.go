@@ -0,0 +1,111 @@
+package importruns
+
+import (
+	"slices"
+	"strings"
+
+	"sgf-meetup-api/pkg/shared/models"
+)
+
+func importRunsToDTOs(runs, groupRuns []models.ImportRun) []importRunDTO {
+	groupRunsByRunID := make(map[string][]models.ImportRun, len(runs))
+	for _, groupRun := range groupRuns {
+		groupRunsByRunID[groupRun.RunID] = append(groupRunsByRunID[groupRun.RunID], groupRun)
+	}
+
+	dtos := make([]importRunDTO, len(runs))
+	for i, run := range runs {
+		dtos[i] = importRunToDTO(run, groupRunsByRunID[run.RunID])
+	}
+
+	return dtos
+}
+
+func importRunToDTO(run models.ImportRun, groupRuns []models.ImportRun) importRunDTO {
+	slices.SortFunc(groupRuns, func(a, b models.ImportRun) int {
+		return strings.Compare(a.Scope, b.Scope)
+	})
+
+	groupDTOs := make([]groupImportRunDTO, len(groupRuns))
+	for i, groupRun := range groupRuns {
+		groupDTOs[i] = groupImportRunToDTO(groupRun)
+	}
+
+	return importRunDTO{
+		ID:          run.RunID,
+		Status:      importRunToStatus(run),
+		StartedAt:   run.StartedAt,
+		EndedAt:     run.EndedAt,
+		Error:       importRunToError(run),
+		Fetched:     run.Fetched,
+		Upserted:    run.Upserted,
+		Unchanged:   run.Unchanged,
+		Archived:    run.Archived,
+		MeetupPages: run.MeetupPages,
+		Anomalies:   importAnomaliesToDTOs(run.Anomalies),
+		Groups:      groupDTOs,
+	}
+}
+
+func groupImportRunToDTO(run models.ImportRun) groupImportRunDTO {
+	return groupImportRunDTO{
+		GroupID:     run.Scope,
+		Status:      importRunToStatus(run),
+		StartedAt:   run.StartedAt,
+		EndedAt:     run.EndedAt,
+		Error:       importRunToError(run),
+		Fetched:     run.Fetched,
+		Upserted:    run.Upserted,
+		Unchanged:   run.Unchanged,
+		Archived:    run.Archived,
+		MeetupPages: run.MeetupPages,
+		Anomalies:   importAnomaliesToDTOs(run.Anomalies),
+	}
+}
+
+func importAnomaliesToDTOs(anomalies []models.ImportAnomaly) []importAnomalyDTO {
+	dtos := make([]importAnomalyDTO, len(anomalies))
+	for i, anomaly := range anomalies {
+		dtos[i] = importAnomalyDTO{
+			Type:     string(anomaly.Type),
+			Message:  anomaly.Message,
+			EventIDs: anomaly.EventIDs,
+		}
+	}
+
+	return dtos
+}
+
+// groupRunsToSyncStatus summarises a group's recent runs, which must be ordered newest first.
+func groupRunsToSyncStatus(groupID string, groupRuns []models.ImportRun) syncStatusDTO {
+	lastAttempt := groupRuns[0]
+
+	status := syncStatusDTO{
+		GroupID:           groupID,
+		LastAttemptedAt:   lastAttempt.StartedAt,
+		LastAttemptStatus: importRunToStatus(lastAttempt),
+	}
+
+	for _, run := range groupRuns {
+		if run.Succeeded() {
+			status.LastRefreshedAt = &run.EndedAt
+			break
+		}
+	}
+
+	return status
+}
+
+func importRunToStatus(run models.ImportRun) importRunStatus {
+	if run.Succeeded() {
+		return importRunStatusSucceeded
+	}
+	return importRunStatusFailed
+}
+
+func importRunToError(run models.ImportRun) *string {
+	if run.Succeeded() {
+		return nil
+	}
+	return &run.Error
+}
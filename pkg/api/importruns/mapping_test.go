@@ -0,0 +1,55 @@
+package importruns
+
+import (
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupRunsToSyncStatus(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+
+	t.Run("reports no refresh when every recent run failed", func(t *testing.T) {
+		status := groupRunsToSyncStatus("group1", []models.ImportRun{
+			{Scope: "group1", StartedAt: now, EndedAt: now, Error: "boom"},
+		})
+
+		assert.Nil(t, status.LastRefreshedAt)
+		assert.Equal(t, importRunStatusFailed, status.LastAttemptStatus)
+		assert.Equal(t, now, status.LastAttemptedAt)
+	})
+
+	t.Run("uses the newest successful run", func(t *testing.T) {
+		status := groupRunsToSyncStatus("group1", []models.ImportRun{
+			{Scope: "group1", StartedAt: now, EndedAt: now.Add(time.Minute)},
+			{Scope: "group1", StartedAt: now.Add(-time.Hour), EndedAt: now.Add(-time.Hour)},
+		})
+
+		require.NotNil(t, status.LastRefreshedAt)
+		assert.Equal(t, now.Add(time.Minute), *status.LastRefreshedAt)
+		assert.Equal(t, importRunStatusSucceeded, status.LastAttemptStatus)
+	})
+}
+
+func TestImportRunsToDTOs(t *testing.T) {
+	runs := []models.ImportRun{
+		{Scope: models.ImportRunScopeAll, RunID: "run1", Groups: []string{"b", "a"}},
+	}
+	groupRuns := []models.ImportRun{
+		{Scope: "b", RunID: "run1", Error: "boom"},
+		{Scope: "a", RunID: "run1"},
+	}
+
+	dtos := importRunsToDTOs(runs, groupRuns)
+
+	require.Len(t, dtos, 1)
+	require.Len(t, dtos[0].Groups, 2)
+	assert.Equal(t, "a", dtos[0].Groups[0].GroupID)
+	assert.Equal(t, importRunStatusFailed, dtos[0].Groups[1].Status)
+	assert.Equal(t, "boom", *dtos[0].Groups[1].Error)
+	assert.Nil(t, dtos[0].Error)
+}
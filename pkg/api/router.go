@@ -7,8 +7,11 @@ import (
 	"log/slog"
 
 	"sgf-meetup-api/pkg/api/auth"
+	"sgf-meetup-api/pkg/api/cors"
 	_ "sgf-meetup-api/pkg/api/docs"
 	"sgf-meetup-api/pkg/api/groupevents"
+	"sgf-meetup-api/pkg/api/importruns"
+	"sgf-meetup-api/pkg/shared/models"
 
 	"github.com/gin-gonic/gin"
 	sloggin "github.com/samber/slog-gin"
@@ -28,12 +31,17 @@ func NewRouter(
 	logger *slog.Logger,
 	authController *auth.Controller,
 	groupEventsController *groupevents.Controller,
+	importRunsController *importruns.Controller,
 	authMiddleware *auth.Middleware,
+	scopeMiddleware *auth.ScopeMiddleware,
+	originMiddleware *auth.OriginMiddleware,
+	corsMiddleware *cors.Middleware,
 ) *gin.Engine {
 	r := gin.Default()
 
 	r.Use(sloggin.New(logger.WithGroup("http")))
 	r.Use(gin.Recovery())
+	r.Use(corsMiddleware.Handler)
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -42,9 +50,15 @@ func NewRouter(
 	authController.RegisterRoutes(v1Group)
 
 	authGroup := v1Group.Group("/")
-	authGroup.Use(authMiddleware.Handler)
+	authGroup.Use(authMiddleware.Handler, originMiddleware.Handler)
 
 	groupEventsController.RegisterRoutes(authGroup)
+	importRunsController.RegisterRoutes(authGroup)
+
+	adminGroup := authGroup.Group("/admin")
+	adminGroup.Use(scopeMiddleware.Require(models.APIUserScopeAdmin))
+
+	importRunsController.RegisterAdminRoutes(adminGroup)
 
 	return r
 }
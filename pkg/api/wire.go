@@ -8,7 +8,9 @@ import (
 
 	"sgf-meetup-api/pkg/api/apiconfig"
 	"sgf-meetup-api/pkg/api/auth"
+	"sgf-meetup-api/pkg/api/cors"
 	"sgf-meetup-api/pkg/api/groupevents"
+	"sgf-meetup-api/pkg/api/importruns"
 	"sgf-meetup-api/pkg/shared/clock"
 	"sgf-meetup-api/pkg/shared/db"
 	"sgf-meetup-api/pkg/shared/logging"
@@ -28,7 +30,9 @@ func InitRouter(ctx context.Context) (*gin.Engine, error) {
 	panic(wire.Build(
 		CommonProviders,
 		auth.Providers,
+		cors.Providers,
 		groupevents.Providers,
+		importruns.Providers,
 		NewRouter,
 	))
 }
@@ -8,18 +8,19 @@ package api
 
 import (
 	"context"
-	"github.com/gin-gonic/gin"
-	"github.com/google/wire"
 	"sgf-meetup-api/pkg/api/apiconfig"
 	"sgf-meetup-api/pkg/api/auth"
+	"sgf-meetup-api/pkg/api/cors"
 	"sgf-meetup-api/pkg/api/groupevents"
+	"sgf-meetup-api/pkg/api/importruns"
 	"sgf-meetup-api/pkg/shared/appconfig"
 	"sgf-meetup-api/pkg/shared/clock"
 	"sgf-meetup-api/pkg/shared/db"
 	"sgf-meetup-api/pkg/shared/logging"
-)
 
-import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+
 	_ "sgf-meetup-api/pkg/api/docs"
 )
 
@@ -51,9 +52,22 @@ func InitRouter(ctx context.Context) (*gin.Engine, error) {
 	controllerConfig := groupevents.NewControllerConfig(config)
 	dynamoDBGroupEventRepositoryConfig := groupevents.NewDynamoDBGroupEventRepositoryConfig(config)
 	dynamoDBGroupEventRepository := groupevents.NewDynamoDBGroupEventRepository(dynamoDBGroupEventRepositoryConfig, realTimeSource, client)
-	groupeventsController := groupevents.NewController(controllerConfig, dynamoDBGroupEventRepository)
+	dynamoDBSeriesRepositoryConfig := groupevents.NewDynamoDBSeriesRepositoryConfig(config)
+	dynamoDBSeriesRepository := groupevents.NewDynamoDBSeriesRepository(dynamoDBSeriesRepositoryConfig, client)
+	dynamoDBGroupVersionRepositoryConfig := groupevents.NewDynamoDBGroupVersionRepositoryConfig(config)
+	dynamoDBGroupVersionRepository := groupevents.NewDynamoDBGroupVersionRepository(dynamoDBGroupVersionRepositoryConfig, client)
+	groupeventsController := groupevents.NewController(controllerConfig, dynamoDBGroupEventRepository, dynamoDBSeriesRepository, dynamoDBGroupVersionRepository, realTimeSource)
+	dynamoDBImportRunRepositoryConfig := importruns.NewDynamoDBImportRunRepositoryConfig(config)
+	dynamoDBImportRunRepository := importruns.NewDynamoDBImportRunRepository(dynamoDBImportRunRepositoryConfig, client)
+	lambdaImportTriggerConfig := importruns.NewLambdaImportTriggerConfig(config)
+	lambdaImportTrigger := importruns.NewLambdaImportTrigger(lambdaImportTriggerConfig, awsConfig)
+	importrunsController := importruns.NewController(dynamoDBImportRunRepository, lambdaImportTrigger)
 	middleware := auth.NewMiddleware(tokenManagerImpl)
-	engine := NewRouter(logger, controller, groupeventsController, middleware)
+	scopeMiddleware := auth.NewScopeMiddleware(dynamoDBAPIUserRepository)
+	originMiddleware := auth.NewOriginMiddleware(dynamoDBAPIUserRepository)
+	middlewareConfig := cors.NewMiddlewareConfig(config)
+	corsMiddleware := cors.NewMiddleware(middlewareConfig)
+	engine := NewRouter(logger, controller, groupeventsController, importrunsController, middleware, scopeMiddleware, originMiddleware, corsMiddleware)
 	return engine, nil
 }
 
@@ -2,9 +2,12 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,10 +18,28 @@ func TestInitRouter(t *testing.T) {
 
 	t.Setenv("EVENTS_TABLE_NAME", "events")
 	t.Setenv("API_USERS_TABLE_NAME", "users")
+	t.Setenv("IMPORT_RUNS_TABLE_NAME", "import-runs")
+	t.Setenv("IMPORTER_FUNCTION_NAME", "importer")
 	t.Setenv("GROUP_ID_DATE_TIME_INDEX_NAME", "group-index")
+	t.Setenv("EVENT_SERIES_TABLE_NAME", "event-series")
+	t.Setenv("GROUP_VERSIONS_TABLE_NAME", "group-versions")
+	t.Setenv("SERIES_ID_DATE_TIME_INDEX_NAME", "series-index")
 	t.Setenv("JWT_SECRET", "secretkey")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://opensgf.org")
 
-	_, err := InitRouter(ctx)
+	router, err := InitRouter(ctx)
 
 	require.NoError(t, err)
+
+	t.Run("answers preflight requests for authenticated routes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/groups/sgfdevs/events", nil)
+		req.Header.Set("Origin", "https://opensgf.org")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://opensgf.org", w.Header().Get("Access-Control-Allow-Origin"))
+	})
 }
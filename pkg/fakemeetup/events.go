@@ -0,0 +1,280 @@
+package fakemeetup
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/fakers"
+	"sgf-meetup-api/pkg/shared/models"
+)
+
+const (
+	eventStatusActive = "ACTIVE"
+	eventStatusPast   = "PAST"
+)
+
+var (
+	ErrGroupNotFound = errors.New("group not found")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// eventNode is an event in the shape Meetup's GraphQL API returns it, which is also the format
+// of fixture files.
+type eventNode struct {
+	ID                 string              `json:"id"`
+	Title              string              `json:"title"`
+	EventURL           string              `json:"eventUrl"`
+	Description        string              `json:"description"`
+	DateTime           *models.CustomTime  `json:"dateTime"`
+	Duration           string              `json:"duration"`
+	CreatedTime        *models.CustomTime  `json:"createdTime"`
+	Updated            *models.CustomTime  `json:"updated"`
+	Going              int                 `json:"going"`
+	MaxTickets         int                 `json:"maxTickets"`
+	EventType          string              `json:"eventType"`
+	OnlineVenue        *onlineVenue        `json:"onlineVenue"`
+	FeeSettings        *models.MeetupFee   `json:"feeSettings"`
+	Topics             topicConnection     `json:"topics"`
+	Venue              *models.MeetupVenue `json:"venue"`
+	Group              eventGroup          `json:"group"`
+	EventHosts         []models.MeetupHost `json:"eventHosts"`
+	FeaturedEventPhoto *eventPhoto         `json:"featuredEventPhoto"`
+	Series             *eventSeries        `json:"series"`
+}
+
+type onlineVenue struct {
+	URL string `json:"url"`
+}
+
+type topicConnection struct {
+	Edges []topicEdge `json:"edges"`
+}
+
+type topicEdge struct {
+	Node struct {
+		Name string `json:"name"`
+	} `json:"node"`
+}
+
+type eventGroup struct {
+	Name    string `json:"name"`
+	URLName string `json:"urlname"`
+}
+
+type eventPhoto struct {
+	ID      string `json:"id"`
+	BaseUrl string `json:"baseUrl"`
+}
+
+type eventSeries struct {
+	ID string `json:"id"`
+}
+
+// EventsPage is a page of a group's events, cut from the events matching a status filter.
+type EventsPage struct {
+	TotalCount  int
+	Events      []eventNode
+	EndCursor   string
+	HasNextPage bool
+}
+
+// EventStore holds each group's events, sorted by date.
+type EventStore struct {
+	groups     map[string][]eventNode
+	timeSource clock.TimeSource
+}
+
+// NewEventStore loads groups from the fixtures directory when one is configured, otherwise it
+// generates events for the configured groups spread evenly around the current time.
+func NewEventStore(
+	config *fakemeetupconfig.Config,
+	timeSource clock.TimeSource,
+) (*EventStore, error) {
+	store := &EventStore{
+		groups:     map[string][]eventNode{},
+		timeSource: timeSource,
+	}
+
+	if config.FixturesDir != "" {
+		if err := store.loadFixtures(config.FixturesDir); err != nil {
+			return nil, err
+		}
+	} else {
+		store.generate(config.GroupNames, config.EventsPerGroup, config.Seed)
+	}
+
+	for _, events := range store.groups {
+		slices.SortStableFunc(events, func(a, b eventNode) int {
+			return eventTime(a).Compare(eventTime(b))
+		})
+	}
+
+	return store, nil
+}
+
+func (s *EventStore) loadFixtures(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var events []eventNode
+		if err = json.Unmarshal(data, &events); err != nil {
+			return fmt.Errorf("invalid fixture %s: %w", path, err)
+		}
+
+		group := strings.TrimSuffix(filepath.Base(path), ".json")
+		for i := range events {
+			if events[i].Group.URLName == "" {
+				events[i].Group.URLName = group
+			}
+			if events[i].Group.Name == "" {
+				events[i].Group.Name = group
+			}
+		}
+
+		s.groups[group] = events
+	}
+
+	return nil
+}
+
+// generate creates count events a week apart for each group, half of them in the past.
+func (s *EventStore) generate(groups []string, count int, seed uint64) {
+	faker := fakers.NewMeetupFaker(seed)
+	now := s.timeSource.Now().Truncate(time.Hour)
+
+	offsets := make([]time.Duration, count)
+	for i := range offsets {
+		offsets[i] = time.Duration(i-count/2)*7*24*time.Hour + 18*time.Hour
+	}
+
+	for _, group := range groups {
+		events := faker.CreateEventsWithDates(group, now, offsets...)
+
+		nodes := make([]eventNode, 0, len(events))
+		for _, event := range events {
+			nodes = append(nodes, newEventNode(group, event))
+		}
+
+		s.groups[group] = nodes
+	}
+}
+
+func newEventNode(group string, event models.MeetupEvent) eventNode {
+	node := eventNode{
+		ID:          event.ID,
+		Title:       event.Title,
+		EventURL:    event.EventURL,
+		Description: event.Description,
+		DateTime:    event.DateTime,
+		Duration:    event.Duration,
+		CreatedTime: &models.CustomTime{Time: event.DateTime.AddDate(0, -1, 0)},
+		Going:       event.Going,
+		EventType:   string(event.EventType),
+		FeeSettings: event.Fee,
+		Venue:       event.Venue,
+		Group:       eventGroup{Name: event.GroupName, URLName: group},
+		EventHosts:  event.Hosts,
+		FeaturedEventPhoto: &eventPhoto{
+			ID:      event.ID,
+			BaseUrl: "https://secure.meetupstatic.com/photos/event/",
+		},
+	}
+
+	if event.Capacity != nil {
+		node.MaxTickets = *event.Capacity
+	}
+
+	if event.IsOnline() {
+		node.OnlineVenue = &onlineVenue{URL: event.EventURL + "/online"}
+	}
+
+	for _, topic := range event.Topics {
+		var edge topicEdge
+		edge.Node.Name = topic
+		node.Topics.Edges = append(node.Topics.Edges, edge)
+	}
+
+	return node
+}
+
+// Page returns up to first of the group's events with the status, following cursor. Active
+// events are the ones that haven't started yet.
+func (s *EventStore) Page(group, status string, first int, cursor string) (*EventsPage, error) {
+	events, ok := s.groups[group]
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+
+	now := s.timeSource.Now()
+	matching := slices.DeleteFunc(slices.Clone(events), func(event eventNode) bool {
+		upcoming := eventTime(event).After(now)
+		return upcoming != (status == eventStatusActive)
+	})
+
+	start, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	start = min(start, len(matching))
+	end := min(start+max(first, 0), len(matching))
+
+	page := &EventsPage{
+		TotalCount:  len(matching),
+		Events:      matching[start:end],
+		HasNextPage: end < len(matching),
+	}
+	if end > start {
+		page.EndCursor = encodeCursor(end)
+	}
+
+	return page, nil
+}
+
+func eventTime(event eventNode) time.Time {
+	if event.DateTime == nil {
+		return time.Time{}
+	}
+	return event.DateTime.Time
+}
+
+// Cursors are opaque to clients, like Meetup's, but are just the offset of the next event.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	offset, err := strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,148 @@
+package fakemeetup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fixtureNow = time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func newFixtureStore(t *testing.T) *EventStore {
+	t.Helper()
+
+	store, err := NewEventStore(
+		&fakemeetupconfig.Config{FixturesDir: "testdata"},
+		clock.NewMockTimeSource(fixtureNow),
+	)
+	require.NoError(t, err)
+
+	return store
+}
+
+func eventIDs(events []eventNode) []string {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestNewEventStore_Fixtures(t *testing.T) {
+	store := newFixtureStore(t)
+
+	active, err := store.Page("sgfdevs", eventStatusActive, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"future-1", "future-2"}, eventIDs(active.Events))
+	assert.Equal(t, 2, active.TotalCount)
+	assert.False(t, active.HasNextPage)
+
+	past, err := store.Page("sgfdevs", eventStatusPast, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"past-1"}, eventIDs(past.Events))
+
+	// Groups missing from fixture events are taken from the file name.
+	assert.Equal(t, "sgfdevs", active.Events[1].Group.URLName)
+}
+
+func TestNewEventStore_Generated(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	config := &fakemeetupconfig.Config{
+		GroupNames:     []string{"sgfdevs", "open-sgf"},
+		EventsPerGroup: 10,
+		Seed:           1,
+	}
+
+	store, err := NewEventStore(config, clock.NewMockTimeSource(now))
+	require.NoError(t, err)
+
+	for _, group := range config.GroupNames {
+		active, err := store.Page(group, eventStatusActive, 100, "")
+		require.NoError(t, err)
+		past, err := store.Page(group, eventStatusPast, 100, "")
+		require.NoError(t, err)
+
+		assert.Len(t, active.Events, 5)
+		assert.Len(t, past.Events, 5)
+		for _, event := range active.Events {
+			assert.True(t, event.DateTime.After(now))
+			assert.Equal(t, group, event.Group.URLName)
+		}
+	}
+
+	// Generated events decode the same way Meetup's do.
+	page, err := store.Page("sgfdevs", eventStatusActive, 1, "")
+	require.NoError(t, err)
+	data, err := json.Marshal(page.Events[0])
+	require.NoError(t, err)
+
+	var event models.MeetupEvent
+	require.NoError(t, json.Unmarshal(data, &event))
+	assert.Equal(t, page.Events[0].ID, event.ID)
+	assert.Equal(t, "sgfdevs", event.GroupID)
+	assert.NotNil(t, event.EndDateTime)
+	require.Len(t, event.Images, 1)
+
+	// The same seed generates the same events.
+	again, err := NewEventStore(config, clock.NewMockTimeSource(now))
+	require.NoError(t, err)
+	againPage, err := again.Page("sgfdevs", eventStatusActive, 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, page.Events[0].ID, againPage.Events[0].ID)
+}
+
+func TestNewEventStore_InvalidFixture(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600))
+
+	_, err := NewEventStore(
+		&fakemeetupconfig.Config{FixturesDir: dir},
+		clock.NewMockTimeSource(fixtureNow),
+	)
+
+	assert.ErrorContains(t, err, "broken.json")
+}
+
+func TestEventStore_Page(t *testing.T) {
+	store := newFixtureStore(t)
+
+	t.Run("pages with cursors", func(t *testing.T) {
+		first, err := store.Page("sgfdevs", eventStatusActive, 1, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"future-1"}, eventIDs(first.Events))
+		assert.True(t, first.HasNextPage)
+		assert.Equal(t, 2, first.TotalCount)
+
+		second, err := store.Page("sgfdevs", eventStatusActive, 1, first.EndCursor)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"future-2"}, eventIDs(second.Events))
+		assert.False(t, second.HasNextPage)
+	})
+
+	t.Run("cursor past the end returns no events", func(t *testing.T) {
+		page, err := store.Page("sgfdevs", eventStatusActive, 1, encodeCursor(10))
+		require.NoError(t, err)
+		assert.Empty(t, page.Events)
+		assert.Empty(t, page.EndCursor)
+		assert.False(t, page.HasNextPage)
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		_, err := store.Page("unknown", eventStatusActive, 1, "")
+		assert.ErrorIs(t, err, ErrGroupNotFound)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := store.Page("sgfdevs", eventStatusActive, 1, "not a cursor")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
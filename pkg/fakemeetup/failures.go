@@ -0,0 +1,159 @@
+package fakemeetup
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+)
+
+type FailureMode string
+
+const (
+	// FailureRateLimited responds with 429 Too Many Requests.
+	FailureRateLimited FailureMode = "rate_limited"
+	// FailureServerError responds with 500 Internal Server Error.
+	FailureServerError FailureMode = "server_error"
+	// FailureGraphQLError responds with a GraphQL error and no data.
+	FailureGraphQLError FailureMode = "graphql_error"
+	// FailureGraphQLRateLimited responds with the GraphQL error Meetup uses for rate limiting.
+	FailureGraphQLRateLimited FailureMode = "graphql_rate_limited"
+	// FailureSlow waits for Delay before responding normally.
+	FailureSlow FailureMode = "slow"
+)
+
+type Endpoint string
+
+const (
+	EndpointToken   Endpoint = "token"
+	EndpointGraphQL Endpoint = "graphql"
+)
+
+// Failure makes requests to an endpoint fail, or every endpoint when Endpoint is empty.
+type Failure struct {
+	Mode     FailureMode `json:"mode"`
+	Endpoint Endpoint    `json:"endpoint,omitempty"`
+	// Times is how many requests fail before the failure is removed. Zero fails every request
+	// until the failures are cleared.
+	Times int `json:"times,omitempty"`
+	// RetryAfter is the Retry-After header in seconds for rate limited responses, or how far
+	// away the reset time is for GraphQL rate limits.
+	RetryAfter int `json:"retryAfter,omitempty"`
+	// DelayMS is how long slow responses wait, in milliseconds.
+	DelayMS int `json:"delayMs,omitempty"`
+	// Message replaces the default GraphQL error message.
+	Message string `json:"message,omitempty"`
+}
+
+func (f Failure) validate() error {
+	switch f.Mode {
+	case FailureRateLimited, FailureServerError, FailureSlow:
+	case FailureGraphQLError, FailureGraphQLRateLimited:
+		if f.Endpoint == EndpointToken {
+			return fmt.Errorf("%s failures only apply to the graphql endpoint", f.Mode)
+		}
+	default:
+		return fmt.Errorf("unknown failure mode %q", f.Mode)
+	}
+
+	switch f.Endpoint {
+	case "", EndpointToken, EndpointGraphQL:
+	default:
+		return fmt.Errorf("unknown endpoint %q", f.Endpoint)
+	}
+
+	if f.Times < 0 || f.RetryAfter < 0 || f.DelayMS < 0 {
+		return fmt.Errorf("times, retryAfter and delayMs must not be negative")
+	}
+
+	return nil
+}
+
+func (f Failure) appliesTo(endpoint Endpoint) bool {
+	if f.Endpoint == "" {
+		// GraphQL errors can't be returned from the token endpoint.
+		return endpoint == EndpointGraphQL ||
+			(f.Mode != FailureGraphQLError && f.Mode != FailureGraphQLRateLimited)
+	}
+	return f.Endpoint == endpoint
+}
+
+// Failures is the queue of failures requests are checked against, in the order they were added.
+type Failures struct {
+	lock     sync.Mutex
+	failures []Failure
+}
+
+// NewFailures starts with the failures configured in FAKE_MEETUP_FAILURES.
+func NewFailures(config *fakemeetupconfig.Config) (*Failures, error) {
+	failures := &Failures{}
+
+	if config.FailuresJSON == "" {
+		return failures, nil
+	}
+
+	var configured []Failure
+	if err := json.Unmarshal([]byte(config.FailuresJSON), &configured); err != nil {
+		return nil, fmt.Errorf("invalid failures: %w", err)
+	}
+
+	if err := failures.Add(configured...); err != nil {
+		return nil, err
+	}
+
+	return failures, nil
+}
+
+func (f *Failures) Add(failures ...Failure) error {
+	for _, failure := range failures {
+		if err := failure.validate(); err != nil {
+			return err
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.failures = append(f.failures, failures...)
+
+	return nil
+}
+
+func (f *Failures) List() []Failure {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return slices.Clone(f.failures)
+}
+
+func (f *Failures) Clear() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.failures = nil
+}
+
+// Next returns the first failure for the endpoint, using up one of its times.
+func (f *Failures) Next(endpoint Endpoint) (Failure, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for i, failure := range f.failures {
+		if !failure.appliesTo(endpoint) {
+			continue
+		}
+
+		if failure.Times > 0 {
+			f.failures[i].Times--
+			if f.failures[i].Times == 0 {
+				f.failures = slices.Delete(f.failures, i, i+1)
+			}
+		}
+
+		return failure, true
+	}
+
+	return Failure{}, false
+}
@@ -0,0 +1,121 @@
+package fakemeetup
+
+import (
+	"testing"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFailures(t *testing.T) {
+	t.Run("starts with configured failures", func(t *testing.T) {
+		failures, err := NewFailures(&fakemeetupconfig.Config{
+			FailuresJSON: `[{"mode": "rate_limited", "endpoint": "token", "times": 1}]`,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Failure{
+			{Mode: FailureRateLimited, Endpoint: EndpointToken, Times: 1},
+		}, failures.List())
+	})
+
+	t.Run("rejects invalid failures", func(t *testing.T) {
+		_, err := NewFailures(&fakemeetupconfig.Config{FailuresJSON: `[{"mode": "broken"}]`})
+		assert.ErrorContains(t, err, "unknown failure mode")
+
+		_, err = NewFailures(&fakemeetupconfig.Config{FailuresJSON: `{`})
+		assert.ErrorContains(t, err, "invalid failures")
+	})
+}
+
+func TestFailures_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure Failure
+		err     string
+	}{
+		{name: "valid", failure: Failure{Mode: FailureSlow, DelayMS: 100}},
+		{
+			name:    "unknown endpoint",
+			failure: Failure{Mode: FailureServerError, Endpoint: "other"},
+			err:     "unknown endpoint",
+		},
+		{
+			name:    "graphql error on token endpoint",
+			failure: Failure{Mode: FailureGraphQLError, Endpoint: EndpointToken},
+			err:     "only apply to the graphql endpoint",
+		},
+		{
+			name:    "negative times",
+			failure: Failure{Mode: FailureServerError, Times: -1},
+			err:     "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Failures{}).Add(tt.failure)
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestFailures_Next(t *testing.T) {
+	t.Run("uses up limited failures in order", func(t *testing.T) {
+		failures := &Failures{}
+		require.NoError(t, failures.Add(
+			Failure{Mode: FailureServerError, Times: 1},
+			Failure{Mode: FailureRateLimited, Times: 2},
+		))
+
+		var modes []FailureMode
+		for range 4 {
+			if failure, ok := failures.Next(EndpointGraphQL); ok {
+				modes = append(modes, failure.Mode)
+			}
+		}
+
+		assert.Equal(t, []FailureMode{
+			FailureServerError,
+			FailureRateLimited,
+			FailureRateLimited,
+		}, modes)
+		assert.Empty(t, failures.List())
+	})
+
+	t.Run("unlimited failures last until cleared", func(t *testing.T) {
+		failures := &Failures{}
+		require.NoError(t, failures.Add(Failure{Mode: FailureServerError}))
+
+		for range 3 {
+			_, ok := failures.Next(EndpointToken)
+			assert.True(t, ok)
+		}
+
+		failures.Clear()
+		_, ok := failures.Next(EndpointToken)
+		assert.False(t, ok)
+	})
+
+	t.Run("only matches the failure's endpoint", func(t *testing.T) {
+		failures := &Failures{}
+		require.NoError(t, failures.Add(
+			Failure{Mode: FailureServerError, Endpoint: EndpointGraphQL},
+			Failure{Mode: FailureGraphQLError},
+		))
+
+		_, ok := failures.Next(EndpointToken)
+		assert.False(t, ok)
+
+		failure, ok := failures.Next(EndpointGraphQL)
+		require.True(t, ok)
+		assert.Equal(t, FailureServerError, failure.Mode)
+	})
+}
@@ -0,0 +1,99 @@
+package fakemeetupconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/appconfig"
+
+	"github.com/google/wire"
+	"github.com/spf13/viper"
+)
+
+const (
+	portKey           = "FAKE_MEETUP_PORT"
+	groupNamesKey     = "FAKE_MEETUP_GROUP_NAMES"
+	fixturesDirKey    = "FAKE_MEETUP_FIXTURES_DIR"
+	eventsPerGroupKey = "FAKE_MEETUP_EVENTS_PER_GROUP"
+	seedKey           = "FAKE_MEETUP_SEED"
+	tokenTTLKey       = "FAKE_MEETUP_TOKEN_TTL"
+	failuresKey       = "FAKE_MEETUP_FAILURES"
+)
+
+var configKeys = []string{
+	portKey,
+	groupNamesKey,
+	fixturesDirKey,
+	eventsPerGroupKey,
+	seedKey,
+	tokenTTLKey,
+	failuresKey,
+}
+
+type Config struct {
+	appconfig.Common `              mapstructure:",squash"`
+	Port             int      `mapstructure:"fake_meetup_port"`
+	GroupNames       []string `mapstructure:"fake_meetup_group_names"`
+	// FixturesDir holds a <urlname>.json file of events for each group. Groups are generated
+	// with fakers when it's empty.
+	FixturesDir    string        `mapstructure:"fake_meetup_fixtures_dir"`
+	EventsPerGroup int           `mapstructure:"fake_meetup_events_per_group"`
+	Seed           uint64        `mapstructure:"fake_meetup_seed"`
+	TokenTTL       time.Duration `mapstructure:"fake_meetup_token_ttl"`
+	// FailuresJSON is a JSON array of failures to start the server with.
+	FailuresJSON string `mapstructure:"fake_meetup_failures"`
+}
+
+func NewConfig(ctx context.Context) (*Config, error) {
+	var config Config
+
+	err := appconfig.NewParser().
+		WithCommonConfig().
+		DefineKeys(configKeys).
+		WithEnvFile(".", ".env").
+		WithEnvVars().
+		WithCustomProcessor(setDefaults).
+		Parse(ctx, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func setDefaults(_ context.Context, v *viper.Viper) error {
+	v.SetDefault(strings.ToLower(portKey), 8090)
+	v.SetDefault(strings.ToLower(groupNamesKey), []string{"sgfdevs", "open-sgf"})
+	v.SetDefault(strings.ToLower(eventsPerGroupKey), 60)
+	v.SetDefault(strings.ToLower(seedKey), 1)
+	v.SetDefault(strings.ToLower(tokenTTLKey), "1h")
+	return nil
+}
+
+func (config *Config) validate() error {
+	if config.Port < 1 {
+		return fmt.Errorf("%s must be a valid port", portKey)
+	}
+
+	if config.EventsPerGroup < 0 {
+		return fmt.Errorf("%s must not be negative", eventsPerGroupKey)
+	}
+
+	if config.TokenTTL <= 0 {
+		return fmt.Errorf("%s must be positive", tokenTTLKey)
+	}
+
+	return nil
+}
+
+var ConfigProviders = wire.NewSet(
+	wire.FieldsOf(new(*Config), "Common"),
+	wire.FieldsOf(new(appconfig.Common), "Logging"),
+	NewConfig,
+)
@@ -0,0 +1,70 @@
+package fakemeetupconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		cfg, err := NewConfig(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 8090, cfg.Port)
+		assert.Equal(t, []string{"sgfdevs", "open-sgf"}, cfg.GroupNames)
+		assert.Equal(t, 60, cfg.EventsPerGroup)
+		assert.Equal(t, uint64(1), cfg.Seed)
+		assert.Equal(t, time.Hour, cfg.TokenTTL)
+		assert.Empty(t, cfg.FixturesDir)
+		assert.Empty(t, cfg.FailuresJSON)
+	})
+
+	t.Run("load from environment variables", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv(portKey, "9000")
+		t.Setenv(groupNamesKey, "a,b,c")
+		t.Setenv(fixturesDirKey, "./fixtures")
+		t.Setenv(eventsPerGroupKey, "5")
+		t.Setenv(seedKey, "42")
+		t.Setenv(tokenTTLKey, "5m")
+		t.Setenv(failuresKey, `[{"mode": "slow"}]`)
+
+		cfg, err := NewConfig(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 9000, cfg.Port)
+		assert.Equal(t, []string{"a", "b", "c"}, cfg.GroupNames)
+		assert.Equal(t, "./fixtures", cfg.FixturesDir)
+		assert.Equal(t, 5, cfg.EventsPerGroup)
+		assert.Equal(t, uint64(42), cfg.Seed)
+		assert.Equal(t, 5*time.Minute, cfg.TokenTTL)
+		assert.Equal(t, `[{"mode": "slow"}]`, cfg.FailuresJSON)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		tests := map[string]string{
+			portKey:           "0",
+			eventsPerGroupKey: "-1",
+			tokenTTLKey:       "0s",
+		}
+
+		for key, value := range tests {
+			t.Run(key, func(t *testing.T) {
+				t.Chdir(t.TempDir())
+				t.Setenv(key, value)
+
+				_, err := NewConfig(ctx)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), key)
+			})
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package fakemeetup
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+
+	"github.com/gin-gonic/gin"
+	sloggin "github.com/samber/slog-gin"
+)
+
+func NewRouter(logger *slog.Logger, server *Server) *gin.Engine {
+	r := gin.New()
+
+	r.Use(sloggin.New(logger.WithGroup("http")))
+	r.Use(gin.Recovery())
+
+	server.RegisterRoutes(r)
+
+	return r
+}
+
+func NewHTTPServer(config *fakemeetupconfig.Config, router *gin.Engine) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", config.Port),
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,256 @@
+package fakemeetup
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/clock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+const (
+	// TokenPath and GraphQLPath match the paths of MEETUP_AUTH_URL and MEETUP_API_URL's defaults.
+	TokenPath   = "/oauth2/access"
+	GraphQLPath = "/gql-ext"
+	// FailuresPath manages the failures requests are checked against.
+	FailuresPath = "/_fake/failures"
+
+	defaultItemsNum = 20
+)
+
+var eventStatusPattern = regexp.MustCompile(`status:\s*\[\s*(\w+)`)
+
+type Server struct {
+	events     *EventStore
+	tokens     *TokenIssuer
+	failures   *Failures
+	timeSource clock.TimeSource
+	logger     *slog.Logger
+}
+
+func NewServer(
+	events *EventStore,
+	tokens *TokenIssuer,
+	failures *Failures,
+	timeSource clock.TimeSource,
+	logger *slog.Logger,
+) *Server {
+	return &Server{
+		events:     events,
+		tokens:     tokens,
+		failures:   failures,
+		timeSource: timeSource,
+		logger:     logger,
+	}
+}
+
+func (s *Server) RegisterRoutes(r gin.IRouter) {
+	r.POST(TokenPath, s.token)
+	r.POST(GraphQLPath, s.graphQL)
+
+	r.GET(FailuresPath, s.listFailures)
+	r.POST(FailuresPath, s.addFailures)
+	r.DELETE(FailuresPath, s.clearFailures)
+}
+
+func (s *Server) token(ctx *gin.Context) {
+	if s.fail(ctx, EndpointToken) {
+		return
+	}
+
+	var (
+		resp *tokenResponse
+		err  error
+	)
+
+	switch grantType := ctx.PostForm("grant_type"); grantType {
+	case "urn:ietf:params:oauth:grant-type:jwt-bearer":
+		resp, err = s.tokens.ExchangeAssertion(ctx.PostForm("assertion"))
+	case "refresh_token":
+		resp, err = s.tokens.ExchangeRefreshToken(ctx.PostForm("refresh_token"))
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unsupported_grant_type"})
+		return
+	}
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid_grant"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphQL answers the groupByUrlname events queries the importer sends. Only the status filter
+// and pagination arguments are read from the query, every field is always returned.
+func (s *Server) graphQL(ctx *gin.Context) {
+	if s.fail(ctx, EndpointGraphQL) {
+		return
+	}
+
+	token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if !s.tokens.Valid(token) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid_token"})
+		return
+	}
+
+	var req graphQLRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if !strings.Contains(req.Query, "groupByUrlname") || !strings.Contains(req.Query, "events") {
+		writeGraphQLError(ctx, "the fake only supports groupByUrlname events queries", nil)
+		return
+	}
+
+	status := eventStatusActive
+	if match := eventStatusPattern.FindStringSubmatch(req.Query); match != nil {
+		status = match[1]
+	}
+	if status != eventStatusActive && status != eventStatusPast {
+		writeGraphQLError(ctx, "unsupported event status "+status, nil)
+		return
+	}
+
+	urlname, _ := req.Variables["urlname"].(string)
+	cursor, _ := req.Variables["cursor"].(string)
+	first := defaultItemsNum
+	if itemsNum, ok := req.Variables["itemsNum"].(float64); ok {
+		first = int(itemsNum)
+	}
+
+	page, err := s.events.Page(urlname, status, first, cursor)
+	if errors.Is(err, ErrGroupNotFound) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"data":   gin.H{"groupByUrlname": nil},
+			"errors": []gin.H{{"message": "group not found: " + urlname}},
+		})
+		return
+	}
+	if err != nil {
+		writeGraphQLError(ctx, err.Error(), nil)
+		return
+	}
+
+	edges := make([]gin.H, 0, len(page.Events))
+	for _, event := range page.Events {
+		edges = append(edges, gin.H{"node": event})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"groupByUrlname": gin.H{
+				"events": gin.H{
+					"totalCount": page.TotalCount,
+					"pageInfo": gin.H{
+						"endCursor":   page.EndCursor,
+						"hasNextPage": page.HasNextPage,
+					},
+					"edges": edges,
+				},
+			},
+		},
+	})
+}
+
+// fail responds with the endpoint's next failure, reporting whether it wrote the response.
+// Slow failures only delay the response.
+func (s *Server) fail(ctx *gin.Context, endpoint Endpoint) bool {
+	failure, ok := s.failures.Next(endpoint)
+	if !ok {
+		return false
+	}
+
+	s.logger.Info("failing request", "endpoint", endpoint, "mode", failure.Mode)
+
+	switch failure.Mode {
+	case FailureSlow:
+		select {
+		case <-ctx.Request.Context().Done():
+			ctx.Abort()
+			return true
+		case <-time.After(time.Duration(failure.DelayMS) * time.Millisecond):
+			return false
+		}
+	case FailureRateLimited:
+		if failure.RetryAfter > 0 {
+			ctx.Header("Retry-After", strconv.Itoa(failure.RetryAfter))
+		}
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": "rate_limited"})
+	case FailureServerError:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+	case FailureGraphQLError:
+		message := failure.Message
+		if message == "" {
+			message = "fake meetup failure"
+		}
+		writeGraphQLError(ctx, message, nil)
+	case FailureGraphQLRateLimited:
+		message := failure.Message
+		if message == "" {
+			message = "Too many requests, please try again shortly."
+		}
+		resetAt := s.timeSource.Now().Add(time.Duration(failure.RetryAfter) * time.Second)
+		writeGraphQLError(ctx, message, gin.H{
+			"code":    "RATE_LIMITED",
+			"resetAt": resetAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return true
+}
+
+func writeGraphQLError(ctx *gin.Context, message string, extensions gin.H) {
+	graphQLError := gin.H{"message": message}
+	if extensions != nil {
+		graphQLError["extensions"] = extensions
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": nil, "errors": []gin.H{graphQLError}})
+}
+
+func (s *Server) listFailures(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, s.failures.List())
+}
+
+// addFailures queues a JSON array of failures after any already queued.
+func (s *Server) addFailures(ctx *gin.Context) {
+	var failures []Failure
+	if err := ctx.ShouldBindJSON(&failures); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.failures.Add(failures...); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, s.failures.List())
+}
+
+func (s *Server) clearFailures(ctx *gin.Context) {
+	s.failures.Clear()
+	ctx.Status(http.StatusNoContent)
+}
+
+var Providers = wire.NewSet(
+	NewEventStore,
+	NewTokenIssuer,
+	NewFailures,
+	NewServer,
+)
@@ -0,0 +1,339 @@
+package fakemeetup
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEventsQuery = `query ($urlname: String!, $itemsNum: Int!, $cursor: String) {
+  groupByUrlname(urlname: $urlname) {
+    events(first: $itemsNum, after: $cursor, filter: { status: [%s] }) { totalCount }
+  }
+}`
+
+type testServer struct {
+	router   *gin.Engine
+	failures *Failures
+}
+
+func setupServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	timeSource := clock.NewMockTimeSource(fixtureNow)
+	failures := &Failures{}
+	server := NewServer(
+		newFixtureStore(t),
+		NewTokenIssuer(&fakemeetupconfig.Config{TokenTTL: time.Hour}, timeSource),
+		failures,
+		timeSource,
+		logging.NewMockLogger(),
+	)
+
+	return &testServer{
+		router:   NewRouter(logging.NewMockLogger(), server),
+		failures: failures,
+	}
+}
+
+func (s *testServer) requestToken(t *testing.T, form url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func signedAssertion(t *testing.T) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:  "client-key",
+		Subject: "user-id",
+	})
+	token.Header["kid"] = "signing-key"
+
+	assertion, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	return assertion
+}
+
+func (s *testServer) accessToken(t *testing.T) tokenResponse {
+	t.Helper()
+
+	w := s.requestToken(t, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {signedAssertion(t)},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var token tokenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+
+	return token
+}
+
+func (s *testServer) query(
+	t *testing.T,
+	token string,
+	status string,
+	variables map[string]any,
+) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	body, err := json.Marshal(graphQLRequest{
+		Query:     strings.Replace(testEventsQuery, "%s", status, 1),
+		Variables: variables,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, GraphQLPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var resp map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return w, resp
+}
+
+func eventsFrom(t *testing.T, resp map[string]any) map[string]any {
+	t.Helper()
+
+	data, _ := resp["data"].(map[string]any)
+	group, _ := data["groupByUrlname"].(map[string]any)
+	events, ok := group["events"].(map[string]any)
+	require.True(t, ok, "response has no events: %v", resp)
+
+	return events
+}
+
+func TestServer_Token(t *testing.T) {
+	t.Run("exchanges assertions and refresh tokens", func(t *testing.T) {
+		s := setupServer(t)
+
+		token := s.accessToken(t)
+		assert.NotEmpty(t, token.AccessToken)
+		assert.Equal(t, 3600, token.ExpiresIn)
+
+		refreshForm := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {token.RefreshToken},
+		}
+		w := s.requestToken(t, refreshForm)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var refreshed tokenResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+		assert.NotEqual(t, token.AccessToken, refreshed.AccessToken)
+
+		// Refresh tokens can only be used once.
+		w = s.requestToken(t, refreshForm)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects malformed assertions", func(t *testing.T) {
+		s := setupServer(t)
+
+		w := s.requestToken(t, url.Values{
+			"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+			"assertion":  {"not-a-jwt"},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_grant")
+	})
+
+	t.Run("rejects unsupported grant types", func(t *testing.T) {
+		s := setupServer(t)
+
+		w := s.requestToken(t, url.Values{"grant_type": {"password"}})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unsupported_grant_type")
+	})
+}
+
+func TestServer_GraphQL(t *testing.T) {
+	t.Run("pages through events", func(t *testing.T) {
+		s := setupServer(t)
+		token := s.accessToken(t).AccessToken
+
+		w, resp := s.query(t, token, eventStatusActive, map[string]any{
+			"urlname":  "sgfdevs",
+			"itemsNum": 1,
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		events := eventsFrom(t, resp)
+		pageInfo := events["pageInfo"].(map[string]any)
+		assert.Equal(t, float64(2), events["totalCount"])
+		assert.Equal(t, true, pageInfo["hasNextPage"])
+		edges := events["edges"].([]any)
+		require.Len(t, edges, 1)
+		assert.Equal(t, "future-1", edges[0].(map[string]any)["node"].(map[string]any)["id"])
+
+		_, resp = s.query(t, token, eventStatusActive, map[string]any{
+			"urlname":  "sgfdevs",
+			"itemsNum": 1,
+			"cursor":   pageInfo["endCursor"],
+		})
+
+		events = eventsFrom(t, resp)
+		assert.Equal(t, false, events["pageInfo"].(map[string]any)["hasNextPage"])
+		edges = events["edges"].([]any)
+		require.Len(t, edges, 1)
+		assert.Equal(t, "future-2", edges[0].(map[string]any)["node"].(map[string]any)["id"])
+	})
+
+	t.Run("filters by status", func(t *testing.T) {
+		s := setupServer(t)
+
+		_, resp := s.query(t, s.accessToken(t).AccessToken, eventStatusPast, map[string]any{
+			"urlname":  "sgfdevs",
+			"itemsNum": 50,
+		})
+
+		edges := eventsFrom(t, resp)["edges"].([]any)
+		require.Len(t, edges, 1)
+		assert.Equal(t, "past-1", edges[0].(map[string]any)["node"].(map[string]any)["id"])
+	})
+
+	t.Run("requires a token", func(t *testing.T) {
+		s := setupServer(t)
+
+		w, _ := s.query(t, "unknown", eventStatusActive, map[string]any{"urlname": "sgfdevs"})
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("unknown groups return a graphql error", func(t *testing.T) {
+		s := setupServer(t)
+
+		w, resp := s.query(t, s.accessToken(t).AccessToken, eventStatusActive, map[string]any{
+			"urlname": "unknown",
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, resp, "errors")
+	})
+}
+
+func TestServer_Failures(t *testing.T) {
+	t.Run("rate limited", func(t *testing.T) {
+		s := setupServer(t)
+		token := s.accessToken(t).AccessToken
+		require.NoError(t, s.failures.Add(Failure{
+			Mode:       FailureRateLimited,
+			RetryAfter: 5,
+			Times:      1,
+		}))
+
+		w, _ := s.query(t, token, eventStatusActive, map[string]any{"urlname": "sgfdevs"})
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "5", w.Header().Get("Retry-After"))
+
+		w, _ = s.query(t, token, eventStatusActive, map[string]any{"urlname": "sgfdevs"})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("server error on the token endpoint", func(t *testing.T) {
+		s := setupServer(t)
+		require.NoError(t, s.failures.Add(Failure{
+			Mode:     FailureServerError,
+			Endpoint: EndpointToken,
+		}))
+
+		w := s.requestToken(t, url.Values{
+			"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+			"assertion":  {signedAssertion(t)},
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("graphql rate limit", func(t *testing.T) {
+		s := setupServer(t)
+		token := s.accessToken(t).AccessToken
+		require.NoError(t, s.failures.Add(Failure{Mode: FailureGraphQLRateLimited, RetryAfter: 60}))
+
+		w, resp := s.query(t, token, eventStatusActive, map[string]any{"urlname": "sgfdevs"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		graphQLError := resp["errors"].([]any)[0].(map[string]any)
+		extensions := graphQLError["extensions"].(map[string]any)
+		assert.Equal(t, "RATE_LIMITED", extensions["code"])
+		assert.Equal(t, fixtureNow.Add(time.Minute).Format(time.RFC3339), extensions["resetAt"])
+	})
+
+	t.Run("graphql error", func(t *testing.T) {
+		s := setupServer(t)
+		token := s.accessToken(t).AccessToken
+		require.NoError(t, s.failures.Add(Failure{Mode: FailureGraphQLError, Message: "boom"}))
+
+		_, resp := s.query(t, token, eventStatusActive, map[string]any{"urlname": "sgfdevs"})
+
+		assert.Nil(t, resp["data"])
+		assert.Equal(t, "boom", resp["errors"].([]any)[0].(map[string]any)["message"])
+	})
+
+	t.Run("slow responses", func(t *testing.T) {
+		s := setupServer(t)
+		token := s.accessToken(t).AccessToken
+		require.NoError(t, s.failures.Add(Failure{Mode: FailureSlow, DelayMS: 50, Times: 1}))
+
+		start := time.Now()
+		w, _ := s.query(t, token, eventStatusActive, map[string]any{"urlname": "sgfdevs"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("managed over http", func(t *testing.T) {
+		s := setupServer(t)
+
+		req := httptest.NewRequest(
+			http.MethodPost,
+			FailuresPath,
+			strings.NewReader(`[{"mode": "server_error", "endpoint": "graphql", "times": 2}]`),
+		)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, s.failures.List(), 1)
+
+		req = httptest.NewRequest(http.MethodPost, FailuresPath, strings.NewReader(`[{}]`))
+		w = httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		req = httptest.NewRequest(http.MethodDelete, FailuresPath, nil)
+		w = httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, s.failures.List())
+	})
+}
@@ -0,0 +1,98 @@
+package fakemeetup
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidGrant = errors.New("invalid grant")
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+}
+
+// TokenIssuer hands out access tokens for JWT assertions and refresh tokens. Assertions must be
+// well formed, but their signatures aren't checked since the fake has no public keys.
+type TokenIssuer struct {
+	lock          sync.Mutex
+	ttl           time.Duration
+	accessTokens  map[string]time.Time
+	refreshTokens map[string]bool
+	timeSource    clock.TimeSource
+}
+
+func NewTokenIssuer(config *fakemeetupconfig.Config, timeSource clock.TimeSource) *TokenIssuer {
+	return &TokenIssuer{
+		ttl:           config.TokenTTL,
+		accessTokens:  map[string]time.Time{},
+		refreshTokens: map[string]bool{},
+		timeSource:    timeSource,
+	}
+}
+
+// ExchangeAssertion issues a token for a JWT naming the client as its issuer and the user as its
+// subject.
+func (i *TokenIssuer) ExchangeAssertion(assertion string) (*tokenResponse, error) {
+	var claims jwt.RegisteredClaims
+	token, _, err := jwt.NewParser().ParseUnverified(assertion, &claims)
+	if err != nil {
+		return nil, ErrInvalidGrant
+	}
+
+	if token.Header["kid"] == nil || claims.Issuer == "" || claims.Subject == "" {
+		return nil, ErrInvalidGrant
+	}
+
+	return i.issue(), nil
+}
+
+// ExchangeRefreshToken issues a new token for a refresh token this issuer handed out. Refresh
+// tokens can only be used once.
+func (i *TokenIssuer) ExchangeRefreshToken(refreshToken string) (*tokenResponse, error) {
+	i.lock.Lock()
+	valid := i.refreshTokens[refreshToken]
+	delete(i.refreshTokens, refreshToken)
+	i.lock.Unlock()
+
+	if !valid {
+		return nil, ErrInvalidGrant
+	}
+
+	return i.issue(), nil
+}
+
+// Valid reports whether the access token was issued here and hasn't expired.
+func (i *TokenIssuer) Valid(accessToken string) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	expiresAt, ok := i.accessTokens[accessToken]
+	return ok && i.timeSource.Now().Before(expiresAt)
+}
+
+func (i *TokenIssuer) issue() *tokenResponse {
+	resp := &tokenResponse{
+		AccessToken:  uuid.NewString(),
+		RefreshToken: uuid.NewString(),
+		ExpiresIn:    int(i.ttl / time.Second),
+		TokenType:    "bearer",
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.accessTokens[resp.AccessToken] = i.timeSource.Now().Add(i.ttl)
+	i.refreshTokens[resp.RefreshToken] = true
+
+	return resp
+}
@@ -0,0 +1,30 @@
+//go:build wireinject
+// +build wireinject
+
+package fakemeetup
+
+import (
+	"context"
+	"net/http"
+
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/google/wire"
+)
+
+var CommonProviders = wire.NewSet(
+	fakemeetupconfig.ConfigProviders,
+	logging.DefaultLogger,
+	clock.RealClockProvider,
+)
+
+func InitHTTPServer(ctx context.Context) (*http.Server, error) {
+	panic(wire.Build(
+		CommonProviders,
+		Providers,
+		NewRouter,
+		NewHTTPServer,
+	))
+}
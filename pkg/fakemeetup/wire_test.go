@@ -0,0 +1,19 @@
+package fakemeetup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitHTTPServer(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("FAKE_MEETUP_PORT", "9090")
+
+	server, err := InitHTTPServer(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, ":9090", server.Addr)
+}
@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+)
+
+// BackfillOptions narrows a backfill. The zero value resumes every enabled group from its
+// checkpoint and runs until each group's past events are exhausted.
+type BackfillOptions struct {
+	Groups []string `json:"groups"`
+	// Restart ignores saved checkpoints and pages from each group's first past event.
+	Restart bool `json:"restart"`
+	// MaxPages limits how many Meetup pages each group fetches in this run. Zero is unlimited.
+	MaxPages int `json:"maxPages"`
+	// CatchUp keeps paging past a completed checkpoint's cursor to pick up events held since
+	// the group's backfill finished.
+	CatchUp bool `json:"catchUp"`
+}
+
+type BackfillReport struct {
+	Groups []GroupBackfillReport `json:"groups"`
+}
+
+type GroupBackfillReport struct {
+	Group string `json:"group"`
+	// Pages and Events count what this run fetched, not the group's running totals.
+	Pages     int    `json:"pages"`
+	Events    int    `json:"events"`
+	Completed bool   `json:"completed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Backfiller copies each group's past Meetup events into the archive table. Progress is saved
+// after every page so a backfill can be stopped and resumed.
+type Backfiller struct {
+	config                       ServiceConfig
+	timeSource                   clock.TimeSource
+	logger                       *slog.Logger
+	eventRepository              EventRepository
+	meetupRepository             MeetupRepository
+	backfillCheckpointRepository BackfillCheckpointRepository
+}
+
+func NewBackfiller(
+	config ServiceConfig,
+	timeSource clock.TimeSource,
+	logger *slog.Logger,
+	eventRepository EventRepository,
+	meetupRepository MeetupRepository,
+	backfillCheckpointRepository BackfillCheckpointRepository,
+) *Backfiller {
+	return &Backfiller{
+		config:                       config,
+		timeSource:                   timeSource,
+		logger:                       logger,
+		eventRepository:              eventRepository,
+		meetupRepository:             meetupRepository,
+		backfillCheckpointRepository: backfillCheckpointRepository,
+	}
+}
+
+// Backfill runs groups one at a time to keep the load on Meetup's API low. A failing group is
+// reported and the remaining groups still run.
+func (b *Backfiller) Backfill(ctx context.Context, opts BackfillOptions) (*BackfillReport, error) {
+	groups, err := selectGroups(b.config.Groups, opts.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BackfillReport{Groups: make([]GroupBackfillReport, 0, len(groups))}
+	var multiErr error
+
+	for _, group := range groups {
+		groupReport := GroupBackfillReport{Group: group.URLName}
+
+		if err := b.backfillGroup(ctx, group, opts, &groupReport); err != nil {
+			b.logger.Error("error backfilling group",
+				slog.String("group", group.URLName),
+				slog.String("error", err.Error()),
+			)
+			groupReport.Error = err.Error()
+			multiErr = errors.Join(multiErr, fmt.Errorf("%s: %w", group.URLName, err))
+		}
+
+		report.Groups = append(report.Groups, groupReport)
+	}
+
+	return report, multiErr
+}
+
+func (b *Backfiller) backfillGroup(
+	ctx context.Context,
+	group importerconfig.GroupConfig,
+	opts BackfillOptions,
+	report *GroupBackfillReport,
+) error {
+	checkpoint, err := b.backfillCheckpointRepository.GetCheckpoint(ctx, group.URLName)
+	if err != nil {
+		return err
+	}
+
+	if opts.Restart {
+		checkpoint.Cursor = ""
+		checkpoint.Pages = 0
+		checkpoint.Events = 0
+		checkpoint.Completed = false
+	}
+
+	if opts.CatchUp {
+		checkpoint.Completed = false
+	}
+
+	for !checkpoint.Completed && (opts.MaxPages <= 0 || report.Pages < opts.MaxPages) {
+		page, err := b.meetupRepository.GetPastEventsPage(ctx, group.URLName, checkpoint.Cursor)
+		if err != nil {
+			return err
+		}
+
+		for i := range page.Events {
+			applyGroupConfig(&page.Events[i], ownerGroupConfig(b.config.Groups, &page.Events[i]))
+		}
+
+		if err := b.eventRepository.UpsertArchivedEvents(ctx, page.Events); err != nil {
+			return err
+		}
+
+		// The checkpoint is only advanced once the page is written, so an interrupted page is
+		// fetched again rather than skipped. An empty page has no end cursor, so the next run
+		// asks again from the same place.
+		if page.EndCursor != "" {
+			checkpoint.Cursor = page.EndCursor
+		}
+		checkpoint.Pages++
+		checkpoint.Events += len(page.Events)
+		checkpoint.Completed = !page.HasNextPage
+		checkpoint.UpdatedAt = b.timeSource.Now().UTC()
+
+		if err := b.backfillCheckpointRepository.SaveCheckpoint(ctx, *checkpoint); err != nil {
+			return err
+		}
+
+		report.Pages++
+		report.Events += len(page.Events)
+	}
+
+	report.Completed = checkpoint.Completed
+
+	b.logger.Info("backfilled group",
+		slog.String("group", group.URLName),
+		slog.Int("pages", report.Pages),
+		slog.Int("events", report.Events),
+		slog.Int("totalEvents", checkpoint.Events),
+		slog.Bool("completed", checkpoint.Completed),
+	)
+
+	return nil
+}
+
+// WriteText writes the report in a form meant for reading in a terminal.
+func (r *BackfillReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+
+	for _, group := range r.Groups {
+		status := "in progress"
+		if group.Completed {
+			status = "complete"
+		}
+
+		fmt.Fprintf(&b, "%s: %d pages, %d events, %s\n",
+			group.Group, group.Pages, group.Events, status)
+
+		if group.Error != "" {
+			fmt.Fprintf(&b, "  error: %s\n", group.Error)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *BackfillReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
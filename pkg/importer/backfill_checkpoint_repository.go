@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"context"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type BackfillCheckpointRepository interface {
+	// GetCheckpoint returns the group's checkpoint, or an empty one if the group has never been
+	// backfilled.
+	GetCheckpoint(ctx context.Context, group string) (*models.BackfillCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint models.BackfillCheckpoint) error
+}
+
+type DynamoDBBackfillCheckpointRepositoryConfig struct {
+	BackfillCheckpointsTableName string
+}
+
+func NewDynamoDBBackfillCheckpointRepositoryConfig(
+	config *importerconfig.Config,
+) DynamoDBBackfillCheckpointRepositoryConfig {
+	return DynamoDBBackfillCheckpointRepositoryConfig{
+		BackfillCheckpointsTableName: config.BackfillCheckpointsTableName,
+	}
+}
+
+type DynamoDBBackfillCheckpointRepository struct {
+	config DynamoDBBackfillCheckpointRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBBackfillCheckpointRepository(
+	config DynamoDBBackfillCheckpointRepositoryConfig,
+	db *db.Client,
+) *DynamoDBBackfillCheckpointRepository {
+	return &DynamoDBBackfillCheckpointRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (r *DynamoDBBackfillCheckpointRepository) GetCheckpoint(
+	ctx context.Context,
+	group string,
+) (*models.BackfillCheckpoint, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.config.BackfillCheckpointsTableName),
+		Key: map[string]types.AttributeValue{
+			"groupId": &types.AttributeValueMemberS{Value: group},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := models.BackfillCheckpoint{GroupID: group}
+	if result.Item == nil {
+		return &checkpoint, nil
+	}
+
+	if err = attributevalue.UnmarshalMap(result.Item, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+func (r *DynamoDBBackfillCheckpointRepository) SaveCheckpoint(
+	ctx context.Context,
+	checkpoint models.BackfillCheckpoint,
+) error {
+	item, err := attributevalue.MarshalMap(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.config.BackfillCheckpointsTableName),
+		Item:      item,
+	})
+
+	return err
+}
+
+var BackfillCheckpointRepositoryProviders = wire.NewSet(
+	wire.Bind(new(BackfillCheckpointRepository), new(*DynamoDBBackfillCheckpointRepository)),
+	NewDynamoDBBackfillCheckpointRepositoryConfig,
+	NewDynamoDBBackfillCheckpointRepository,
+)
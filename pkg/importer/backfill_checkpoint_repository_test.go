@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDynamoDBBackfillCheckpointRepositoryConfig(t *testing.T) {
+	cfg := &importerconfig.Config{BackfillCheckpointsTableName: "checkpoints"}
+
+	repoConfig := NewDynamoDBBackfillCheckpointRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.BackfillCheckpointsTableName, repoConfig.BackfillCheckpointsTableName)
+}
+
+func TestDynamoDBBackfillCheckpointRepository(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	repo := NewDynamoDBBackfillCheckpointRepository(
+		DynamoDBBackfillCheckpointRepositoryConfig{
+			BackfillCheckpointsTableName: *infra.BackfillCheckpointsTableProps.TableName,
+		},
+		testDB.Client,
+	)
+
+	t.Run("returns an empty checkpoint for new groups", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		checkpoint, err := repo.GetCheckpoint(ctx, "group1")
+		require.NoError(t, err)
+
+		assert.Equal(t, &models.BackfillCheckpoint{GroupID: "group1"}, checkpoint)
+	})
+
+	t.Run("returns the saved checkpoint", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		saved := models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-2",
+			Pages:     2,
+			Events:    100,
+			UpdatedAt: time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, repo.SaveCheckpoint(ctx, saved))
+
+		checkpoint, err := repo.GetCheckpoint(ctx, "group1")
+		require.NoError(t, err)
+
+		assert.Equal(t, &saved, checkpoint)
+	})
+}
@@ -0,0 +1,298 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/fakers"
+	"sgf-meetup-api/pkg/shared/logging"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockBackfillCheckpointRepository struct {
+	mock.Mock
+}
+
+func (m *MockBackfillCheckpointRepository) GetCheckpoint(
+	ctx context.Context,
+	group string,
+) (*models.BackfillCheckpoint, error) {
+	args := m.Called(ctx, group)
+	checkpoint, _ := args.Get(0).(*models.BackfillCheckpoint)
+	return checkpoint, args.Error(1)
+}
+
+func (m *MockBackfillCheckpointRepository) SaveCheckpoint(
+	ctx context.Context,
+	checkpoint models.BackfillCheckpoint,
+) error {
+	args := m.Called(ctx, checkpoint)
+	return args.Error(0)
+}
+
+func TestBackfiller_Backfill(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+
+	pastPage := func(cursor string, hasNextPage bool) *EventsPage {
+		return &EventsPage{
+			Events: []models.MeetupEvent{
+				meetupFaker.CreateEvent("group1", now.AddDate(-1, 0, 0)),
+				meetupFaker.CreateEvent("group1", now.AddDate(-2, 0, 0)),
+			},
+			EndCursor:   cursor,
+			HasNextPage: hasNextPage,
+		}
+	}
+
+	newBackfiller := func(
+		eventRepo *MockEventRepository,
+		meetupRepo *MockMeetupRepository,
+		checkpointRepo *MockBackfillCheckpointRepository,
+	) *Backfiller {
+		return NewBackfiller(
+			ServiceConfig{Groups: groupConfigs("group1", "group2")},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			checkpointRepo,
+		)
+	}
+
+	t.Run("pages through past events and saves a checkpoint per page", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		checkpointRepo := new(MockBackfillCheckpointRepository)
+
+		checkpointRepo.On("GetCheckpoint", ctx, "group1").
+			Return(&models.BackfillCheckpoint{GroupID: "group1"}, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "").
+			Return(pastPage("cursor-1", true), nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "cursor-1").
+			Return(pastPage("cursor-2", false), nil)
+		eventRepo.On("UpsertArchivedEvents", ctx, mock.Anything).Return(nil)
+		checkpointRepo.On("SaveCheckpoint", ctx, models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-1",
+			Pages:     1,
+			Events:    2,
+			UpdatedAt: now,
+		}).Return(nil).Once()
+		checkpointRepo.On("SaveCheckpoint", ctx, models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-2",
+			Pages:     2,
+			Events:    4,
+			Completed: true,
+			UpdatedAt: now,
+		}).Return(nil).Once()
+
+		report, err := newBackfiller(eventRepo, meetupRepo, checkpointRepo).
+			Backfill(ctx, BackfillOptions{Groups: []string{"group1"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []GroupBackfillReport{
+			{Group: "group1", Pages: 2, Events: 4, Completed: true},
+		}, report.Groups)
+		eventRepo.AssertNumberOfCalls(t, "UpsertArchivedEvents", 2)
+		checkpointRepo.AssertExpectations(t)
+	})
+
+	t.Run("resumes from the saved cursor", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		checkpointRepo := new(MockBackfillCheckpointRepository)
+
+		checkpointRepo.On("GetCheckpoint", ctx, "group1").Return(&models.BackfillCheckpoint{
+			GroupID: "group1",
+			Cursor:  "cursor-4",
+			Pages:   4,
+			Events:  8,
+		}, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "cursor-4").
+			Return(pastPage("cursor-5", false), nil)
+		eventRepo.On("UpsertArchivedEvents", ctx, mock.Anything).Return(nil)
+		checkpointRepo.On("SaveCheckpoint", ctx, mock.MatchedBy(
+			func(checkpoint models.BackfillCheckpoint) bool {
+				return checkpoint.Pages == 5 && checkpoint.Events == 10 && checkpoint.Completed
+			},
+		)).Return(nil)
+
+		report, err := newBackfiller(eventRepo, meetupRepo, checkpointRepo).
+			Backfill(ctx, BackfillOptions{Groups: []string{"group1"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, report.Groups[0].Pages)
+		meetupRepo.AssertExpectations(t)
+		checkpointRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips completed groups unless restarted", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		checkpointRepo := new(MockBackfillCheckpointRepository)
+
+		checkpointRepo.On("GetCheckpoint", ctx, "group1").Return(&models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-9",
+			Pages:     9,
+			Events:    18,
+			Completed: true,
+		}, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "").
+			Return(pastPage("cursor-1", false), nil)
+		eventRepo.On("UpsertArchivedEvents", ctx, mock.Anything).Return(nil)
+		checkpointRepo.On("SaveCheckpoint", ctx, mock.Anything).Return(nil)
+
+		backfiller := newBackfiller(eventRepo, meetupRepo, checkpointRepo)
+
+		report, err := backfiller.Backfill(ctx, BackfillOptions{Groups: []string{"group1"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []GroupBackfillReport{{Group: "group1", Completed: true}}, report.Groups)
+		meetupRepo.AssertNotCalled(
+			t,
+			"GetPastEventsPage",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		)
+
+		report, err = backfiller.Backfill(ctx, BackfillOptions{
+			Groups:  []string{"group1"},
+			Restart: true,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, report.Groups[0].Pages)
+		checkpointRepo.AssertCalled(t, "SaveCheckpoint", ctx, mock.MatchedBy(
+			func(checkpoint models.BackfillCheckpoint) bool {
+				return checkpoint.Cursor == "cursor-1" && checkpoint.Pages == 1
+			},
+		))
+	})
+
+	t.Run("catches up from a completed group's cursor", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		checkpointRepo := new(MockBackfillCheckpointRepository)
+
+		checkpointRepo.On("GetCheckpoint", ctx, "group1").Return(&models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-9",
+			Pages:     9,
+			Events:    18,
+			Completed: true,
+		}, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "cursor-9").
+			Return(&EventsPage{Events: []models.MeetupEvent{}}, nil)
+		eventRepo.On("UpsertArchivedEvents", ctx, mock.Anything).Return(nil)
+		checkpointRepo.On("SaveCheckpoint", ctx, models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-9",
+			Pages:     10,
+			Events:    18,
+			Completed: true,
+			UpdatedAt: now,
+		}).Return(nil).Once()
+
+		report, err := newBackfiller(eventRepo, meetupRepo, checkpointRepo).
+			Backfill(ctx, BackfillOptions{Groups: []string{"group1"}, CatchUp: true})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]GroupBackfillReport{{Group: "group1", Pages: 1, Completed: true}},
+			report.Groups,
+		)
+		checkpointRepo.AssertExpectations(t)
+	})
+
+	t.Run("stops after max pages", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		checkpointRepo := new(MockBackfillCheckpointRepository)
+
+		checkpointRepo.On("GetCheckpoint", ctx, "group1").
+			Return(&models.BackfillCheckpoint{GroupID: "group1"}, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "").
+			Return(pastPage("cursor-1", true), nil)
+		eventRepo.On("UpsertArchivedEvents", ctx, mock.Anything).Return(nil)
+		checkpointRepo.On("SaveCheckpoint", ctx, mock.Anything).Return(nil)
+
+		report, err := newBackfiller(eventRepo, meetupRepo, checkpointRepo).
+			Backfill(ctx, BackfillOptions{Groups: []string{"group1"}, MaxPages: 1})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]GroupBackfillReport{{Group: "group1", Pages: 1, Events: 2}},
+			report.Groups,
+		)
+		meetupRepo.AssertNumberOfCalls(t, "GetPastEventsPage", 1)
+	})
+
+	t.Run("keeps the checkpoint when a page fails and continues with other groups",
+		func(t *testing.T) {
+			eventRepo := new(MockEventRepository)
+			meetupRepo := new(MockMeetupRepository)
+			checkpointRepo := new(MockBackfillCheckpointRepository)
+
+			checkpointRepo.On("GetCheckpoint", ctx, "group1").
+				Return(&models.BackfillCheckpoint{GroupID: "group1"}, nil)
+			checkpointRepo.On("GetCheckpoint", ctx, "group2").
+				Return(&models.BackfillCheckpoint{GroupID: "group2"}, nil)
+			meetupRepo.On("GetPastEventsPage", ctx, "group1", "").
+				Return(nil, errors.New("meetup unavailable"))
+			meetupRepo.On("GetPastEventsPage", ctx, "group2", "").
+				Return(pastPage("cursor-1", false), nil)
+			eventRepo.On("UpsertArchivedEvents", ctx, mock.Anything).Return(nil)
+			checkpointRepo.On("SaveCheckpoint", ctx, mock.Anything).Return(nil)
+
+			report, err := newBackfiller(eventRepo, meetupRepo, checkpointRepo).
+				Backfill(ctx, BackfillOptions{})
+			require.Error(t, err)
+
+			require.Len(t, report.Groups, 2)
+			assert.Equal(t, "meetup unavailable", report.Groups[0].Error)
+			assert.True(t, report.Groups[1].Completed)
+			checkpointRepo.AssertNumberOfCalls(t, "SaveCheckpoint", 1)
+		},
+	)
+
+	t.Run("rejects groups that are not configured", func(t *testing.T) {
+		backfiller := newBackfiller(
+			new(MockEventRepository),
+			new(MockMeetupRepository),
+			new(MockBackfillCheckpointRepository),
+		)
+
+		_, err := backfiller.Backfill(ctx, BackfillOptions{Groups: []string{"unknown"}})
+
+		assert.ErrorIs(t, err, ErrUnknownGroup)
+	})
+}
+
+func TestBackfillReport_WriteText(t *testing.T) {
+	report := &BackfillReport{Groups: []GroupBackfillReport{
+		{Group: "group1", Pages: 2, Events: 75, Completed: true},
+		{Group: "group2", Pages: 1, Events: 50, Error: "meetup unavailable"},
+	}}
+
+	var b bytes.Buffer
+	require.NoError(t, report.WriteText(&b))
+
+	assert.Equal(t, "group1: 2 pages, 75 events, complete\n"+
+		"group2: 1 pages, 50 events, in progress\n"+
+		"  error: meetup unavailable\n", b.String())
+}
@@ -2,6 +2,7 @@ package importer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
@@ -24,6 +25,9 @@ type EventRepository interface {
 	GetUpcomingEventsForGroup(ctx context.Context, group string) ([]models.MeetupEvent, error)
 	ArchiveEvents(ctx context.Context, eventIds []string) error
 	UpsertEvents(ctx context.Context, events []models.MeetupEvent) error
+	UpsertArchivedEvents(ctx context.Context, events []models.MeetupEvent) error
+	// RemoveCoHost stops listing the events for a group that no longer co-hosts them.
+	RemoveCoHost(ctx context.Context, group string, events []models.MeetupEvent) error
 }
 
 type DynamoDBEventRepositoryConfig struct {
@@ -72,6 +76,13 @@ func (er *DynamoDBEventRepository) GetUpcomingEventsForGroup(
 		And(expression.Key("dateTime").
 			GreaterThan(expression.Value(now)))
 
+	return er.queryGroupEvents(ctx, keyCond)
+}
+
+func (er *DynamoDBEventRepository) queryGroupEvents(
+	ctx context.Context,
+	keyCond expression.KeyConditionBuilder,
+) ([]models.MeetupEvent, error) {
 	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
 	if err != nil {
 		return nil, err
@@ -85,22 +96,74 @@ func (er *DynamoDBEventRepository) GetUpcomingEventsForGroup(
 		KeyConditionExpression:    expr.KeyCondition(),
 	})
 
-	var allEvents []models.MeetupEvent
+	var items []map[string]types.AttributeValue
 
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
 			return nil, err
 		}
+		items = append(items, page.Items...)
+	}
+
+	items, err = er.resolveListings(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	var allEvents []models.MeetupEvent
+	if err := attributevalue.UnmarshalListOfMaps(items, &allEvents); err != nil {
+		return nil, err
+	}
+
+	return allEvents, nil
+}
+
+// resolveListings replaces listings of co-hosted events with the events they point to. Listings
+// left behind by an event that was archived are dropped.
+func (er *DynamoDBEventRepository) resolveListings(
+	ctx context.Context,
+	items []map[string]types.AttributeValue,
+) ([]map[string]types.AttributeValue, error) {
+	var listedIDs []string
+	for _, item := range items {
+		if id, ok := models.ListedEventID(item); ok {
+			listedIDs = append(listedIDs, id)
+		}
+	}
+
+	if len(listedIDs) == 0 {
+		return items, nil
+	}
+
+	listedItems, err := er.getItems(ctx, listedIDs)
+	if err != nil {
+		return nil, err
+	}
 
-		var events []models.MeetupEvent
-		if err := attributevalue.UnmarshalListOfMaps(page.Items, &events); err != nil {
+	listedByID := make(map[string]map[string]types.AttributeValue, len(listedItems))
+	for _, item := range listedItems {
+		var id string
+		if err := attributevalue.Unmarshal(item["id"], &id); err != nil {
 			return nil, err
 		}
-		allEvents = append(allEvents, events...)
+		listedByID[id] = item
 	}
 
-	return allEvents, nil
+	resolved := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, item := range items {
+		id, ok := models.ListedEventID(item)
+		if !ok {
+			resolved = append(resolved, item)
+			continue
+		}
+
+		if listed, ok := listedByID[id]; ok {
+			resolved = append(resolved, listed)
+		}
+	}
+
+	return resolved, nil
 }
 
 func (er *DynamoDBEventRepository) ArchiveEvents(ctx context.Context, eventIds []string) error {
@@ -118,58 +181,333 @@ func (er *DynamoDBEventRepository) ArchiveEvents(ctx context.Context, eventIds [
 			continue
 		}
 
-		if err := er.writeToArchive(ctx, items); err != nil {
-			return err
+		if err := er.moveToArchive(ctx, items); err != nil {
+			return fmt.Errorf("archive chunk: %w", err)
 		}
 
-		if err := er.deleteIds(ctx, chunk); err != nil {
-			return fmt.Errorf("delete chunk: %w", err)
+		if err := er.deleteListings(ctx, items); err != nil {
+			return fmt.Errorf("delete listings: %w", err)
 		}
 	}
 	return nil
 }
 
+// maxEventWriteAttempts is how many times an event is read again and rewritten when another
+// import changes its co-hosts before the write lands.
+const maxEventWriteAttempts = 5
+
+// UpsertEvents writes the events along with a listing for each co-host. Co-hosts already stored
+// for an event are kept with the event's own co-hosts added, since the imports of an event's
+// hosts can overlap and each only knows about its own group. Events are written in
+// transactions conditioned on their stored co-hosts not changing, and merged again with the new
+// ones when they have.
 func (er *DynamoDBEventRepository) UpsertEvents(
 	ctx context.Context,
 	events []models.MeetupEvent,
 ) error {
-	return er.upsertEventsToTable(ctx, events, er.config.EventsTableName)
+	if len(events) == 0 {
+		return nil
+	}
+
+	// A transaction can't write an item twice, so only the last copy of an event is written.
+	ids := make([]string, 0, len(events))
+	eventsByID := make(map[string]models.MeetupEvent, len(events))
+	for _, event := range events {
+		if _, ok := eventsByID[event.ID]; !ok {
+			ids = append(ids, event.ID)
+		}
+		eventsByID[event.ID] = event
+	}
+
+	uniqueEvents := make([]models.MeetupEvent, len(ids))
+	for i, id := range ids {
+		uniqueEvents[i] = eventsByID[id]
+	}
+
+	storedEvents, err := er.getEvents(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	var writeRequests []types.WriteRequest
+	for chunk := range slices.Chunk(uniqueEvents, db.MaxTransactSize) {
+		written, err := er.putEvents(ctx, chunk, storedEvents)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range written {
+			for _, listing := range event.Listings() {
+				av, err := attributevalue.MarshalMap(listing)
+				if err != nil {
+					return err
+				}
+
+				writeRequests = append(writeRequests, types.WriteRequest{
+					PutRequest: &types.PutRequest{Item: av},
+				})
+			}
+		}
+	}
+
+	if len(writeRequests) == 0 {
+		return nil
+	}
+
+	return db.BatchWriteItems(
+		ctx,
+		er.db,
+		db.DefaultRetryPolicy,
+		er.config.EventsTableName,
+		writeRequests,
+	)
 }
 
-func (er *DynamoDBEventRepository) upsertEventsToTable(
+// putEvents writes the events, each with the co-hosts in storedEvents added, in a single
+// transaction. Events whose co-hosts changed since they were read are read again into
+// storedEvents and the transaction retried. It returns the events as written.
+func (er *DynamoDBEventRepository) putEvents(
 	ctx context.Context,
 	events []models.MeetupEvent,
-	table string,
+	storedEvents map[string]*models.MeetupEvent,
+) ([]models.MeetupEvent, error) {
+	for attempt := 1; ; attempt++ {
+		written := make([]models.MeetupEvent, len(events))
+		items := make([]types.TransactWriteItem, len(events))
+		for i, event := range events {
+			stored := storedEvents[event.ID]
+
+			written[i] = event
+			if stored != nil {
+				for _, coHost := range stored.CoHosts() {
+					written[i].AddCoHost(coHost)
+				}
+			}
+
+			item, err := er.eventPut(written[i], stored)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+
+		err := db.TransactWriteItems(ctx, er.db, db.DefaultRetryPolicy, items)
+
+		conflicts := coHostConflicts(err)
+		if len(conflicts) == 0 {
+			if err != nil {
+				return nil, err
+			}
+			return written, nil
+		}
+
+		if attempt >= maxEventWriteAttempts {
+			return nil, fmt.Errorf("write events: co-hosts kept changing: %w", err)
+		}
+
+		for _, i := range conflicts {
+			id := events[i].ID
+			if storedEvents[id], err = er.getEvent(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// coHostConflicts returns the indexes of the transaction's events that were rejected because
+// their co-hosts changed.
+func coHostConflicts(err error) []int {
+	var cancelled *types.TransactionCanceledException
+	if !errors.As(err, &cancelled) {
+		return nil
+	}
+
+	var conflicts []int
+	for i, reason := range cancelled.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			conflicts = append(conflicts, i)
+		}
+	}
+
+	return conflicts
+}
+
+// RemoveCoHost only updates the events' hosting groups, so it doesn't overwrite fields written
+// by the owner's import in the meantime.
+func (er *DynamoDBEventRepository) RemoveCoHost(
+	ctx context.Context,
+	group string,
+	events []models.MeetupEvent,
 ) error {
 	if len(events) == 0 {
 		return nil
 	}
 
-	for chunk := range slices.Chunk(events, db.MaxBatchSize) {
-		writeRequests := make([]types.WriteRequest, 0, len(chunk))
+	writeRequests := make([]types.WriteRequest, len(events))
+	for i, event := range events {
+		err := er.retryCoHostConflicts(
+			ctx,
+			event.ID,
+			&event,
+			func(stored *models.MeetupEvent) error {
+				if stored == nil || !slices.Contains(stored.CoHosts(), group) {
+					return nil
+				}
+
+				updated := *stored
+				updated.RemoveCoHost(group)
+
+				return er.updateCoHosts(ctx, *stored, updated.GroupIDs)
+			},
+		)
+		if err != nil {
+			return err
+		}
 
-		for _, event := range chunk {
-			av, err := attributevalue.MarshalMap(event)
-			if err != nil {
-				return err
-			}
+		writeRequests[i] = types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{
+				Key: er.createKey(models.EventListingID(event.ID, group)),
+			},
+		}
+	}
 
-			writeRequests = append(writeRequests, types.WriteRequest{
-				PutRequest: &types.PutRequest{Item: av},
-			})
+	return db.BatchWriteItems(
+		ctx,
+		er.db,
+		db.DefaultRetryPolicy,
+		er.config.EventsTableName,
+		writeRequests,
+	)
+}
+
+// retryCoHostConflicts calls write with the event as last read until it isn't rejected for the
+// event's co-hosts having changed, reading the event again after each rejection. stored is the
+// event as already read, or nil if it didn't exist.
+func (er *DynamoDBEventRepository) retryCoHostConflicts(
+	ctx context.Context,
+	id string,
+	stored *models.MeetupEvent,
+	write func(stored *models.MeetupEvent) error,
+) error {
+	for attempt := 1; ; attempt++ {
+		err := write(stored)
+
+		var conditionFailed *types.ConditionalCheckFailedException
+		if !errors.As(err, &conditionFailed) {
+			return err
 		}
 
-		_, err := er.db.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]types.WriteRequest{
-				table: writeRequests,
-			},
-		})
+		if attempt >= maxEventWriteAttempts {
+			return fmt.Errorf("write event %s: co-hosts kept changing: %w", id, err)
+		}
+
+		if stored, err = er.getEvent(ctx, id); err != nil {
+			return err
+		}
+	}
+}
+
+// eventPut writes the event if the co-hosts stored for it are still stored's.
+func (er *DynamoDBEventRepository) eventPut(
+	event models.MeetupEvent,
+	stored *models.MeetupEvent,
+) (types.TransactWriteItem, error) {
+	av, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	expr, err := expression.NewBuilder().WithCondition(coHostsUnchanged(stored)).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	return types.TransactWriteItem{Put: &types.Put{
+		TableName:                 aws.String(er.config.EventsTableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}}, nil
+}
+
+// updateCoHosts sets the stored event's hosting groups if they haven't changed since it was read.
+func (er *DynamoDBEventRepository) updateCoHosts(
+	ctx context.Context,
+	stored models.MeetupEvent,
+	groupIDs []string,
+) error {
+	groupIDsName := expression.Name("groupIds")
+
+	update := expression.Remove(groupIDsName)
+	if len(groupIDs) > 0 {
+		update = expression.Set(groupIDsName, expression.Value(groupIDs))
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.And(
+			expression.AttributeExists(expression.Name("id")),
+			coHostsUnchanged(&stored),
+		)).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = er.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(er.config.EventsTableName),
+		Key:                       er.createKey(stored.ID),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	return err
+}
+
+// coHostsUnchanged holds while the event's stored hosting groups are still those of stored,
+// which is nil for an event that hadn't been stored.
+func coHostsUnchanged(stored *models.MeetupEvent) expression.ConditionBuilder {
+	groupIDs := expression.Name("groupIds")
+	if stored == nil || len(stored.GroupIDs) == 0 {
+		return expression.AttributeNotExists(groupIDs)
+	}
+
+	return groupIDs.Equal(expression.Value(stored.GroupIDs))
+}
+
+func (er *DynamoDBEventRepository) UpsertArchivedEvents(
+	ctx context.Context,
+	events []models.MeetupEvent,
+) error {
+	return er.upsertEventsToTable(ctx, events, er.config.ArchivedEventsTableName)
+}
+
+func (er *DynamoDBEventRepository) upsertEventsToTable(
+	ctx context.Context,
+	events []models.MeetupEvent,
+	table string,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	writeRequests := make([]types.WriteRequest, 0, len(events))
+
+	for _, event := range events {
+		av, err := attributevalue.MarshalMap(event)
 		if err != nil {
 			return err
 		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: av},
+		})
 	}
 
-	return nil
+	return db.BatchWriteItems(ctx, er.db, db.DefaultRetryPolicy, table, writeRequests)
 }
 
 func (er *DynamoDBEventRepository) getItems(
@@ -181,46 +519,113 @@ func (er *DynamoDBEventRepository) getItems(
 		keys[i] = er.createKey(id)
 	}
 
-	res, err := er.db.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
-		RequestItems: map[string]types.KeysAndAttributes{
-			er.config.EventsTableName: {Keys: keys},
-		},
+	return db.BatchGetItems(ctx, er.db, db.DefaultRetryPolicy, er.config.EventsTableName, keys)
+}
+
+// getEvents returns the stored events by ID, leaving out any that aren't stored.
+func (er *DynamoDBEventRepository) getEvents(
+	ctx context.Context,
+	ids []string,
+) (map[string]*models.MeetupEvent, error) {
+	items, err := er.getItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.MeetupEvent
+	if err := attributevalue.UnmarshalListOfMaps(items, &events); err != nil {
+		return nil, err
+	}
+
+	eventsByID := make(map[string]*models.MeetupEvent, len(events))
+	for i := range events {
+		eventsByID[events[i].ID] = &events[i]
+	}
+
+	return eventsByID, nil
+}
+
+// getEvent returns the stored event, or nil if it isn't stored. The read is consistent so it
+// sees the write that caused a conflict.
+func (er *DynamoDBEventRepository) getEvent(
+	ctx context.Context,
+	id string,
+) (*models.MeetupEvent, error) {
+	result, err := er.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(er.config.EventsTableName),
+		Key:            er.createKey(id),
+		ConsistentRead: aws.Bool(true),
 	})
 	if err != nil {
 		return nil, err
 	}
-	return res.Responses[er.config.EventsTableName], nil
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var event models.MeetupEvent
+	if err := attributevalue.UnmarshalMap(result.Item, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
 }
 
-func (er *DynamoDBEventRepository) writeToArchive(
+// moveToArchive copies items to the archive table and deletes them from the events table in a
+// single transaction, so an event is never lost or left in both tables.
+func (er *DynamoDBEventRepository) moveToArchive(
 	ctx context.Context,
 	items []map[string]types.AttributeValue,
 ) error {
-	writes := make([]types.WriteRequest, len(items))
-	for i, item := range items {
-		writes[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: item}}
+	transactItems := make([]types.TransactWriteItem, 0, len(items)*2)
+	for _, item := range items {
+		transactItems = append(transactItems,
+			types.TransactWriteItem{Put: &types.Put{
+				TableName: aws.String(er.config.ArchivedEventsTableName),
+				Item:      item,
+			}},
+			types.TransactWriteItem{Delete: &types.Delete{
+				TableName: aws.String(er.config.EventsTableName),
+				Key:       map[string]types.AttributeValue{"id": item["id"]},
+			}},
+		)
 	}
 
-	_, err := er.db.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]types.WriteRequest{er.config.ArchivedEventsTableName: writes},
-	})
-
-	return err
+	return db.TransactWriteItems(ctx, er.db, db.DefaultRetryPolicy, transactItems)
 }
 
-func (er *DynamoDBEventRepository) deleteIds(ctx context.Context, ids []string) error {
-	deletes := make([]types.WriteRequest, len(ids))
-	for i, id := range ids {
-		deletes[i] = types.WriteRequest{
-			DeleteRequest: &types.DeleteRequest{Key: er.createKey(id)},
+// deleteListings removes the co-host listings of archived events. It runs after the archive
+// transaction since listings of a missing event are already skipped when read.
+func (er *DynamoDBEventRepository) deleteListings(
+	ctx context.Context,
+	items []map[string]types.AttributeValue,
+) error {
+	var events []models.MeetupEvent
+	if err := attributevalue.UnmarshalListOfMaps(items, &events); err != nil {
+		return err
+	}
+
+	var writeRequests []types.WriteRequest
+	for _, event := range events {
+		for _, listing := range event.Listings() {
+			writeRequests = append(writeRequests, types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{Key: er.createKey(listing.ID)},
+			})
 		}
 	}
 
-	_, err := er.db.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]types.WriteRequest{er.config.EventsTableName: deletes},
-	})
+	if len(writeRequests) == 0 {
+		return nil
+	}
 
-	return err
+	return db.BatchWriteItems(
+		ctx,
+		er.db,
+		db.DefaultRetryPolicy,
+		er.config.EventsTableName,
+		writeRequests,
+	)
 }
 
 func (er *DynamoDBEventRepository) createKey(id string) map[string]types.AttributeValue {
@@ -2,6 +2,9 @@ package importer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,6 +123,28 @@ func TestDynamoDBEventRepository_GetUpcomingEventsForGroup(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, result, 1)
 	})
+
+	t.Run("returns events co-hosted by the group", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		coHosted := meetupFaker.CreateEvent("other-group", mockNow.Add(1*time.Hour))
+		coHosted.AddCoHost("test-group")
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{
+			coHosted,
+			meetupFaker.CreateEvent("test-group", mockNow.Add(2*time.Hour)),
+		}))
+
+		result, err := repo.GetUpcomingEventsForGroup(ctx, "test-group")
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, coHosted.ID, result[0].ID)
+		assert.Equal(t, "other-group", result[0].GroupID)
+		assert.Equal(t, []string{"other-group", "test-group"}, result[0].GroupIDs)
+
+		result, err = repo.GetUpcomingEventsForGroup(ctx, "other-group")
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
 }
 
 func TestDynamoDBEventRepository_ArchiveEvents(t *testing.T) {
@@ -171,6 +197,22 @@ func TestDynamoDBEventRepository_ArchiveEvents(t *testing.T) {
 		}
 	})
 
+	t.Run("deletes the listings of co-hosted events", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("test-group", mockNow.Add(1*time.Hour))
+		event.AddCoHost("other-group")
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{event}))
+
+		listingID := models.EventListingID(event.ID, "other-group")
+		require.True(t, testDB.CheckItemExists(ctx, repoConfig.EventsTableName, "id", listingID))
+
+		require.NoError(t, repo.ArchiveEvents(ctx, []string{event.ID}))
+
+		assert.False(t, testDB.CheckItemExists(ctx, repoConfig.EventsTableName, "id", listingID))
+		assert.Zero(t, testDB.GetItemCount(ctx, repoConfig.EventsTableName))
+	})
+
 	t.Run("handles empty input list", func(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 
@@ -274,6 +316,110 @@ func TestDynamoDBEventRepository_UpsertEvents(t *testing.T) {
 		assert.Equal(t, "UPDATED TITLE", result.Title)
 	})
 
+	t.Run("keeps co-hosts stored by an overlapping import", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("group1", time.Now().Add(1*time.Hour))
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{event}))
+
+		// group2's import stores itself as a co-host after group1's import read the event
+		// without it, and group1's import writes last.
+		coHosted := event
+		coHosted.AddCoHost("group2")
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{coHosted}))
+
+		updatedEvent := event
+		updatedEvent.Title = "UPDATED TITLE"
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{updatedEvent}))
+
+		stored, err := repo.getEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATED TITLE", stored.Title)
+		assert.Equal(t, []string{"group1", "group2"}, stored.GroupIDs)
+		assert.True(t, testDB.CheckItemExists(
+			ctx,
+			repoConfig.EventsTableName,
+			"id",
+			models.EventListingID(event.ID, "group2"),
+		))
+	})
+
+	t.Run("keeps every co-host when imports write at the same time", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("group1", time.Now().Add(1*time.Hour))
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{event}))
+
+		var wg sync.WaitGroup
+		for _, group := range []string{"group1", "group2", "group3", "group4"} {
+			wg.Go(func() {
+				imported := event
+				imported.AddCoHost(group)
+				assert.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{imported}))
+			})
+		}
+		wg.Wait()
+
+		stored, err := repo.getEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"group1", "group2", "group3", "group4"}, stored.GroupIDs)
+	})
+
+	t.Run("rejects writes made with co-hosts that have since changed", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("group1", time.Now().Add(1*time.Hour))
+		coHosted := event
+		coHosted.AddCoHost("group2")
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{coHosted}))
+
+		staleEvent := event
+		staleEvent.AddCoHost("group3")
+
+		put := func(event models.MeetupEvent, stored *models.MeetupEvent) error {
+			item, err := repo.eventPut(event, stored)
+			require.NoError(t, err)
+			return db.TransactWriteItems(
+				ctx,
+				testDB.Client,
+				db.DefaultRetryPolicy,
+				[]types.TransactWriteItem{item},
+			)
+		}
+
+		assert.Equal(t, []int{0}, coHostConflicts(put(event, nil)))
+		assert.Equal(t, []int{0}, coHostConflicts(put(event, &staleEvent)))
+		require.NoError(t, put(coHosted, &coHosted))
+	})
+
+	t.Run("writes more events than fit in one transaction", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		var testEvents []models.MeetupEvent
+		for range db.MaxTransactSize + 5 {
+			testEvents = append(testEvents, meetupFaker.CreateEvent("group1", time.Now()))
+		}
+
+		require.NoError(t, repo.UpsertEvents(ctx, testEvents))
+
+		eventCount := testDB.GetItemCount(ctx, repoConfig.EventsTableName)
+		assert.Equal(t, db.MaxTransactSize+5, eventCount)
+	})
+
+	t.Run("writes the last copy of a repeated event", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("group1", time.Now().Add(1*time.Hour))
+		updatedEvent := event
+		updatedEvent.Title = "UPDATED TITLE"
+
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{event, updatedEvent}))
+
+		stored, err := repo.getEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATED TITLE", stored.Title)
+	})
+
 	t.Run("handles empty input list", func(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 
@@ -295,3 +441,80 @@ func TestDynamoDBEventRepository_UpsertEvents(t *testing.T) {
 		assert.Equal(t, db.MaxBatchSize+5, eventCount)
 	})
 }
+
+func TestDynamoDBEventRepository_RemoveCoHost(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	mockNow := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	repoConfig := DynamoDBEventRepositoryConfig{
+		EventsTableName:    *infra.EventsTableProps.TableName,
+		GroupDateIndexName: *infra.GroupIdDateTimeIndex.IndexName,
+	}
+	repo := NewDynamoDBEventRepository(
+		repoConfig,
+		testDB.Client,
+		clock.NewMockTimeSource(mockNow),
+		logging.NewMockLogger(),
+	)
+	meetupFaker := fakers.NewMeetupFaker(0)
+
+	event := meetupFaker.CreateEvent("test-group", mockNow.Add(1*time.Hour))
+	event.AddCoHost("other-group")
+	require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{event}))
+
+	require.NoError(t, repo.RemoveCoHost(ctx, "other-group", []models.MeetupEvent{event}))
+
+	result, err := repo.GetUpcomingEventsForGroup(ctx, "other-group")
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	result, err = repo.GetUpcomingEventsForGroup(ctx, "test-group")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Nil(t, result[0].GroupIDs)
+	assert.Equal(t, 1, testDB.GetItemCount(ctx, repoConfig.EventsTableName))
+
+	t.Run("keeps co-hosts added since the event was read", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		event := meetupFaker.CreateEvent("test-group", mockNow.Add(1*time.Hour))
+		event.AddCoHost("other-group")
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{event}))
+
+		coHosted := event
+		coHosted.AddCoHost("third-group")
+		require.NoError(t, repo.UpsertEvents(ctx, []models.MeetupEvent{coHosted}))
+
+		require.NoError(t, repo.RemoveCoHost(ctx, "other-group", []models.MeetupEvent{event}))
+
+		stored, err := repo.getEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"test-group", "third-group"}, stored.GroupIDs)
+	})
+}
+
+func TestCoHostConflicts(t *testing.T) {
+	t.Run("returns the events whose condition failed", func(t *testing.T) {
+		err := &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		}
+
+		assert.Equal(t, []int{1, 3}, coHostConflicts(fmt.Errorf("write: %w", err)))
+	})
+
+	t.Run("ignores other errors", func(t *testing.T) {
+		assert.Nil(t, coHostConflicts(nil))
+		assert.Nil(t, coHostConflicts(errors.New("db error")))
+		assert.Nil(t, coHostConflicts(&types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{{Code: aws.String("ValidationError")}},
+		}))
+	})
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/meetupproxy"
+	"sgf-meetup-api/pkg/shared/clock"
+
+	"github.com/google/wire"
+)
+
+// NewGraphQLHandler returns the handler for the configured proxy mode, recording its responses
+// when a recordings directory is set. The in-process proxy is only initialized when it's
+// selected since it needs Meetup credentials.
+func NewGraphQLHandler(
+	ctx context.Context,
+	config *importerconfig.Config,
+	httpClient *http.Client,
+	timeSource clock.TimeSource,
+	logger *slog.Logger,
+) (GraphQLHandler, error) {
+	var handler GraphQLHandler
+
+	switch config.ProxyMode {
+	case importerconfig.ProxyModeReplay:
+		return NewReplayGraphQLHandler(config.RecordingsDir)
+	case importerconfig.ProxyModeHTTP:
+		handler = NewHTTPProxyGraphQLHandler(NewHTTPProxyGraphQLHandlerConfig(config), httpClient)
+	case importerconfig.ProxyModeInProcess:
+		proxy, err := meetupproxy.InitService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = NewInProcessProxyGraphQLHandler(proxy)
+	default:
+		handler = NewLambdaProxyGraphQLHandler(NewLambdaProxyGraphQLHandlerConfig(config), logger)
+	}
+
+	if config.RecordingsDir != "" {
+		logger.Info("recording meetup responses", "dir", config.RecordingsDir)
+		return NewRecordingGraphQLHandler(handler, config.RecordingsDir, timeSource), nil
+	}
+
+	return handler, nil
+}
+
+var GraphQLHandlerProviders = wire.NewSet(NewGraphQLHandler)
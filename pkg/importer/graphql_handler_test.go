@@ -0,0 +1,116 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGraphQLHandler(t *testing.T) {
+	t.Run("lambda", func(t *testing.T) {
+		handler, err := NewGraphQLHandler(
+			context.Background(),
+			&importerconfig.Config{
+				ProxyMode:         importerconfig.ProxyModeLambda,
+				ProxyFunctionName: "meetupproxy",
+			},
+			nil,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+		require.NoError(t, err)
+
+		assert.IsType(t, &LambdaProxyGraphQLHandler{}, handler)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		handler, err := NewGraphQLHandler(
+			context.Background(),
+			&importerconfig.Config{
+				ProxyMode: importerconfig.ProxyModeHTTP,
+				ProxyURL:  "http://localhost:8080/graphql",
+			},
+			nil,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+		require.NoError(t, err)
+
+		assert.IsType(t, &HTTPProxyGraphQLHandler{}, handler)
+	})
+
+	t.Run("in process", func(t *testing.T) {
+		t.Setenv("MEETUP_PRIVATE_KEY_BASE64", "c29tZUJhc2U2NEtleQ==")
+		t.Setenv("MEETUP_USER_ID", "meetupUserId")
+		t.Setenv("MEETUP_CLIENT_KEY", "meetupClientKey")
+		t.Setenv("MEETUP_SIGNING_KEY_ID", "signingKeyId")
+		t.Setenv("RESPONSE_CACHE_TABLE_NAME", "response-cache")
+		t.Setenv("TOKEN_STORE_TABLE_NAME", "token-store")
+
+		handler, err := NewGraphQLHandler(
+			context.Background(),
+			&importerconfig.Config{ProxyMode: importerconfig.ProxyModeInProcess},
+			nil,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+		require.NoError(t, err)
+
+		assert.IsType(t, &InProcessProxyGraphQLHandler{}, handler)
+	})
+
+	t.Run("records when a recordings directory is set", func(t *testing.T) {
+		handler, err := NewGraphQLHandler(
+			context.Background(),
+			&importerconfig.Config{
+				ProxyMode:     importerconfig.ProxyModeHTTP,
+				ProxyURL:      "http://localhost:8080/graphql",
+				RecordingsDir: t.TempDir(),
+			},
+			nil,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+		require.NoError(t, err)
+
+		require.IsType(t, &RecordingGraphQLHandler{}, handler)
+		assert.IsType(t, &HTTPProxyGraphQLHandler{}, handler.(*RecordingGraphQLHandler).handler)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		handler, err := NewGraphQLHandler(
+			context.Background(),
+			&importerconfig.Config{
+				ProxyMode:     importerconfig.ProxyModeReplay,
+				RecordingsDir: "testdata/recordings",
+			},
+			nil,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+		require.NoError(t, err)
+
+		assert.IsType(t, &ReplayGraphQLHandler{}, handler)
+	})
+
+	t.Run("in process needs the proxy's config", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		_, err := NewGraphQLHandler(
+			context.Background(),
+			&importerconfig.Config{ProxyMode: importerconfig.ProxyModeInProcess},
+			nil,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+
+		assert.ErrorContains(t, err, "MEETUP_USER_ID")
+	})
+}
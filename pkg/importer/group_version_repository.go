@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"context"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type GroupVersionRepository interface {
+	// SaveGroupVersions sets the versions of the changed groups. The unchanged groups keep
+	// their version, and are only given one when they don't have one yet.
+	SaveGroupVersions(ctx context.Context, changed, unchanged []models.GroupVersion) error
+}
+
+type DynamoDBGroupVersionRepositoryConfig struct {
+	GroupVersionsTableName string
+}
+
+func NewDynamoDBGroupVersionRepositoryConfig(
+	config *importerconfig.Config,
+) DynamoDBGroupVersionRepositoryConfig {
+	return DynamoDBGroupVersionRepositoryConfig{
+		GroupVersionsTableName: config.GroupVersionsTableName,
+	}
+}
+
+type DynamoDBGroupVersionRepository struct {
+	config DynamoDBGroupVersionRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBGroupVersionRepository(
+	config DynamoDBGroupVersionRepositoryConfig,
+	db *db.Client,
+) *DynamoDBGroupVersionRepository {
+	return &DynamoDBGroupVersionRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (r *DynamoDBGroupVersionRepository) SaveGroupVersions(
+	ctx context.Context,
+	changed, unchanged []models.GroupVersion,
+) error {
+	for _, version := range changed {
+		if err := r.saveGroupVersion(ctx, version, true); err != nil {
+			return err
+		}
+	}
+
+	for _, version := range unchanged {
+		if err := r.saveGroupVersion(ctx, version, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveGroupVersion updates the group's version in place. Without overwrite the version and
+// update time are only set if_not_exists, which leaves an existing version alone.
+func (r *DynamoDBGroupVersionRepository) saveGroupVersion(
+	ctx context.Context,
+	version models.GroupVersion,
+	overwrite bool,
+) error {
+	versionName := expression.Name("version")
+	updatedAtName := expression.Name("updatedAt")
+	versionValue := expression.Value(version.Version)
+	updatedAtValue := expression.Value(version.UpdatedAt)
+
+	var update expression.UpdateBuilder
+	if overwrite {
+		update = expression.Set(versionName, versionValue).
+			Set(updatedAtName, updatedAtValue)
+	} else {
+		update = expression.Set(versionName, versionName.IfNotExists(versionValue)).
+			Set(updatedAtName, updatedAtName.IfNotExists(updatedAtValue))
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.config.GroupVersionsTableName),
+		Key: map[string]types.AttributeValue{
+			"groupId": &types.AttributeValueMemberS{Value: version.GroupID},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+	})
+
+	return err
+}
+
+var GroupVersionRepositoryProviders = wire.NewSet(
+	wire.Bind(new(GroupVersionRepository), new(*DynamoDBGroupVersionRepository)),
+	NewDynamoDBGroupVersionRepositoryConfig,
+	NewDynamoDBGroupVersionRepository,
+)
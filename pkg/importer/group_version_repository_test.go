@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDynamoDBGroupVersionRepositoryConfig(t *testing.T) {
+	cfg := &importerconfig.Config{GroupVersionsTableName: "groupVersions"}
+
+	repoConfig := NewDynamoDBGroupVersionRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.GroupVersionsTableName, repoConfig.GroupVersionsTableName)
+}
+
+func TestDynamoDBGroupVersionRepository_SaveGroupVersions(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	repoConfig := DynamoDBGroupVersionRepositoryConfig{
+		GroupVersionsTableName: *infra.GroupVersionsTableProps.TableName,
+	}
+	repo := NewDynamoDBGroupVersionRepository(repoConfig, testDB.Client)
+
+	getVersion := func(t *testing.T, groupID string) models.GroupVersion {
+		t.Helper()
+
+		result, err := testDB.Client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(repoConfig.GroupVersionsTableName),
+			Key: map[string]types.AttributeValue{
+				"groupId": &types.AttributeValueMemberS{Value: groupID},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.Item)
+
+		var version models.GroupVersion
+		require.NoError(t, attributevalue.UnmarshalMap(result.Item, &version))
+		return version
+	}
+
+	firstImport := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	secondImport := firstImport.Add(2 * time.Hour)
+
+	t.Run("only changed groups get a new version", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		require.NoError(t, repo.SaveGroupVersions(ctx, []models.GroupVersion{
+			{GroupID: "group1", Version: "run1", UpdatedAt: firstImport},
+			{GroupID: "group2", Version: "run1", UpdatedAt: firstImport},
+		}, nil))
+
+		require.NoError(t, repo.SaveGroupVersions(ctx,
+			[]models.GroupVersion{{GroupID: "group1", Version: "run2", UpdatedAt: secondImport}},
+			[]models.GroupVersion{
+				{GroupID: "group2", Version: "run2", UpdatedAt: secondImport},
+				{GroupID: "group3", Version: "run2", UpdatedAt: secondImport},
+			},
+		))
+
+		assert.Equal(t, models.GroupVersion{
+			GroupID:   "group1",
+			Version:   "run2",
+			UpdatedAt: secondImport,
+		}, getVersion(t, "group1"))
+		assert.Equal(t, models.GroupVersion{
+			GroupID:   "group2",
+			Version:   "run1",
+			UpdatedAt: firstImport,
+		}, getVersion(t, "group2"))
+		assert.Equal(t, models.GroupVersion{
+			GroupID:   "group3",
+			Version:   "run2",
+			UpdatedAt: secondImport,
+		}, getVersion(t, "group3"), "unchanged groups without a version get one")
+	})
+
+	t.Run("handles empty input lists", func(t *testing.T) {
+		require.NoError(t, repo.SaveGroupVersions(ctx, nil, nil))
+	})
+}
@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/meetupproxy"
+)
+
+type HTTPProxyGraphQLHandlerConfig struct {
+	URL string
+	// Token is sent as a bearer token when it's set.
+	Token string
+}
+
+func NewHTTPProxyGraphQLHandlerConfig(
+	config *importerconfig.Config,
+) HTTPProxyGraphQLHandlerConfig {
+	return HTTPProxyGraphQLHandlerConfig{
+		URL:   config.ProxyURL,
+		Token: config.ProxyToken,
+	}
+}
+
+// HTTPProxyGraphQLHandler sends queries to a Meetup proxy running as an HTTP server.
+type HTTPProxyGraphQLHandler struct {
+	config     HTTPProxyGraphQLHandlerConfig
+	httpClient *http.Client
+}
+
+func NewHTTPProxyGraphQLHandler(
+	config HTTPProxyGraphQLHandlerConfig,
+	httpClient *http.Client,
+) *HTTPProxyGraphQLHandler {
+	return &HTTPProxyGraphQLHandler{
+		config:     config,
+		httpClient: httpClient,
+	}
+}
+
+func (h *HTTPProxyGraphQLHandler) ExecuteQuery(
+	ctx context.Context,
+	queryID string,
+	variables map[string]any,
+) ([]byte, error) {
+	body, err := json.Marshal(meetupproxy.Request{
+		QueryID:   queryID,
+		Variables: variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		h.config.URL,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if h.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.config.Token)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("%w: %s", ErrMeetupThrottled, respBody)
+	}
+
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("%w: %s", ErrMeetupUnavailable, respBody)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("meetup proxy returned status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return respBody, nil
+}
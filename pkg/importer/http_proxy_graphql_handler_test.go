@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/meetupproxy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPProxyGraphQLHandlerConfig(t *testing.T) {
+	cfg := &importerconfig.Config{
+		ProxyURL:   "http://localhost:8080/graphql",
+		ProxyToken: "secret",
+	}
+
+	handlerConfig := NewHTTPProxyGraphQLHandlerConfig(cfg)
+
+	assert.Equal(t, cfg.ProxyURL, handlerConfig.URL)
+	assert.Equal(t, cfg.ProxyToken, handlerConfig.Token)
+}
+
+func TestHTTPProxyGraphQLHandler_ExecuteQuery(t *testing.T) {
+	newHandler := func(t *testing.T, token string, h http.HandlerFunc) *HTTPProxyGraphQLHandler {
+		t.Helper()
+
+		ts := httptest.NewServer(h)
+		t.Cleanup(ts.Close)
+
+		return NewHTTPProxyGraphQLHandler(
+			HTTPProxyGraphQLHandlerConfig{URL: ts.URL, Token: token},
+			&http.Client{},
+		)
+	}
+
+	t.Run("sends the query id and variables", func(t *testing.T) {
+		var req meetupproxy.Request
+		var authorization string
+		handler := newHandler(t, "secret", func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			_, _ = w.Write([]byte(`{"data": "test"}`))
+		})
+
+		result, err := handler.ExecuteQuery(
+			context.Background(),
+			meetupproxy.GroupFutureEventsQueryID,
+			map[string]any{"urlname": "sgfdevs"},
+		)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"data": "test"}`, string(result))
+		assert.Equal(t, "Bearer secret", authorization)
+		assert.Equal(t, meetupproxy.GroupFutureEventsQueryID, req.QueryID)
+		assert.Equal(t, map[string]any{"urlname": "sgfdevs"}, req.Variables)
+	})
+
+	t.Run("omits the token when it isn't set", func(t *testing.T) {
+		authorization := "unset"
+		handler := newHandler(t, "", func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, authorization)
+	})
+
+	t.Run("throttled responses match ErrMeetupThrottled", func(t *testing.T) {
+		handler := newHandler(t, "", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+
+		assert.ErrorIs(t, err, ErrMeetupThrottled)
+	})
+
+	t.Run("unavailable responses match ErrMeetupUnavailable", func(t *testing.T) {
+		handler := newHandler(t, "", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+
+		assert.ErrorIs(t, err, ErrMeetupUnavailable)
+	})
+
+	t.Run("other statuses fail", func(t *testing.T) {
+		handler := newHandler(t, "", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("upstream failed"))
+		})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+
+		assert.ErrorContains(t, err, "status 502: upstream failed")
+		assert.NotErrorIs(t, err, ErrMeetupThrottled)
+	})
+}
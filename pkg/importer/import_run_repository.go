@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"context"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type ImportRunRepository interface {
+	SaveImportRuns(ctx context.Context, runs []models.ImportRun) error
+}
+
+type DynamoDBImportRunRepositoryConfig struct {
+	ImportRunsTableName string
+}
+
+func NewDynamoDBImportRunRepositoryConfig(
+	config *importerconfig.Config,
+) DynamoDBImportRunRepositoryConfig {
+	return DynamoDBImportRunRepositoryConfig{
+		ImportRunsTableName: config.ImportRunsTableName,
+	}
+}
+
+type DynamoDBImportRunRepository struct {
+	config DynamoDBImportRunRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBImportRunRepository(
+	config DynamoDBImportRunRepositoryConfig,
+	db *db.Client,
+) *DynamoDBImportRunRepository {
+	return &DynamoDBImportRunRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (r *DynamoDBImportRunRepository) SaveImportRuns(
+	ctx context.Context,
+	runs []models.ImportRun,
+) error {
+	writeRequests := make([]types.WriteRequest, 0, len(runs))
+
+	for _, run := range runs {
+		av, err := attributevalue.MarshalMap(run)
+		if err != nil {
+			return err
+		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: av},
+		})
+	}
+
+	return db.BatchWriteItems(
+		ctx,
+		r.db,
+		db.DefaultRetryPolicy,
+		r.config.ImportRunsTableName,
+		writeRequests,
+	)
+}
+
+var ImportRunRepositoryProviders = wire.NewSet(
+	wire.Bind(new(ImportRunRepository), new(*DynamoDBImportRunRepository)),
+	NewDynamoDBImportRunRepositoryConfig,
+	NewDynamoDBImportRunRepository,
+)
@@ -0,0 +1,66 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDynamoDBImportRunRepositoryConfig(t *testing.T) {
+	cfg := &importerconfig.Config{ImportRunsTableName: "importRuns"}
+
+	repoConfig := NewDynamoDBImportRunRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.ImportRunsTableName, repoConfig.ImportRunsTableName)
+}
+
+func TestDynamoDBImportRunRepository_SaveImportRuns(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	repoConfig := DynamoDBImportRunRepositoryConfig{
+		ImportRunsTableName: *infra.ImportRunsTableProps.TableName,
+	}
+	repo := NewDynamoDBImportRunRepository(repoConfig, testDB.Client)
+
+	t.Run("saves runs across chunks", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		startedAt := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+		runID := newImportRunID(startedAt)
+		runs := make([]models.ImportRun, 0, db.MaxBatchSize+2)
+		for i := range db.MaxBatchSize + 1 {
+			runs = append(runs, models.ImportRun{
+				Scope:     fmt.Sprintf("group-%d", i),
+				RunID:     runID,
+				StartedAt: startedAt,
+				EndedAt:   startedAt.Add(time.Minute),
+			})
+		}
+		runs = append(runs, models.ImportRun{
+			Scope:     models.ImportRunScopeAll,
+			RunID:     runID,
+			StartedAt: startedAt,
+			EndedAt:   startedAt.Add(time.Minute),
+		})
+
+		require.NoError(t, repo.SaveImportRuns(ctx, runs))
+
+		assert.Equal(t, len(runs), testDB.GetItemCount(ctx, repoConfig.ImportRunsTableName))
+	})
+
+	t.Run("handles empty input list", func(t *testing.T) {
+		require.NoError(t, repo.SaveImportRuns(ctx, []models.ImportRun{}))
+	})
+}
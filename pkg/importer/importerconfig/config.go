@@ -13,27 +13,72 @@ import (
 
 const (
 	meetupGroupNamesKey         = "MEETUP_GROUP_NAMES"
+	meetupGroupsKey             = "MEETUP_GROUPS"
+	meetupGroupsFileKey         = "MEETUP_GROUPS_FILE"
+	importConcurrencyKey        = "IMPORT_CONCURRENCY"
+	proxyModeKey                = "MEETUP_PROXY_MODE"
 	proxyFunctionNameKey        = "MEETUP_PROXY_FUNCTION_NAME"
+	proxyURLKey                 = "MEETUP_PROXY_URL"
+	proxyTokenKey               = "MEETUP_PROXY_TOKEN"
+	recordingsDirKey            = "MEETUP_RECORDINGS_DIR"
 	archivedEventsTableNameKey  = "ARCHIVED_EVENTS_TABLE_NAME"
 	eventsTableNameKey          = "EVENTS_TABLE_NAME"
 	groupIDDateTimeIndexNameKey = "GROUP_ID_DATE_TIME_INDEX_NAME"
+	archiveMaxCountKey          = "ARCHIVE_MAX_COUNT"
+	archiveMaxPercentKey        = "ARCHIVE_MAX_PERCENT"
+	archiveForceGroupsKey       = "ARCHIVE_FORCE_GROUPS"
+	importRunsTableNameKey      = "IMPORT_RUNS_TABLE_NAME"
+	backfillCheckpointsTableKey = "BACKFILL_CHECKPOINTS_TABLE_NAME"
+	eventSeriesTableNameKey     = "EVENT_SERIES_TABLE_NAME"
+	groupVersionsTableNameKey   = "GROUP_VERSIONS_TABLE_NAME"
 )
 
 var configKeys = []string{
 	meetupGroupNamesKey,
+	meetupGroupsKey,
+	meetupGroupsFileKey,
+	importConcurrencyKey,
+	proxyModeKey,
 	proxyFunctionNameKey,
+	proxyURLKey,
+	proxyTokenKey,
+	recordingsDirKey,
 	archivedEventsTableNameKey,
 	eventsTableNameKey,
 	groupIDDateTimeIndexNameKey,
+	archiveMaxCountKey,
+	archiveMaxPercentKey,
+	archiveForceGroupsKey,
+	importRunsTableNameKey,
+	backfillCheckpointsTableKey,
+	eventSeriesTableNameKey,
+	groupVersionsTableNameKey,
 }
 
 type Config struct {
-	appconfig.Common         `         mapstructure:",squash"`
-	MeetupGroupNames         []string `mapstructure:"meetup_group_names"`
-	ProxyFunctionName        string   `mapstructure:"meetup_proxy_function_name"`
-	ArchivedEventsTableName  string   `mapstructure:"archived_events_table_name"`
-	EventsTableName          string   `mapstructure:"events_table_name"`
-	GroupIDDateTimeIndexName string   `mapstructure:"group_id_date_time_index_name"`
+	appconfig.Common             `              mapstructure:",squash"`
+	MeetupGroupNames             []string `mapstructure:"meetup_group_names"`
+	MeetupGroupsJSON             string   `mapstructure:"meetup_groups"`
+	MeetupGroupsFile             string   `mapstructure:"meetup_groups_file"`
+	ImportConcurrency            int      `mapstructure:"import_concurrency"`
+	ProxyMode                    string   `mapstructure:"meetup_proxy_mode"`
+	ProxyFunctionName            string   `mapstructure:"meetup_proxy_function_name"`
+	ProxyURL                     string   `mapstructure:"meetup_proxy_url"`
+	ProxyToken                   string   `mapstructure:"meetup_proxy_token"`
+	RecordingsDir                string   `mapstructure:"meetup_recordings_dir"`
+	ArchivedEventsTableName      string   `mapstructure:"archived_events_table_name"`
+	EventsTableName              string   `mapstructure:"events_table_name"`
+	GroupIDDateTimeIndexName     string   `mapstructure:"group_id_date_time_index_name"`
+	ArchiveMaxCount              int      `mapstructure:"archive_max_count"`
+	ArchiveMaxPercent            int      `mapstructure:"archive_max_percent"`
+	ArchiveForceGroups           []string `mapstructure:"archive_force_groups"`
+	ImportRunsTableName          string   `mapstructure:"import_runs_table_name"`
+	BackfillCheckpointsTableName string   `mapstructure:"backfill_checkpoints_table_name"`
+	EventSeriesTableName         string   `mapstructure:"event_series_table_name"`
+	GroupVersionsTableName       string   `mapstructure:"group_versions_table_name"`
+	// Groups is every group to import, built from MeetupGroupsJSON, MeetupGroupsFile and
+	// MeetupGroupNames.
+	Groups []GroupConfig `mapstructure:"-"`
 }
 
 func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager) (*Config, error) {
@@ -55,6 +100,10 @@ func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager)
 		return nil, err
 	}
 
+	if config.Groups, err = config.parseGroups(); err != nil {
+		return nil, err
+	}
+
 	if err = config.validate(); err != nil {
 		return nil, err
 	}
@@ -62,16 +111,52 @@ func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager)
 	return &config, nil
 }
 
+// Modes for reaching the Meetup proxy. The in-process mode runs the proxy inside the importer,
+// reading the proxy's own config. The replay mode serves responses recorded in RecordingsDir
+// instead of reaching a proxy, while the other modes record to it when it's set.
+const (
+	ProxyModeLambda    = "lambda"
+	ProxyModeHTTP      = "http"
+	ProxyModeInProcess = "inprocess"
+	ProxyModeReplay    = "replay"
+)
+
 func setDefaults(_ context.Context, v *viper.Viper) error {
+	v.SetDefault(strings.ToLower(proxyModeKey), ProxyModeLambda)
 	v.SetDefault(strings.ToLower(meetupGroupNamesKey), []string{})
+	v.SetDefault(strings.ToLower(importConcurrencyKey), 3)
+	v.SetDefault(strings.ToLower(archiveMaxCountKey), 10)
+	v.SetDefault(strings.ToLower(archiveMaxPercentKey), 50)
+	v.SetDefault(strings.ToLower(archiveForceGroupsKey), []string{})
 	return nil
 }
 
 func (config *Config) validate() error {
 	var missing []string
 
-	if config.ProxyFunctionName == "" {
-		missing = append(missing, proxyFunctionNameKey)
+	switch config.ProxyMode {
+	case ProxyModeLambda:
+		if config.ProxyFunctionName == "" {
+			missing = append(missing, proxyFunctionNameKey)
+		}
+	case ProxyModeHTTP:
+		if config.ProxyURL == "" {
+			missing = append(missing, proxyURLKey)
+		}
+	case ProxyModeInProcess:
+	case ProxyModeReplay:
+		if config.RecordingsDir == "" {
+			missing = append(missing, recordingsDirKey)
+		}
+	default:
+		return fmt.Errorf(
+			"%s must be one of %s, %s, %s or %s",
+			proxyModeKey,
+			ProxyModeLambda,
+			ProxyModeHTTP,
+			ProxyModeInProcess,
+			ProxyModeReplay,
+		)
 	}
 	if config.EventsTableName == "" {
 		missing = append(missing, eventsTableNameKey)
@@ -82,11 +167,27 @@ func (config *Config) validate() error {
 	if config.GroupIDDateTimeIndexName == "" {
 		missing = append(missing, groupIDDateTimeIndexNameKey)
 	}
+	if config.ImportRunsTableName == "" {
+		missing = append(missing, importRunsTableNameKey)
+	}
+	if config.BackfillCheckpointsTableName == "" {
+		missing = append(missing, backfillCheckpointsTableKey)
+	}
+	if config.EventSeriesTableName == "" {
+		missing = append(missing, eventSeriesTableNameKey)
+	}
+	if config.GroupVersionsTableName == "" {
+		missing = append(missing, groupVersionsTableNameKey)
+	}
 
 	if len(missing) > 0 {
 		return fmt.Errorf("missing required env vars: %v", strings.Join(missing, ", "))
 	}
 
+	if config.ImportConcurrency < 1 {
+		return fmt.Errorf("%s must be at least 1", importConcurrencyKey)
+	}
+
 	return nil
 }
 
@@ -23,7 +23,14 @@ func TestNewConfig(t *testing.T) {
 		t.Setenv(eventsTableNameKey, "test-events")
 		t.Setenv(archivedEventsTableNameKey, "test-archived")
 		t.Setenv(groupIDDateTimeIndexNameKey, "test-index")
+		t.Setenv(importRunsTableNameKey, "test-import-runs")
+		t.Setenv(backfillCheckpointsTableKey, "test-checkpoints")
+		t.Setenv(eventSeriesTableNameKey, "test-event-series")
+		t.Setenv(groupVersionsTableNameKey, "test-group-versions")
 		t.Setenv(meetupGroupNamesKey, "group1,group2")
+		t.Setenv(archiveMaxCountKey, "5")
+		t.Setenv(archiveMaxPercentKey, "25")
+		t.Setenv(archiveForceGroupsKey, "group2")
 
 		cfg, err := NewConfig(ctx, awsConfigManager)
 		require.NoError(t, err)
@@ -32,7 +39,14 @@ func TestNewConfig(t *testing.T) {
 		assert.Equal(t, "test-events", cfg.EventsTableName)
 		assert.Equal(t, "test-archived", cfg.ArchivedEventsTableName)
 		assert.Equal(t, "test-index", cfg.GroupIDDateTimeIndexName)
+		assert.Equal(t, "test-import-runs", cfg.ImportRunsTableName)
+		assert.Equal(t, "test-checkpoints", cfg.BackfillCheckpointsTableName)
+		assert.Equal(t, "test-event-series", cfg.EventSeriesTableName)
+		assert.Equal(t, "test-group-versions", cfg.GroupVersionsTableName)
 		assert.Equal(t, []string{"group1", "group2"}, cfg.MeetupGroupNames)
+		assert.Equal(t, 5, cfg.ArchiveMaxCount)
+		assert.Equal(t, 25, cfg.ArchiveMaxPercent)
+		assert.Equal(t, []string{"group2"}, cfg.ArchiveForceGroups)
 	})
 
 	t.Run("successful load from .env file", func(t *testing.T) {
@@ -44,6 +58,10 @@ func TestNewConfig(t *testing.T) {
 			eventsTableNameKey + "=file-events",
 			archivedEventsTableNameKey + "=file-archived",
 			groupIDDateTimeIndexNameKey + "=file-index",
+			importRunsTableNameKey + "=file-import-runs",
+			backfillCheckpointsTableKey + "=file-checkpoints",
+			eventSeriesTableNameKey + "=file-event-series",
+			groupVersionsTableNameKey + "=file-group-versions",
 			meetupGroupNamesKey + "=group3,group4",
 		}, "\n")
 
@@ -61,20 +79,158 @@ func TestNewConfig(t *testing.T) {
 		assert.Equal(t, "file-events", cfg.EventsTableName)
 		assert.Equal(t, "file-archived", cfg.ArchivedEventsTableName)
 		assert.Equal(t, "file-index", cfg.GroupIDDateTimeIndexName)
+		assert.Equal(t, "file-import-runs", cfg.ImportRunsTableName)
+		assert.Equal(t, "file-checkpoints", cfg.BackfillCheckpointsTableName)
+		assert.Equal(t, "file-event-series", cfg.EventSeriesTableName)
+		assert.Equal(t, "file-group-versions", cfg.GroupVersionsTableName)
 		assert.Equal(t, []string{"group3", "group4"}, cfg.MeetupGroupNames)
 	})
 
-	t.Run("sets default values for MeetupGroupNames", func(t *testing.T) {
+	t.Run("sets default values", func(t *testing.T) {
 		switchToTempTestDir(t)
 		t.Setenv(proxyFunctionNameKey, "test-proxy")
 		t.Setenv(eventsTableNameKey, "test-events")
 		t.Setenv(archivedEventsTableNameKey, "test-archived")
 		t.Setenv(groupIDDateTimeIndexNameKey, "test-index")
+		t.Setenv(importRunsTableNameKey, "test-import-runs")
+		t.Setenv(backfillCheckpointsTableKey, "test-checkpoints")
+		t.Setenv(eventSeriesTableNameKey, "test-event-series")
+		t.Setenv(groupVersionsTableNameKey, "test-group-versions")
 
 		cfg, err := NewConfig(ctx, awsConfigManager)
 		require.NoError(t, err)
 
 		assert.Empty(t, cfg.MeetupGroupNames)
+		assert.Equal(t, 10, cfg.ArchiveMaxCount)
+		assert.Equal(t, 50, cfg.ArchiveMaxPercent)
+		assert.Empty(t, cfg.ArchiveForceGroups)
+		assert.Equal(t, 3, cfg.ImportConcurrency)
+		assert.Empty(t, cfg.Groups)
+	})
+
+	t.Run("builds groups from MEETUP_GROUP_NAMES", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv(meetupGroupNamesKey, "group1,group2")
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(t, []GroupConfig{
+			{URLName: "group1", Source: GroupSourceMeetup},
+			{URLName: "group2", Source: GroupSourceMeetup},
+		}, cfg.Groups)
+	})
+
+	t.Run("loads structured groups and merges MEETUP_GROUP_NAMES", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv(meetupGroupsKey, `[{
+			"urlname": "group1",
+			"disabled": true,
+			"displayName": "Group One",
+			"tags": ["tech"],
+			"horizonDays": 30,
+			"importPastEvents": true
+		}]`)
+		t.Setenv(meetupGroupNamesKey, "group1,group2")
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(t, []GroupConfig{
+			{
+				URLName:          "group1",
+				Disabled:         true,
+				DisplayName:      "Group One",
+				Source:           GroupSourceMeetup,
+				Tags:             []string{"tech"},
+				HorizonDays:      30,
+				ImportPastEvents: true,
+			},
+			{URLName: "group2", Source: GroupSourceMeetup},
+		}, cfg.Groups)
+	})
+
+	t.Run("loads structured groups from a file", func(t *testing.T) {
+		setRequiredEnv(t)
+		path := filepath.Join(t.TempDir(), "groups.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"urlname": "group1"}]`), 0o600))
+		t.Setenv(meetupGroupsFileKey, path)
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(t, []GroupConfig{{URLName: "group1", Source: GroupSourceMeetup}}, cfg.Groups)
+	})
+
+	t.Run("rejects invalid group config", func(t *testing.T) {
+		tests := map[string]string{
+			"invalid json":       `{`,
+			"missing urlname":    `[{"displayName": "Group"}]`,
+			"duplicate group":    `[{"urlname": "group1"}, {"urlname": "group1"}]`,
+			"unsupported source": `[{"urlname": "group1", "source": "eventbrite"}]`,
+			"negative horizon":   `[{"urlname": "group1", "horizonDays": -1}]`,
+		}
+
+		for name, groups := range tests {
+			t.Run(name, func(t *testing.T) {
+				setRequiredEnv(t)
+				t.Setenv(meetupGroupsKey, groups)
+
+				_, err := NewConfig(ctx, awsConfigManager)
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("rejects concurrency below one", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv(importConcurrencyKey, "0")
+
+		_, err := NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), importConcurrencyKey)
+	})
+
+	t.Run("proxy modes", func(t *testing.T) {
+		setRequiredEnv(t)
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+		assert.Equal(t, ProxyModeLambda, cfg.ProxyMode)
+
+		t.Setenv(proxyModeKey, ProxyModeHTTP)
+		t.Setenv(proxyFunctionNameKey, "")
+		_, err = NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), proxyURLKey)
+		assert.NotContains(t, err.Error(), proxyFunctionNameKey)
+
+		t.Setenv(proxyURLKey, "http://localhost:8080/graphql")
+		t.Setenv(proxyTokenKey, "secret")
+		cfg, err = NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/graphql", cfg.ProxyURL)
+		assert.Equal(t, "secret", cfg.ProxyToken)
+
+		t.Setenv(proxyModeKey, ProxyModeInProcess)
+		t.Setenv(proxyURLKey, "")
+		_, err = NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		t.Setenv(proxyModeKey, ProxyModeReplay)
+		_, err = NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), recordingsDirKey)
+
+		t.Setenv(recordingsDirKey, "./recordings")
+		cfg, err = NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+		assert.Equal(t, "./recordings", cfg.RecordingsDir)
+
+		t.Setenv(proxyModeKey, "carrier-pigeon")
+		_, err = NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), proxyModeKey)
 	})
 
 	t.Run("validation fails with missing fields", func(t *testing.T) {
@@ -86,9 +242,27 @@ func TestNewConfig(t *testing.T) {
 		assert.Contains(t, err.Error(), eventsTableNameKey)
 		assert.Contains(t, err.Error(), archivedEventsTableNameKey)
 		assert.Contains(t, err.Error(), groupIDDateTimeIndexNameKey)
+		assert.Contains(t, err.Error(), importRunsTableNameKey)
+		assert.Contains(t, err.Error(), backfillCheckpointsTableKey)
+		assert.Contains(t, err.Error(), eventSeriesTableNameKey)
+		assert.Contains(t, err.Error(), groupVersionsTableNameKey)
 	})
 }
 
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+
+	switchToTempTestDir(t)
+	t.Setenv(proxyFunctionNameKey, "test-proxy")
+	t.Setenv(eventsTableNameKey, "test-events")
+	t.Setenv(archivedEventsTableNameKey, "test-archived")
+	t.Setenv(groupIDDateTimeIndexNameKey, "test-index")
+	t.Setenv(importRunsTableNameKey, "test-import-runs")
+	t.Setenv(backfillCheckpointsTableKey, "test-checkpoints")
+	t.Setenv(eventSeriesTableNameKey, "test-event-series")
+	t.Setenv(groupVersionsTableNameKey, "test-group-versions")
+}
+
 func switchToTempTestDir(t *testing.T) {
 	t.Helper()
 
@@ -0,0 +1,81 @@
+package importerconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+const GroupSourceMeetup = "meetup"
+
+// GroupConfig controls how a single group is imported. Groups listed only in MEETUP_GROUP_NAMES
+// get the zero value for everything but URLName and Source.
+type GroupConfig struct {
+	URLName     string `json:"urlname"`
+	Disabled    bool   `json:"disabled"`
+	DisplayName string `json:"displayName"`
+	Source      string `json:"source"`
+	// Tags are stored on every event imported for the group.
+	Tags []string `json:"tags"`
+	// HorizonDays is how far ahead to import events. Zero uses the default of six months.
+	HorizonDays int `json:"horizonDays"`
+	// ImportPastEvents has each import advance the group's archive backfill by a few pages.
+	ImportPastEvents bool `json:"importPastEvents"`
+}
+
+// parseGroups builds the group list from the structured MEETUP_GROUPS value or the file at
+// MEETUP_GROUPS_FILE, then appends any MEETUP_GROUP_NAMES that aren't already listed.
+func (config *Config) parseGroups() ([]GroupConfig, error) {
+	groupsJSON := []byte(config.MeetupGroupsJSON)
+	if len(groupsJSON) == 0 && config.MeetupGroupsFile != "" {
+		contents, err := os.ReadFile(config.MeetupGroupsFile)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", meetupGroupsFileKey, err)
+		}
+		groupsJSON = contents
+	}
+
+	var groups []GroupConfig
+	if len(groupsJSON) > 0 {
+		if err := json.Unmarshal(groupsJSON, &groups); err != nil {
+			return nil, fmt.Errorf("parse group config: %w", err)
+		}
+	}
+
+	for _, name := range config.MeetupGroupNames {
+		if slices.ContainsFunc(groups, func(g GroupConfig) bool { return g.URLName == name }) {
+			continue
+		}
+		groups = append(groups, GroupConfig{URLName: name})
+	}
+
+	seen := make(map[string]struct{}, len(groups))
+	for i := range groups {
+		group := &groups[i]
+
+		if group.URLName == "" {
+			return nil, fmt.Errorf("group config %d is missing urlname", i)
+		}
+		if _, ok := seen[group.URLName]; ok {
+			return nil, fmt.Errorf("group %s is configured more than once", group.URLName)
+		}
+		seen[group.URLName] = struct{}{}
+
+		if group.Source == "" {
+			group.Source = GroupSourceMeetup
+		}
+		if group.Source != GroupSourceMeetup {
+			return nil, fmt.Errorf(
+				"group %s has unsupported source %q",
+				group.URLName,
+				group.Source,
+			)
+		}
+		if group.HorizonDays < 0 {
+			return nil, fmt.Errorf("group %s has a negative horizonDays", group.URLName)
+		}
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sgf-meetup-api/pkg/meetupproxy"
+)
+
+// ProxyService runs Meetup proxy requests, implemented by meetupproxy.Service.
+type ProxyService interface {
+	HandleRequest(ctx context.Context, req meetupproxy.Request) (*meetupproxy.Response, error)
+}
+
+// InProcessProxyGraphQLHandler runs the Meetup proxy inside the importer, so it can run without
+// Lambda.
+type InProcessProxyGraphQLHandler struct {
+	proxy ProxyService
+}
+
+func NewInProcessProxyGraphQLHandler(proxy ProxyService) *InProcessProxyGraphQLHandler {
+	return &InProcessProxyGraphQLHandler{proxy: proxy}
+}
+
+func (h *InProcessProxyGraphQLHandler) ExecuteQuery(
+	ctx context.Context,
+	queryID string,
+	variables map[string]any,
+) ([]byte, error) {
+	resp, err := h.proxy.HandleRequest(ctx, meetupproxy.Request{
+		QueryID:   queryID,
+		Variables: variables,
+	})
+
+	var throttledErr *meetupproxy.ThrottledError
+	if errors.As(err, &throttledErr) {
+		return nil, fmt.Errorf("%w: %w", ErrMeetupThrottled, err)
+	}
+	var circuitOpenErr *meetupproxy.CircuitOpenError
+	if errors.As(err, &circuitOpenErr) {
+		return nil, fmt.Errorf("%w: %w", ErrMeetupUnavailable, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resp)
+}
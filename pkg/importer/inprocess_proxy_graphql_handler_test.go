@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sgf-meetup-api/pkg/meetupproxy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProxyService struct {
+	req  meetupproxy.Request
+	resp *meetupproxy.Response
+	err  error
+}
+
+func (m *mockProxyService) HandleRequest(
+	_ context.Context,
+	req meetupproxy.Request,
+) (*meetupproxy.Response, error) {
+	m.req = req
+	return m.resp, m.err
+}
+
+func TestInProcessProxyGraphQLHandler_ExecuteQuery(t *testing.T) {
+	t.Run("returns the proxy's response", func(t *testing.T) {
+		proxy := &mockProxyService{resp: &meetupproxy.Response{"data": "test"}}
+		handler := NewInProcessProxyGraphQLHandler(proxy)
+
+		result, err := handler.ExecuteQuery(
+			context.Background(),
+			meetupproxy.GroupFutureEventsQueryID,
+			map[string]any{"urlname": "sgfdevs"},
+		)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"data": "test"}`, string(result))
+		assert.Equal(t, meetupproxy.Request{
+			QueryID:   meetupproxy.GroupFutureEventsQueryID,
+			Variables: map[string]any{"urlname": "sgfdevs"},
+		}, proxy.req)
+	})
+
+	t.Run("throttling matches ErrMeetupThrottled", func(t *testing.T) {
+		handler := NewInProcessProxyGraphQLHandler(&mockProxyService{
+			err: &meetupproxy.ThrottledError{},
+		})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+
+		assert.ErrorIs(t, err, ErrMeetupThrottled)
+		var throttledErr *meetupproxy.ThrottledError
+		assert.ErrorAs(t, err, &throttledErr)
+	})
+
+	t.Run("an open circuit matches ErrMeetupUnavailable", func(t *testing.T) {
+		handler := NewInProcessProxyGraphQLHandler(&mockProxyService{
+			err: &meetupproxy.CircuitOpenError{},
+		})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+
+		assert.ErrorIs(t, err, ErrMeetupUnavailable)
+		var circuitOpenErr *meetupproxy.CircuitOpenError
+		assert.ErrorAs(t, err, &circuitOpenErr)
+	})
+
+	t.Run("returns other errors", func(t *testing.T) {
+		proxyErr := errors.New("proxy error")
+		handler := NewInProcessProxyGraphQLHandler(&mockProxyService{err: proxyErr})
+
+		_, err := handler.ExecuteQuery(context.Background(), "query", nil)
+
+		assert.ErrorIs(t, err, proxyErr)
+		assert.NotErrorIs(t, err, ErrMeetupThrottled)
+	})
+}
@@ -3,17 +3,52 @@ package importer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/meetupproxy"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
-	"github.com/google/wire"
 )
 
+// ErrMeetupThrottled matches proxy errors caused by Meetup rate limiting, which are likely to
+// succeed on a later run.
+var ErrMeetupThrottled = errors.New("meetup rate limit exceeded")
+
+// ErrMeetupUnavailable matches proxy errors caused by the proxy's circuit breaker being open
+// after Meetup kept failing.
+var ErrMeetupUnavailable = errors.New("meetup upstream unavailable")
+
+// ProxyError is a failed Meetup proxy invocation, decoded from the error payload Lambda
+// returns. Type is the name of the proxy's Go error type.
+type ProxyError struct {
+	FunctionError string `json:"-"`
+	Type          string `json:"errorType"`
+	Message       string `json:"errorMessage"`
+}
+
+func (e *ProxyError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("lambda execution error: %s", e.FunctionError)
+	}
+	return fmt.Sprintf("lambda execution error: %s: %s", e.Type, e.Message)
+}
+
+func (e *ProxyError) Is(target error) bool {
+	switch target {
+	case ErrMeetupThrottled:
+		return e.Type == "ThrottledError"
+	case ErrMeetupUnavailable:
+		return e.Type == "CircuitOpenError"
+	default:
+		return false
+	}
+}
+
 type LambdaProxyGraphQLHandlerConfig struct {
 	ProxyFunctionName string
 }
@@ -43,14 +78,11 @@ func NewLambdaProxyGraphQLHandler(
 
 func (m *LambdaProxyGraphQLHandler) ExecuteQuery(
 	ctx context.Context,
-	query string,
+	queryID string,
 	variables map[string]any,
 ) ([]byte, error) {
-	request := struct {
-		Query     string         `json:"query"`
-		Variables map[string]any `json:"variables"`
-	}{
-		Query:     query,
+	request := meetupproxy.Request{
+		QueryID:   queryID,
 		Variables: variables,
 	}
 
@@ -80,14 +112,11 @@ func (m *LambdaProxyGraphQLHandler) callLambda(
 	}
 
 	if result.FunctionError != nil {
-		return nil, fmt.Errorf("lambda execution error: %s", *result.FunctionError)
+		proxyErr := &ProxyError{FunctionError: *result.FunctionError}
+		// Payloads that aren't a Lambda error leave the type empty.
+		_ = json.Unmarshal(result.Payload, proxyErr)
+		return nil, proxyErr
 	}
 
 	return result.Payload, nil
 }
-
-var GraphQLHandlerProviders = wire.NewSet(
-	wire.Bind(new(GraphQLHandler), new(*LambdaProxyGraphQLHandler)),
-	NewLambdaProxyGraphQLHandlerConfig,
-	NewLambdaProxyGraphQLHandler,
-)
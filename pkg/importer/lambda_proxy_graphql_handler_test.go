@@ -3,11 +3,13 @@ package importer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/meetupproxy"
 	"sgf-meetup-api/pkg/shared/logging"
 
 	"github.com/stretchr/testify/assert"
@@ -43,7 +45,9 @@ func setupMockLambdaServer(t *testing.T, handler http.HandlerFunc) *httptest.Ser
 }
 
 func TestExecuteQuery_Success(t *testing.T) {
+	var request meetupproxy.Request
 	testServer := setupMockLambdaServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": "test"})
 	})
@@ -54,10 +58,18 @@ func TestExecuteQuery_Success(t *testing.T) {
 		logging.NewMockLogger(),
 	)
 
-	result, err := handler.ExecuteQuery(context.Background(), "query {}", nil)
+	result, err := handler.ExecuteQuery(
+		context.Background(),
+		meetupproxy.GroupFutureEventsQueryID,
+		map[string]any{"urlname": "sgfdevs"},
+	)
 
 	require.NoError(t, err)
 
+	assert.Equal(t, meetupproxy.GroupFutureEventsQueryID, request.QueryID)
+	assert.Empty(t, request.Query)
+	assert.Equal(t, map[string]any{"urlname": "sgfdevs"}, request.Variables)
+
 	var response map[string]interface{}
 	err = json.Unmarshal(result, &response)
 
@@ -77,7 +89,7 @@ func TestExecuteQuery_LambdaExecutionError(t *testing.T) {
 		logging.NewMockLogger(),
 	)
 
-	_, err := handler.ExecuteQuery(context.Background(), "query {}", nil)
+	_, err := handler.ExecuteQuery(context.Background(), meetupproxy.GroupFutureEventsQueryID, nil)
 	if err == nil {
 		t.Fatal("Expected error but got nil")
 	}
@@ -85,6 +97,51 @@ func TestExecuteQuery_LambdaExecutionError(t *testing.T) {
 	assert.Equal(t, "lambda execution error: Unhandled", err.Error())
 }
 
+func TestExecuteQuery_ProxyError(t *testing.T) {
+	tests := []struct {
+		name        string
+		errorType   string
+		throttled   bool
+		unavailable bool
+	}{
+		{name: "throttled", errorType: "ThrottledError", throttled: true},
+		{name: "circuit open", errorType: "CircuitOpenError", unavailable: true},
+		{name: "upstream failure", errorType: "UpstreamError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testServer := setupMockLambdaServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Amz-Function-Error", "Unhandled")
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"errorType":    tt.errorType,
+					"errorMessage": "proxy failure",
+				})
+			})
+			defer testServer.Close()
+
+			handler := NewLambdaProxyGraphQLHandler(
+				LambdaProxyGraphQLHandlerConfig{"test-function"},
+				logging.NewMockLogger(),
+			)
+
+			_, err := handler.ExecuteQuery(
+				context.Background(),
+				meetupproxy.GroupFutureEventsQueryID,
+				nil,
+			)
+
+			var proxyErr *ProxyError
+			require.ErrorAs(t, err, &proxyErr)
+			assert.Equal(t, tt.errorType, proxyErr.Type)
+			assert.Equal(t, "proxy failure", proxyErr.Message)
+			assert.Equal(t, tt.throttled, errors.Is(err, ErrMeetupThrottled))
+			assert.Equal(t, tt.unavailable, errors.Is(err, ErrMeetupUnavailable))
+		})
+	}
+}
+
 func TestExecuteQuery_LambdaInvokeError(t *testing.T) {
 	testServer := setupMockLambdaServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -96,7 +153,7 @@ func TestExecuteQuery_LambdaInvokeError(t *testing.T) {
 		logging.NewMockLogger(),
 	)
 
-	_, err := handler.ExecuteQuery(context.Background(), "query {}", nil)
+	_, err := handler.ExecuteQuery(context.Background(), meetupproxy.GroupFutureEventsQueryID, nil)
 
 	assert.Error(t, err)
 }
@@ -111,7 +168,11 @@ func TestExecuteQuery_MarshalError(t *testing.T) {
 		"channel": make(chan int), // Channels can't be JSON marshaled
 	}
 
-	_, err := handler.ExecuteQuery(context.Background(), "query {}", invalidVariables)
+	_, err := handler.ExecuteQuery(
+		context.Background(),
+		meetupproxy.GroupFutureEventsQueryID,
+		invalidVariables,
+	)
 
 	assert.Error(t, err)
 }
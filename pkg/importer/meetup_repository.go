@@ -6,21 +6,35 @@ import (
 	"log/slog"
 	"time"
 
+	"sgf-meetup-api/pkg/meetupproxy"
 	"sgf-meetup-api/pkg/shared/models"
 
 	"github.com/google/wire"
 )
 
 type MeetupRepository interface {
+	// GetEventsUntilDateForGroup returns the group's upcoming events up to beforeDate along with
+	// the number of pages requested from Meetup.
 	GetEventsUntilDateForGroup(
 		ctx context.Context,
 		group string,
 		beforeDate time.Time,
-	) ([]models.MeetupEvent, error)
+	) ([]models.MeetupEvent, int, error)
+	// GetPastEventsPage returns the page of the group's past events that follows cursor, starting
+	// from the first page when cursor is empty.
+	GetPastEventsPage(ctx context.Context, group string, cursor string) (*EventsPage, error)
 }
 
+// EventsPage is a single page of a group's events from Meetup.
+type EventsPage struct {
+	Events      []models.MeetupEvent
+	EndCursor   string
+	HasNextPage bool
+}
+
+// GraphQLHandler runs the Meetup proxy's persisted queries, identified by their query ID.
 type GraphQLHandler interface {
-	ExecuteQuery(ctx context.Context, query string, variables map[string]any) ([]byte, error)
+	ExecuteQuery(ctx context.Context, queryID string, variables map[string]any) ([]byte, error)
 }
 
 type GraphQLMeetupRepository struct {
@@ -38,48 +52,6 @@ func NewGraphQLMeetupRepository(
 	}
 }
 
-const getFutureEventsQuery = `
-  query ($urlname: String!, $itemsNum: Int!, $cursor: String) {
-	groupByUrlname(urlname: $urlname) {
-	  events(first: $itemsNum, after: $cursor, filter: { status: [ACTIVE] }) {
-		totalCount
-		pageInfo {
-		  endCursor
-		  hasNextPage
-		}
-		edges {
-		  node {
-			id
-			title
-			eventUrl
-			description
-			dateTime
-			duration
-			venue {
-			  name
-			  address
-			  city
-			  state
-			  postalCode
-			}
-			group {
-			  name
-			  urlname
-			}
-			eventHosts {
-			  name
-			}
-			featuredEventPhoto {
-			  id
-			  baseUrl
-			}
-		  }
-		}
-	  }
-	}
-  }
-`
-
 type MeetupFutureEventsResponse struct {
 	Data struct {
 		GroupByUrlname struct {
@@ -103,63 +75,104 @@ func (r *GraphQLMeetupRepository) GetEventsUntilDateForGroup(
 	ctx context.Context,
 	group string,
 	beforeDate time.Time,
-) ([]models.MeetupEvent, error) {
+) ([]models.MeetupEvent, int, error) {
+	var maxFutureDate time.Time
+
+	return r.getEventPages(
+		ctx,
+		meetupproxy.GroupFutureEventsQueryID,
+		group,
+		func(events []models.MeetupEvent) bool {
+			for _, event := range events {
+				if event.DateTime.After(maxFutureDate) {
+					maxFutureDate = event.DateTime.Time
+				}
+			}
+
+			return !maxFutureDate.After(beforeDate)
+		},
+	)
+}
+
+func (r *GraphQLMeetupRepository) GetPastEventsPage(
+	ctx context.Context,
+	group string,
+	cursor string,
+) (*EventsPage, error) {
+	return r.getEventsPage(ctx, meetupproxy.GroupPastEventsQueryID, group, cursor)
+}
+
+// getEventPages pages through the query until Meetup runs out of events or wantMore returns false
+// for the page just fetched.
+func (r *GraphQLMeetupRepository) getEventPages(
+	ctx context.Context,
+	queryID string,
+	group string,
+	wantMore func(events []models.MeetupEvent) bool,
+) ([]models.MeetupEvent, int, error) {
 	events := make([]models.MeetupEvent, 0)
 	cursor := ""
-	var maxFutureDate time.Time
+	pages := 0
 
 	for {
-		variables := map[string]any{
-			"urlname":  group,
-			"itemsNum": 50,
+		page, err := r.getEventsPage(ctx, queryID, group, cursor)
+		if err != nil {
+			return nil, pages, err
 		}
+		pages++
 
-		if cursor != "" {
-			variables["cursor"] = cursor
-		}
+		events = append(events, page.Events...)
 
-		response, err := executeGraphQLQuery[MeetupFutureEventsResponse](
-			r,
-			ctx,
-			getFutureEventsQuery,
-			variables,
-		)
-		if err != nil {
-			return nil, err
+		if !wantMore(page.Events) || !page.HasNextPage {
+			break
 		}
 
-		for _, edge := range response.Data.GroupByUrlname.Events.Edges {
-			event := edge.Node
-			events = append(events, event)
+		cursor = page.EndCursor
+	}
 
-			if event.DateTime.After(maxFutureDate) {
-				maxFutureDate = event.DateTime.Time
-			}
-		}
+	return events, pages, nil
+}
 
-		if maxFutureDate.After(beforeDate) {
-			break
-		}
+func (r *GraphQLMeetupRepository) getEventsPage(
+	ctx context.Context,
+	queryID string,
+	group string,
+	cursor string,
+) (*EventsPage, error) {
+	variables := map[string]any{
+		"urlname":  group,
+		"itemsNum": 50,
+	}
 
-		pageInfo := response.Data.GroupByUrlname.Events.PageInfo
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
 
-		if !pageInfo.HasNextPage {
-			break
-		}
+	response, err := executeGraphQLQuery[MeetupFutureEventsResponse](r, ctx, queryID, variables)
+	if err != nil {
+		return nil, err
+	}
 
-		cursor = pageInfo.EndCursor
+	events := response.Data.GroupByUrlname.Events
+	page := &EventsPage{
+		Events:      make([]models.MeetupEvent, 0, len(events.Edges)),
+		EndCursor:   events.PageInfo.EndCursor,
+		HasNextPage: events.PageInfo.HasNextPage,
+	}
+	for _, edge := range events.Edges {
+		page.Events = append(page.Events, edge.Node)
 	}
 
-	return events, nil
+	return page, nil
 }
 
 func executeGraphQLQuery[T any](
 	r *GraphQLMeetupRepository,
 	ctx context.Context,
-	query string,
+	queryID string,
 	variables map[string]any,
 ) (*T, error) {
-	responseBytes, err := r.handler.ExecuteQuery(ctx, query, variables)
+	responseBytes, err := r.handler.ExecuteQuery(ctx, queryID, variables)
 	if err != nil {
 		return nil, err
 	}
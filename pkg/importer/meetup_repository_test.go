@@ -28,10 +28,15 @@ func TestMeetupRepository_GetEventsUntilDateForGroup(t *testing.T) {
 		repo := NewGraphQLMeetupRepository(mock, logging.NewMockLogger())
 		beforeDate := now.Add(72 * time.Hour)
 
-		events, err := repo.GetEventsUntilDateForGroup(context.Background(), "group", beforeDate)
+		events, pages, err := repo.GetEventsUntilDateForGroup(
+			context.Background(),
+			"group",
+			beforeDate,
+		)
 
 		require.NoError(t, err)
 		assert.Len(t, events, 2)
+		assert.Equal(t, 1, pages)
 		assert.Equal(t, mock.callCount, 1)
 	})
 
@@ -44,10 +49,15 @@ func TestMeetupRepository_GetEventsUntilDateForGroup(t *testing.T) {
 		repo := NewGraphQLMeetupRepository(mock, logging.NewMockLogger())
 		beforeDate := now.Add(60 * time.Hour)
 
-		events, err := repo.GetEventsUntilDateForGroup(context.Background(), "group", beforeDate)
+		events, pages, err := repo.GetEventsUntilDateForGroup(
+			context.Background(),
+			"group",
+			beforeDate,
+		)
 
 		require.NoError(t, err)
 		assert.Len(t, events, 4)
+		assert.Equal(t, 2, pages)
 		assert.Equal(t, mock.callCount, 2)
 	})
 
@@ -60,10 +70,15 @@ func TestMeetupRepository_GetEventsUntilDateForGroup(t *testing.T) {
 		repo := NewGraphQLMeetupRepository(mock, logging.NewMockLogger())
 		beforeDate := now.Add(100 * time.Hour)
 
-		events, err := repo.GetEventsUntilDateForGroup(context.Background(), "group", beforeDate)
+		events, pages, err := repo.GetEventsUntilDateForGroup(
+			context.Background(),
+			"group",
+			beforeDate,
+		)
 
 		require.NoError(t, err)
 		assert.Len(t, events, 4)
+		assert.Equal(t, 2, pages)
 		assert.Equal(t, mock.callCount, 2)
 	})
 
@@ -77,13 +92,38 @@ func TestMeetupRepository_GetEventsUntilDateForGroup(t *testing.T) {
 		}
 
 		repo := NewGraphQLMeetupRepository(failingMock, logging.NewMockLogger())
-		_, err := repo.GetEventsUntilDateForGroup(context.Background(), "group", now)
+		_, _, err := repo.GetEventsUntilDateForGroup(context.Background(), "group", now)
 
 		assert.Error(t, err)
 		assert.Equal(t, failingMock.callCount, 1)
 	})
 }
 
+func TestMeetupRepository_GetPastEventsPage(t *testing.T) {
+	meetupFaker := fakers.NewMeetupFaker(0)
+
+	mock := mockPaginationHandler(meetupFaker, [][]time.Duration{
+		{-96 * time.Hour, -72 * time.Hour},
+		{-48 * time.Hour},
+	})
+
+	repo := NewGraphQLMeetupRepository(mock, logging.NewMockLogger())
+
+	page, err := repo.GetPastEventsPage(context.Background(), "group", "")
+	require.NoError(t, err)
+
+	assert.Len(t, page.Events, 2)
+	assert.True(t, page.HasNextPage)
+	assert.NotEmpty(t, page.EndCursor)
+	assert.Equal(t, 1, mock.callCount)
+
+	page, err = repo.GetPastEventsPage(context.Background(), "group", page.EndCursor)
+	require.NoError(t, err)
+
+	assert.Len(t, page.Events, 1)
+	assert.False(t, page.HasNextPage)
+}
+
 type mockGraphQLHandler struct {
 	callCount int
 	handlers  []func() (*MeetupFutureEventsResponse, error)
@@ -0,0 +1,246 @@
+package importer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy"
+	"sgf-meetup-api/pkg/shared/clock"
+)
+
+// scrubbedKeys are the JSON keys whose values are replaced when recording, compared without
+// case, underscores or dashes.
+var scrubbedKeys = []string{
+	"accesstoken",
+	"refreshtoken",
+	"token",
+	"authorization",
+	"clientsecret",
+	"secret",
+	"password",
+	"assertion",
+	"privatekey",
+}
+
+const scrubbedValue = "[SCRUBBED]"
+
+// GraphQLRecording is a query and the response it got, as saved in a recording file. Query is
+// the persisted query's text when it was recorded, kept to show what produced the response.
+type GraphQLRecording struct {
+	QueryID    string          `json:"queryId"`
+	Query      string          `json:"query,omitempty"`
+	Variables  map[string]any  `json:"variables"`
+	Response   json.RawMessage `json:"response"`
+	RecordedAt time.Time       `json:"recordedAt"`
+}
+
+// recordingKey identifies a query by its ID and scrubbed variables. Variables are compared as
+// JSON so numbers match however they were typed.
+func recordingKey(queryID string, variables map[string]any) (string, error) {
+	// Map keys are marshalled in sorted order, so equal variables always produce equal JSON.
+	variablesJSON, err := json.Marshal(scrub(variables))
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(append([]byte(queryID+"\x00"), variablesJSON...))
+
+	return queryID + "-" + hex.EncodeToString(hash[:6]), nil
+}
+
+// RecordingGraphQLHandler saves every successful query made through the handler it wraps to
+// a file in its directory, with credentials scrubbed, for ReplayGraphQLHandler to serve.
+type RecordingGraphQLHandler struct {
+	handler    GraphQLHandler
+	dir        string
+	queries    *meetupproxy.QueryRegistry
+	timeSource clock.TimeSource
+}
+
+func NewRecordingGraphQLHandler(
+	handler GraphQLHandler,
+	dir string,
+	timeSource clock.TimeSource,
+) *RecordingGraphQLHandler {
+	return &RecordingGraphQLHandler{
+		handler:    handler,
+		dir:        dir,
+		queries:    meetupproxy.NewDefaultQueryRegistry(),
+		timeSource: timeSource,
+	}
+}
+
+func (h *RecordingGraphQLHandler) ExecuteQuery(
+	ctx context.Context,
+	queryID string,
+	variables map[string]any,
+) ([]byte, error) {
+	resp, err := h.handler.ExecuteQuery(ctx, queryID, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.record(queryID, variables, resp); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", queryID, err)
+	}
+
+	return resp, nil
+}
+
+func (h *RecordingGraphQLHandler) record(
+	queryID string,
+	variables map[string]any,
+	resp []byte,
+) error {
+	key, err := recordingKey(queryID, variables)
+	if err != nil {
+		return err
+	}
+
+	var response any
+	if err = json.Unmarshal(resp, &response); err != nil {
+		return err
+	}
+
+	scrubbedResponse, err := json.Marshal(scrub(response))
+	if err != nil {
+		return err
+	}
+
+	recording := GraphQLRecording{
+		QueryID:    queryID,
+		Variables:  scrub(variables).(map[string]any),
+		Response:   scrubbedResponse,
+		RecordedAt: h.timeSource.Now().UTC(),
+	}
+	if query, ok := h.queries.Lookup(queryID); ok {
+		recording.Query = query.Query
+	}
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(h.dir, 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(h.dir, key+".json"), append(data, '\n'), 0o644)
+}
+
+// scrub returns value with the values of credential keys replaced, at any depth.
+func scrub(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		scrubbed := make(map[string]any, len(v))
+		for key, item := range v {
+			if isScrubbedKey(key) {
+				scrubbed[key] = scrubbedValue
+			} else {
+				scrubbed[key] = scrub(item)
+			}
+		}
+		return scrubbed
+	case []any:
+		scrubbed := make([]any, len(v))
+		for i, item := range v {
+			scrubbed[i] = scrub(item)
+		}
+		return scrubbed
+	default:
+		return v
+	}
+}
+
+func isScrubbedKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return slices.Contains(scrubbedKeys, normalized)
+}
+
+// UnmatchedQueryError is returned when replaying a query that wasn't recorded.
+type UnmatchedQueryError struct {
+	QueryID   string
+	Variables map[string]any
+	Dir       string
+}
+
+func (e *UnmatchedQueryError) Error() string {
+	variables, _ := json.Marshal(e.Variables)
+	return fmt.Sprintf(
+		"no recording of %s with variables %s in %s, record it with MEETUP_RECORDINGS_DIR",
+		e.QueryID,
+		variables,
+		e.Dir,
+	)
+}
+
+// ReplayGraphQLHandler serves the responses saved by RecordingGraphQLHandler, failing any query
+// that wasn't recorded.
+type ReplayGraphQLHandler struct {
+	dir        string
+	recordings map[string]GraphQLRecording
+}
+
+// NewReplayGraphQLHandler loads every recording in dir.
+func NewReplayGraphQLHandler(dir string) (*ReplayGraphQLHandler, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no recordings in %s", dir)
+	}
+
+	recordings := make(map[string]GraphQLRecording, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var recording GraphQLRecording
+		if err = json.Unmarshal(data, &recording); err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+		}
+
+		key, err := recordingKey(recording.QueryID, recording.Variables)
+		if err != nil {
+			return nil, err
+		}
+
+		recordings[key] = recording
+	}
+
+	return &ReplayGraphQLHandler{
+		dir:        dir,
+		recordings: recordings,
+	}, nil
+}
+
+func (h *ReplayGraphQLHandler) ExecuteQuery(
+	_ context.Context,
+	queryID string,
+	variables map[string]any,
+) ([]byte, error) {
+	key, err := recordingKey(queryID, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	recording, ok := h.recordings[key]
+	if !ok {
+		return nil, &UnmatchedQueryError{QueryID: queryID, Variables: variables, Dir: h.dir}
+	}
+
+	return recording.Response, nil
+}
@@ -0,0 +1,201 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticGraphQLHandler struct {
+	response  []byte
+	err       error
+	callCount int
+}
+
+func (h *staticGraphQLHandler) ExecuteQuery(
+	_ context.Context,
+	_ string,
+	_ map[string]any,
+) ([]byte, error) {
+	h.callCount++
+	return h.response, h.err
+}
+
+func TestRecordingGraphQLHandler(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 4, 5, 0, time.UTC)
+	variables := map[string]any{"urlname": "sgfdevs", "itemsNum": 50}
+
+	t.Run("records responses for replay", func(t *testing.T) {
+		dir := t.TempDir()
+		inner := &staticGraphQLHandler{response: []byte(`{"data":{"groupByUrlname":null}}`)}
+		recorder := NewRecordingGraphQLHandler(inner, dir, clock.NewMockTimeSource(now))
+
+		resp, err := recorder.ExecuteQuery(
+			context.Background(),
+			meetupproxy.GroupFutureEventsQueryID,
+			variables,
+		)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":{"groupByUrlname":null}}`, string(resp))
+
+		replay, err := NewReplayGraphQLHandler(dir)
+		require.NoError(t, err)
+
+		resp, err = replay.ExecuteQuery(
+			context.Background(),
+			meetupproxy.GroupFutureEventsQueryID,
+			map[string]any{"itemsNum": 50, "urlname": "sgfdevs"},
+		)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":{"groupByUrlname":null}}`, string(resp))
+
+		recording := readRecording(t, dir)
+		assert.Equal(t, meetupproxy.GroupFutureEventsQueryID, recording.QueryID)
+		assert.Contains(t, recording.Query, "groupByUrlname")
+		assert.Equal(t, now, recording.RecordedAt)
+	})
+
+	t.Run("scrubs credentials", func(t *testing.T) {
+		dir := t.TempDir()
+		inner := &staticGraphQLHandler{
+			response: []byte(`{"data":{"viewer":{"access_token":"abc","name":"Jo"}}}`),
+		}
+		recorder := NewRecordingGraphQLHandler(inner, dir, clock.NewMockTimeSource(now))
+
+		resp, err := recorder.ExecuteQuery(
+			context.Background(),
+			"viewer@v1",
+			map[string]any{"clientSecret": "shh", "items": []any{map[string]any{"Token": "t"}}},
+		)
+		require.NoError(t, err)
+		assert.Contains(t, string(resp), "abc", "callers still get the real response")
+
+		data, err := os.ReadFile(filepath.Join(dir, readRecordingName(t, dir)))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "abc")
+		assert.NotContains(t, string(data), "shh")
+		assert.NotContains(t, string(data), `"t"`)
+		assert.Contains(t, string(data), "Jo")
+
+		replay, err := NewReplayGraphQLHandler(dir)
+		require.NoError(t, err)
+
+		_, err = replay.ExecuteQuery(
+			context.Background(),
+			"viewer@v1",
+			map[string]any{"clientSecret": "other", "items": []any{map[string]any{"Token": "x"}}},
+		)
+		assert.NoError(t, err, "scrubbed variables match whatever credentials are sent")
+	})
+
+	t.Run("doesn't record errors", func(t *testing.T) {
+		dir := t.TempDir()
+		inner := &staticGraphQLHandler{err: ErrMeetupThrottled}
+		recorder := NewRecordingGraphQLHandler(inner, dir, clock.NewMockTimeSource(now))
+
+		_, err := recorder.ExecuteQuery(
+			context.Background(),
+			meetupproxy.GroupFutureEventsQueryID,
+			variables,
+		)
+		require.ErrorIs(t, err, ErrMeetupThrottled)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestReplayGraphQLHandler(t *testing.T) {
+	t.Run("fails on unmatched queries", func(t *testing.T) {
+		replay, err := NewReplayGraphQLHandler("testdata/recordings")
+		require.NoError(t, err)
+
+		_, err = replay.ExecuteQuery(
+			context.Background(),
+			meetupproxy.GroupFutureEventsQueryID,
+			map[string]any{"urlname": "open-sgf", "itemsNum": 50},
+		)
+
+		var unmatchedErr *UnmatchedQueryError
+		require.True(t, errors.As(err, &unmatchedErr))
+		assert.Equal(t, meetupproxy.GroupFutureEventsQueryID, unmatchedErr.QueryID)
+		assert.Contains(t, err.Error(), "open-sgf")
+	})
+
+	t.Run("requires recordings", func(t *testing.T) {
+		_, err := NewReplayGraphQLHandler(t.TempDir())
+		assert.ErrorContains(t, err, "no recordings")
+	})
+
+	t.Run("rejects invalid recordings", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0o644))
+
+		_, err := NewReplayGraphQLHandler(dir)
+		assert.ErrorContains(t, err, "invalid recording")
+	})
+}
+
+func TestMeetupRepository_Replay(t *testing.T) {
+	replay, err := NewReplayGraphQLHandler("testdata/recordings")
+	require.NoError(t, err)
+
+	repo := NewGraphQLMeetupRepository(replay, logging.NewMockLogger())
+
+	events, pages, err := repo.GetEventsUntilDateForGroup(
+		context.Background(),
+		"sgfdevs",
+		time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pages)
+	require.Len(t, events, 2)
+
+	physical := events[0]
+	assert.Equal(t, "309876543", physical.ID)
+	assert.Equal(t, "sgfdevs", physical.GroupID)
+	require.NotNil(t, physical.Host)
+	assert.Equal(t, "Jordan Reyes", physical.Host.Name)
+	require.Len(t, physical.Images, 1)
+	assert.Equal(
+		t,
+		"https://secure.meetupstatic.com/photos/event/528431337/676x380.jpg",
+		physical.Images[0].BaseUrl,
+	)
+
+	online := events[1]
+	assert.Empty(t, online.Images)
+	assert.Equal(t, "https://www.meetup.com/sgfdevs/events/309876601/online", online.OnlineURL)
+	assert.Equal(t, "RECURRING-42", online.MeetupSeriesID)
+}
+
+func readRecordingName(t *testing.T, dir string) string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	return entries[0].Name()
+}
+
+func readRecording(t *testing.T, dir string) GraphQLRecording {
+	replay, err := NewReplayGraphQLHandler(dir)
+	require.NoError(t, err)
+	require.Len(t, replay.recordings, 1)
+
+	for _, recording := range replay.recordings {
+		return recording
+	}
+
+	return GraphQLRecording{}
+}
@@ -0,0 +1,159 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ImportReport describes the changes an import made, or would make when DryRun is set.
+type ImportReport struct {
+	DryRun bool          `json:"dryRun"`
+	Groups []GroupReport `json:"groups"`
+}
+
+type GroupReport struct {
+	Group    string        `json:"group"`
+	Inserts  []EventChange `json:"inserts"`
+	Updates  []EventChange `json:"updates"`
+	Archives []EventChange `json:"archives"`
+	// ArchiveSkipped is set when the archive guard stopped Archives from being archived.
+	ArchiveSkipped bool   `json:"archiveSkipped"`
+	Error          string `json:"error,omitempty"`
+}
+
+type EventChange struct {
+	ID     string      `json:"id"`
+	Title  string      `json:"title"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff is a change to a single stored attribute of an event. Nested attributes such as the
+// venue are reported whole.
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+func newEventChange(event models.MeetupEvent, fields []FieldDiff) EventChange {
+	return EventChange{
+		ID:     event.ID,
+		Title:  event.Title,
+		Fields: fields,
+	}
+}
+
+// diffEvents compares events by their stored representation so differences lost on save, such
+// as time zone locations, aren't reported.
+func diffEvents(saved, incoming models.MeetupEvent) ([]FieldDiff, error) {
+	savedItem, err := attributevalue.MarshalMap(saved)
+	if err != nil {
+		return nil, err
+	}
+
+	incomingItem, err := attributevalue.MarshalMap(incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := maps.Clone(incomingItem)
+	maps.Copy(fields, savedItem)
+
+	var diffs []FieldDiff
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		before, after := savedItem[field], incomingItem[field]
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+
+		diffs = append(diffs, FieldDiff{
+			Field:  field,
+			Before: attributeToValue(before),
+			After:  attributeToValue(after),
+		})
+	}
+
+	return diffs, nil
+}
+
+func attributeToValue(av types.AttributeValue) any {
+	if av == nil {
+		return nil
+	}
+
+	var value any
+	if err := attributevalue.Unmarshal(av, &value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// WriteText writes the report in a form meant for reading in a terminal.
+func (r *ImportReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+
+	if r.DryRun {
+		b.WriteString("Dry run, no changes were written.\n")
+	}
+
+	for _, group := range r.Groups {
+		fmt.Fprintf(&b, "\n%s: %d inserts, %d updates, %d archives\n",
+			group.Group, len(group.Inserts), len(group.Updates), len(group.Archives))
+
+		if group.Error != "" {
+			fmt.Fprintf(&b, "  error: %s\n", group.Error)
+		}
+
+		for _, change := range group.Inserts {
+			fmt.Fprintf(&b, "  + %s %q\n", change.ID, change.Title)
+		}
+
+		for _, change := range group.Updates {
+			fmt.Fprintf(&b, "  ~ %s %q\n", change.ID, change.Title)
+			for _, field := range change.Fields {
+				fmt.Fprintf(&b, "      %s: %s -> %s\n",
+					field.Field, formatValue(field.Before), formatValue(field.After))
+			}
+		}
+
+		if group.ArchiveSkipped {
+			b.WriteString("  archive guard threshold exceeded, archives skipped\n")
+		}
+
+		for _, change := range group.Archives {
+			fmt.Fprintf(&b, "  - %s %q\n", change.ID, change.Title)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *ImportReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func formatValue(value any) string {
+	if value == nil {
+		return "<none>"
+	}
+
+	formatted, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(formatted)
+}
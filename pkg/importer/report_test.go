@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/fakers"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEvents(t *testing.T) {
+	meetupFaker := fakers.NewMeetupFaker(0)
+	event := meetupFaker.CreateEvent("group", time.Now().Add(time.Hour))
+
+	t.Run("returns nothing for identical events", func(t *testing.T) {
+		diffs, err := diffEvents(event, event)
+
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("ignores time zone differences", func(t *testing.T) {
+		incoming := event
+		incoming.DateTime = &models.CustomTime{Time: event.DateTime.In(time.FixedZone("", 0))}
+
+		diffs, err := diffEvents(event, incoming)
+
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("reports changed fields in order", func(t *testing.T) {
+		incoming := event
+		incoming.Title = "New title"
+		incoming.Duration = "PT9H"
+
+		diffs, err := diffEvents(event, incoming)
+
+		require.NoError(t, err)
+		require.Len(t, diffs, 2)
+		assert.Equal(t, "duration", diffs[0].Field)
+		assert.Equal(t, "title", diffs[1].Field)
+		assert.Equal(t, event.Title, diffs[1].Before)
+		assert.Equal(t, "New title", diffs[1].After)
+	})
+}
+
+func TestImportReport_Write(t *testing.T) {
+	report := &ImportReport{
+		DryRun: true,
+		Groups: []GroupReport{{
+			Group:   "sgfdevs",
+			Inserts: []EventChange{{ID: "1", Title: "New"}},
+			Updates: []EventChange{{
+				ID:     "2",
+				Title:  "Changed",
+				Fields: []FieldDiff{{Field: "title", Before: "Old", After: "Changed"}},
+			}},
+			Archives:       []EventChange{{ID: "3", Title: "Gone"}},
+			ArchiveSkipped: true,
+		}},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.WriteText(&buf))
+
+		assert.Equal(t, `Dry run, no changes were written.
+
+sgfdevs: 1 inserts, 1 updates, 1 archives
+  + 1 "New"
+  ~ 2 "Changed"
+      title: "Old" -> "Changed"
+  archive guard threshold exceeded, archives skipped
+  - 3 "Gone"
+`, buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.WriteJSON(&buf))
+
+		var decoded ImportReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "sgfdevs", decoded.Groups[0].Group)
+		assert.Equal(t, "title", decoded.Groups[0].Updates[0].Fields[0].Field)
+	})
+}
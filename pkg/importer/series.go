@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+
+	"sgf-meetup-api/pkg/shared/models"
+)
+
+// seriesMatch identifies the series an event would belong to. A detected match only becomes a
+// series once at least minDetectedOccurrences events share it.
+type seriesMatch struct {
+	source models.EventSeriesSource
+	key    string
+}
+
+const minDetectedOccurrences = 2
+
+// seriesTitleStopWords are dropped from titles along with numbers before comparing them, so
+// "Code & Demo Night - March 2025" and "Code & Demo Night #12" match "Code & Demo Night".
+var seriesTitleStopWords = map[string]struct{}{
+	"jan": {}, "january": {}, "feb": {}, "february": {}, "mar": {}, "march": {},
+	"apr": {}, "april": {}, "may": {}, "jun": {}, "june": {}, "jul": {}, "july": {},
+	"aug": {}, "august": {}, "sep": {}, "sept": {}, "september": {}, "oct": {}, "october": {},
+	"nov": {}, "november": {}, "dec": {}, "december": {},
+}
+
+// assignSeries sets SeriesID on the events that recur and returns the series they belong to.
+// Events Meetup reports as recurring use Meetup's series, others are grouped by title, venue
+// and weekday. Known events are the group's saved events, counted so a series is still found
+// when only one of its occurrences is being imported. Series IDs are derived from the match,
+// so an event keeps its series across imports.
+func assignSeries(
+	events []models.MeetupEvent,
+	known []models.MeetupEvent,
+	updatedAt time.Time,
+) []models.EventSeries {
+	occurrences := make(map[seriesMatch]map[string]struct{})
+	for _, list := range [][]models.MeetupEvent{known, events} {
+		for i := range list {
+			match, ok := matchSeries(&list[i])
+			if !ok {
+				continue
+			}
+			if occurrences[match] == nil {
+				occurrences[match] = make(map[string]struct{})
+			}
+			occurrences[match][list[i].ID] = struct{}{}
+		}
+	}
+
+	latest := make(map[string]*models.MeetupEvent)
+	sources := make(map[string]models.EventSeriesSource)
+	var seriesIDs []string
+
+	for i := range events {
+		event := &events[i]
+		event.SeriesID = ""
+
+		match, ok := matchSeries(event)
+		if !ok {
+			continue
+		}
+		if match.source == models.EventSeriesSourceDetected &&
+			len(occurrences[match]) < minDetectedOccurrences {
+			continue
+		}
+
+		event.SeriesID = newSeriesID(event.GroupID, match)
+
+		current, ok := latest[event.SeriesID]
+		if !ok {
+			seriesIDs = append(seriesIDs, event.SeriesID)
+			sources[event.SeriesID] = match.source
+		}
+		if !ok || startsAfter(event, current) {
+			latest[event.SeriesID] = event
+		}
+	}
+
+	series := make([]models.EventSeries, len(seriesIDs))
+	for i, seriesID := range seriesIDs {
+		event := latest[seriesID]
+
+		series[i] = models.EventSeries{
+			SeriesID:  seriesID,
+			GroupID:   event.GroupID,
+			GroupName: event.GroupName,
+			Title:     event.Title,
+			Source:    sources[seriesID],
+			UpdatedAt: updatedAt,
+		}
+		if event.Venue != nil {
+			series[i].VenueName = event.Venue.Name
+		}
+		if event.DateTime != nil {
+			series[i].Weekday = event.DateTime.Weekday().String()
+		}
+	}
+
+	return series
+}
+
+func matchSeries(event *models.MeetupEvent) (seriesMatch, bool) {
+	if event.MeetupSeriesID != "" {
+		return seriesMatch{source: models.EventSeriesSourceMeetup, key: event.MeetupSeriesID}, true
+	}
+
+	title := normalizeSeriesTitle(event.Title)
+	if title == "" || event.DateTime == nil {
+		return seriesMatch{}, false
+	}
+
+	// The weekday is taken in the event's own time zone, which CustomTime preserves
+	key := strings.Join([]string{
+		title,
+		seriesVenue(event),
+		event.DateTime.Weekday().String(),
+	}, "|")
+
+	return seriesMatch{source: models.EventSeriesSourceDetected, key: key}, true
+}
+
+func normalizeSeriesTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+	})
+
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		if _, ok := seriesTitleStopWords[word]; ok {
+			continue
+		}
+		// Drops counters, years and ordinals such as 12, 2025 and 3rd
+		if unicode.IsDigit(rune(word[0])) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+
+	return strings.Join(kept, " ")
+}
+
+func seriesVenue(event *models.MeetupEvent) string {
+	if event.Venue != nil && strings.TrimSpace(event.Venue.Name) != "" {
+		return strings.ToLower(strings.TrimSpace(event.Venue.Name))
+	}
+	if event.IsOnline() {
+		return "online"
+	}
+	return ""
+}
+
+// newSeriesID hashes the match so IDs are URL safe and don't collide across groups.
+func newSeriesID(groupID string, match seriesMatch) string {
+	sum := sha256.Sum256([]byte(groupID + "\x00" + string(match.source) + "\x00" + match.key))
+	return hex.EncodeToString(sum[:8])
+}
+
+func startsAfter(event, other *models.MeetupEvent) bool {
+	if event.DateTime == nil {
+		return false
+	}
+	return other.DateTime == nil || event.DateTime.After(other.DateTime.Time)
+}
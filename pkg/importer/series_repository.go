@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"context"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type SeriesRepository interface {
+	UpsertSeries(ctx context.Context, series []models.EventSeries) error
+}
+
+type DynamoDBSeriesRepositoryConfig struct {
+	EventSeriesTableName string
+}
+
+func NewDynamoDBSeriesRepositoryConfig(
+	config *importerconfig.Config,
+) DynamoDBSeriesRepositoryConfig {
+	return DynamoDBSeriesRepositoryConfig{
+		EventSeriesTableName: config.EventSeriesTableName,
+	}
+}
+
+type DynamoDBSeriesRepository struct {
+	config DynamoDBSeriesRepositoryConfig
+	db     *db.Client
+}
+
+func NewDynamoDBSeriesRepository(
+	config DynamoDBSeriesRepositoryConfig,
+	db *db.Client,
+) *DynamoDBSeriesRepository {
+	return &DynamoDBSeriesRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (r *DynamoDBSeriesRepository) UpsertSeries(
+	ctx context.Context,
+	series []models.EventSeries,
+) error {
+	if len(series) == 0 {
+		return nil
+	}
+
+	writeRequests := make([]types.WriteRequest, 0, len(series))
+
+	for _, s := range series {
+		av, err := attributevalue.MarshalMap(s)
+		if err != nil {
+			return err
+		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: av},
+		})
+	}
+
+	return db.BatchWriteItems(
+		ctx,
+		r.db,
+		db.DefaultRetryPolicy,
+		r.config.EventSeriesTableName,
+		writeRequests,
+	)
+}
+
+var SeriesRepositoryProviders = wire.NewSet(
+	wire.Bind(new(SeriesRepository), new(*DynamoDBSeriesRepository)),
+	NewDynamoDBSeriesRepositoryConfig,
+	NewDynamoDBSeriesRepository,
+)
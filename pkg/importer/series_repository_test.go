@@ -0,0 +1,62 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/importer/importerconfig"
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDynamoDBSeriesRepositoryConfig(t *testing.T) {
+	cfg := &importerconfig.Config{EventSeriesTableName: "eventSeries"}
+
+	repoConfig := NewDynamoDBSeriesRepositoryConfig(cfg)
+
+	assert.Equal(t, cfg.EventSeriesTableName, repoConfig.EventSeriesTableName)
+}
+
+func TestDynamoDBSeriesRepository_UpsertSeries(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	repoConfig := DynamoDBSeriesRepositoryConfig{
+		EventSeriesTableName: *infra.EventSeriesTableProps.TableName,
+	}
+	repo := NewDynamoDBSeriesRepository(repoConfig, testDB.Client)
+
+	t.Run("upserts series across chunks", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		updatedAt := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+		series := make([]models.EventSeries, 0, db.MaxBatchSize+1)
+		for i := range db.MaxBatchSize + 1 {
+			series = append(series, models.EventSeries{
+				SeriesID:  fmt.Sprintf("series-%d", i),
+				GroupID:   "test-group",
+				Title:     "Code & Demo Night",
+				Weekday:   time.Tuesday.String(),
+				Source:    models.EventSeriesSourceDetected,
+				UpdatedAt: updatedAt,
+			})
+		}
+
+		require.NoError(t, repo.UpsertSeries(ctx, series))
+		require.NoError(t, repo.UpsertSeries(ctx, series[:1]))
+
+		assert.Equal(t, len(series), testDB.GetItemCount(ctx, repoConfig.EventSeriesTableName))
+	})
+
+	t.Run("handles empty input list", func(t *testing.T) {
+		require.NoError(t, repo.UpsertSeries(ctx, []models.EventSeries{}))
+	})
+}
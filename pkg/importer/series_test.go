@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignSeries(t *testing.T) {
+	updatedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	central := time.FixedZone("CDT", -5*60*60)
+
+	newEvent := func(id, title string, dateTime time.Time) models.MeetupEvent {
+		return models.MeetupEvent{
+			ID:        id,
+			GroupID:   "open-sgf",
+			GroupName: "Open SGF",
+			Title:     title,
+			DateTime:  &models.CustomTime{Time: dateTime},
+			Venue:     &models.MeetupVenue{Name: "efactory"},
+		}
+	}
+
+	// Third Tuesdays of April and May 2025
+	april := time.Date(2025, 4, 15, 18, 30, 0, 0, central)
+	may := time.Date(2025, 5, 20, 18, 30, 0, 0, central)
+
+	t.Run("detects events sharing a title, venue and weekday", func(t *testing.T) {
+		events := []models.MeetupEvent{
+			newEvent("1", "Code & Demo Night - April 2025", april),
+			newEvent("2", "Code & Demo Night - May 2025", may),
+			newEvent("3", "Open Source Saturday", time.Date(2025, 4, 19, 10, 0, 0, 0, central)),
+		}
+
+		series := assignSeries(events, nil, updatedAt)
+
+		require.Len(t, series, 1)
+		assert.NotEmpty(t, events[0].SeriesID)
+		assert.Equal(t, events[0].SeriesID, events[1].SeriesID)
+		assert.Empty(t, events[2].SeriesID)
+		assert.Equal(t, models.EventSeries{
+			SeriesID:  events[0].SeriesID,
+			GroupID:   "open-sgf",
+			GroupName: "Open SGF",
+			Title:     "Code & Demo Night - May 2025",
+			VenueName: "efactory",
+			Weekday:   "Tuesday",
+			Source:    models.EventSeriesSourceDetected,
+			UpdatedAt: updatedAt,
+		}, series[0])
+	})
+
+	t.Run("counts known events towards a detected series", func(t *testing.T) {
+		known := []models.MeetupEvent{newEvent("1", "Code & Demo Night #11", april)}
+		events := []models.MeetupEvent{newEvent("2", "Code & Demo Night #12", may)}
+
+		series := assignSeries(events, known, updatedAt)
+
+		require.Len(t, series, 1)
+		assert.Equal(t, series[0].SeriesID, events[0].SeriesID)
+	})
+
+	t.Run("doesn't count the same event twice", func(t *testing.T) {
+		known := []models.MeetupEvent{newEvent("1", "Code & Demo Night", april)}
+		events := []models.MeetupEvent{newEvent("1", "Code & Demo Night", april)}
+
+		series := assignSeries(events, known, updatedAt)
+
+		assert.Empty(t, series)
+		assert.Empty(t, events[0].SeriesID)
+	})
+
+	t.Run("keeps events on different weekdays or venues apart", func(t *testing.T) {
+		elsewhere := newEvent("3", "Code & Demo Night", may)
+		elsewhere.Venue = &models.MeetupVenue{Name: "The Library Center"}
+
+		events := []models.MeetupEvent{
+			newEvent("1", "Code & Demo Night", april),
+			newEvent("2", "Code & Demo Night", april.AddDate(0, 0, 1)),
+			elsewhere,
+		}
+
+		assert.Empty(t, assignSeries(events, nil, updatedAt))
+	})
+
+	t.Run("uses Meetup's series for a single occurrence", func(t *testing.T) {
+		event := newEvent("1", "Code & Demo Night", april)
+		event.MeetupSeriesID = "ptvbqtyhcgbvb"
+		events := []models.MeetupEvent{event}
+
+		series := assignSeries(events, nil, updatedAt)
+
+		require.Len(t, series, 1)
+		assert.Equal(t, models.EventSeriesSourceMeetup, series[0].Source)
+		assert.Equal(t, series[0].SeriesID, events[0].SeriesID)
+	})
+
+	t.Run("assigns stable IDs scoped to the group", func(t *testing.T) {
+		first := []models.MeetupEvent{
+			newEvent("1", "Code & Demo Night", april),
+			newEvent("2", "Code & Demo Night", may),
+		}
+		second := []models.MeetupEvent{
+			newEvent("3", "Code & Demo Night", may),
+			newEvent("4", "Code & Demo Night", may.AddDate(0, 0, 28)),
+		}
+		otherGroup := []models.MeetupEvent{
+			newEvent("5", "Code & Demo Night", april),
+			newEvent("6", "Code & Demo Night", may),
+		}
+		for i := range otherGroup {
+			otherGroup[i].GroupID = "sgfdevs"
+		}
+
+		assignSeries(first, nil, updatedAt)
+		assignSeries(second, nil, updatedAt)
+		assignSeries(otherGroup, nil, updatedAt)
+
+		assert.Equal(t, first[0].SeriesID, second[0].SeriesID)
+		assert.NotEqual(t, first[0].SeriesID, otherGroup[0].SeriesID)
+	})
+}
+
+func TestNormalizeSeriesTitle(t *testing.T) {
+	assert.Equal(t, "code & demo night", normalizeSeriesTitle("Code & Demo Night - March 2025"))
+	assert.Equal(t, "code & demo night", normalizeSeriesTitle("Code & Demo Night #12"))
+	assert.Equal(t, "go meetup edition", normalizeSeriesTitle("Go Meetup: 3rd Edition"))
+	assert.Empty(t, normalizeSeriesTitle("2025"))
+}
@@ -3,29 +3,60 @@ package importer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"sgf-meetup-api/pkg/importer/importerconfig"
 	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/models"
+
+	"github.com/google/uuid"
 )
 
 type ServiceConfig struct {
-	GroupNames []string
+	Groups []importerconfig.GroupConfig
+	// Concurrency is how many groups are imported at once. The config ensures it is at least 1.
+	Concurrency  int
+	ArchiveGuard ArchiveGuardConfig
+}
+
+// pastEventPagesPerImport caps how many pages of past events an import fetches for a group
+// with ImportPastEvents, so a long history is backfilled over several runs.
+const pastEventPagesPerImport = 2
+
+// ArchiveGuardConfig limits how many saved events a single import may archive for a group.
+// A zero MaxCount or MaxPercent disables that check.
+type ArchiveGuardConfig struct {
+	MaxCount    int
+	MaxPercent  int
+	ForceGroups []string
 }
 
 func NewServiceConfig(config *importerconfig.Config) ServiceConfig {
 	return ServiceConfig{
-		GroupNames: config.MeetupGroupNames,
+		Groups:      config.Groups,
+		Concurrency: config.ImportConcurrency,
+		ArchiveGuard: ArchiveGuardConfig{
+			MaxCount:    config.ArchiveMaxCount,
+			MaxPercent:  config.ArchiveMaxPercent,
+			ForceGroups: config.ArchiveForceGroups,
+		},
 	}
 }
 
 type Service struct {
-	config           ServiceConfig
-	timeSource       clock.TimeSource
-	logger           *slog.Logger
-	eventRepository  EventRepository
-	meetupRepository MeetupRepository
+	config                 ServiceConfig
+	timeSource             clock.TimeSource
+	logger                 *slog.Logger
+	eventRepository        EventRepository
+	meetupRepository       MeetupRepository
+	importRunRepository    ImportRunRepository
+	seriesRepository       SeriesRepository
+	groupVersionRepository GroupVersionRepository
+	backfiller             *Backfiller
 }
 
 func NewService(
@@ -34,87 +65,484 @@ func NewService(
 	logger *slog.Logger,
 	eventRepository EventRepository,
 	meetupRepository MeetupRepository,
+	importRunRepository ImportRunRepository,
+	seriesRepository SeriesRepository,
+	groupVersionRepository GroupVersionRepository,
+	backfiller *Backfiller,
 ) *Service {
 	return &Service{
-		config:           config,
-		timeSource:       timeSource,
-		logger:           logger,
-		eventRepository:  eventRepository,
-		meetupRepository: meetupRepository,
+		config:                 config,
+		timeSource:             timeSource,
+		logger:                 logger,
+		eventRepository:        eventRepository,
+		meetupRepository:       meetupRepository,
+		importRunRepository:    importRunRepository,
+		seriesRepository:       seriesRepository,
+		groupVersionRepository: groupVersionRepository,
+		backfiller:             backfiller,
+	}
+}
+
+var (
+	ErrUnknownGroup  = errors.New("group is not configured for import")
+	ErrGroupDisabled = errors.New("group is disabled for import")
+)
+
+type groupImportOutcome struct {
+	run     *models.ImportRun
+	report  *GroupReport
+	changed hostSet
+	err     error
+}
+
+// hostSet collects the groups hosting the events an import changes, since a co-hosted event is
+// returned in the listings of every host.
+type hostSet map[string]struct{}
+
+func (s hostSet) add(event models.MeetupEvent) {
+	s[event.GroupID] = struct{}{}
+	for _, coHost := range event.CoHosts() {
+		s[coHost] = struct{}{}
 	}
 }
 
+// ImportOptions narrows an import. The zero value imports every enabled group and writes the
+// results. It doubles as the importer Lambda's payload.
+type ImportOptions struct {
+	Groups []string `json:"groups"`
+	DryRun bool     `json:"dryRun"`
+}
+
 func (s *Service) Import(ctx context.Context) error {
-	sixMonthsFromNow := s.timeSource.Now().AddDate(0, 6, 0)
+	_, err := s.ImportWithOptions(ctx, ImportOptions{})
+	return err
+}
+
+// ImportWithOptions imports the selected groups and reports the changes made to each. With
+// DryRun set nothing is written and the report describes what the import would have done.
+func (s *Service) ImportWithOptions(
+	ctx context.Context,
+	opts ImportOptions,
+) (*ImportReport, error) {
+	startedAt := s.timeSource.Now().UTC()
+
+	groups, err := selectGroups(s.config.Groups, opts.Groups)
+	if err != nil {
+		if !opts.DryRun {
+			s.saveRejectedRun(ctx, startedAt, opts.Groups, err)
+		}
+		return nil, err
+	}
 
-	const maxConcurrency = 3
-	semaphore := make(chan struct{}, maxConcurrency)
-	results := make(chan error, len(s.config.GroupNames))
+	groupNames := make([]string, len(groups))
+	for i, group := range groups {
+		groupNames[i] = group.URLName
+	}
 
-	for _, group := range s.config.GroupNames {
+	run := models.ImportRun{
+		Scope:     models.ImportRunScopeAll,
+		RunID:     newImportRunID(startedAt),
+		Groups:    groupNames,
+		StartedAt: startedAt,
+	}
+
+	semaphore := make(chan struct{}, s.config.Concurrency)
+	results := make(chan groupImportOutcome, len(groups))
+
+	for _, group := range groups {
 		semaphore <- struct{}{}
-		go s.importWorker(ctx, group, sixMonthsFromNow, results, semaphore)
+		go s.importWorker(ctx, run.RunID, group, opts.DryRun, results, semaphore)
 	}
 
+	report := &ImportReport{
+		DryRun: opts.DryRun,
+		Groups: make([]GroupReport, 0, len(groups)),
+	}
+	runs := make([]models.ImportRun, 0, len(groups)+1)
+	changed := hostSet{}
+	var succeeded []string
 	var multiErr error
-	for range s.config.GroupNames {
-		if err := <-results; err != nil {
-			multiErr = errors.Join(multiErr, err)
+	var unavailableGroups []string
+	for range groups {
+		outcome := <-results
+		for group := range outcome.changed {
+			changed[group] = struct{}{}
+		}
+		if outcome.err == nil {
+			succeeded = append(succeeded, outcome.report.Group)
 		}
+
+		if errors.Is(outcome.err, ErrMeetupUnavailable) {
+			unavailableGroups = append(unavailableGroups, outcome.report.Group)
+		} else if outcome.err != nil {
+			multiErr = errors.Join(multiErr, outcome.err)
+		}
+
+		report.Groups = append(report.Groups, *outcome.report)
+
+		run.Fetched += outcome.run.Fetched
+		run.Upserted += outcome.run.Upserted
+		run.Unchanged += outcome.run.Unchanged
+		run.Archived += outcome.run.Archived
+		run.MeetupPages += outcome.run.MeetupPages
+		run.Anomalies = append(run.Anomalies, outcome.run.Anomalies...)
+		runs = append(runs, *outcome.run)
 	}
 	close(results)
-	return multiErr
+
+	slices.SortFunc(report.Groups, func(a, b GroupReport) int {
+		return strings.Compare(a.Group, b.Group)
+	})
+
+	// Groups failing because Meetup is down are reported as one error rather than one each.
+	if len(unavailableGroups) > 0 {
+		slices.Sort(unavailableGroups)
+		s.logger.Warn("meetup upstream unavailable",
+			slog.String("runId", run.RunID),
+			slog.Any("groups", unavailableGroups),
+		)
+		multiErr = errors.Join(
+			fmt.Errorf("%w for %s", ErrMeetupUnavailable, strings.Join(unavailableGroups, ", ")),
+			multiErr,
+		)
+	}
+
+	if multiErr != nil {
+		run.Error = multiErr.Error()
+	}
+	run.EndedAt = s.timeSource.Now().UTC()
+	runs = append(runs, run)
+
+	if opts.DryRun {
+		return report, multiErr
+	}
+
+	if err := s.importRunRepository.SaveImportRuns(ctx, runs); err != nil {
+		s.logger.Error("error saving import runs",
+			slog.String("runId", run.RunID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	s.saveGroupVersions(ctx, run, changed, succeeded)
+
+	return report, multiErr
+}
+
+// saveGroupVersions bumps the version of every group whose events the import changed, including
+// co-hosts that weren't imported. Groups imported without changes keep their version. Versions
+// are saved after the events so a new version is never served with old events.
+func (s *Service) saveGroupVersions(
+	ctx context.Context,
+	run models.ImportRun,
+	changed hostSet,
+	succeeded []string,
+) {
+	newVersion := func(group string) models.GroupVersion {
+		return models.GroupVersion{GroupID: group, Version: run.RunID, UpdatedAt: run.EndedAt}
+	}
+
+	changedVersions := make([]models.GroupVersion, 0, len(changed))
+	for group := range changed {
+		changedVersions = append(changedVersions, newVersion(group))
+	}
+	slices.SortFunc(changedVersions, func(a, b models.GroupVersion) int {
+		return strings.Compare(a.GroupID, b.GroupID)
+	})
+
+	var unchangedVersions []models.GroupVersion
+	for _, group := range succeeded {
+		if _, ok := changed[group]; !ok {
+			unchangedVersions = append(unchangedVersions, newVersion(group))
+		}
+	}
+
+	err := s.groupVersionRepository.SaveGroupVersions(ctx, changedVersions, unchangedVersions)
+	if err != nil {
+		s.logger.Error("error saving group versions",
+			slog.String("runId", run.RunID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// selectGroups returns the configured groups matching names, or every enabled group when names
+// is empty.
+// saveRejectedRun records an import that didn't start because the requested groups couldn't be
+// imported. Imports triggered by the admin API run in the background, so the run is the only
+// place the failure shows up.
+func (s *Service) saveRejectedRun(
+	ctx context.Context,
+	startedAt time.Time,
+	groups []string,
+	err error,
+) {
+	run := models.ImportRun{
+		Scope:     models.ImportRunScopeAll,
+		RunID:     newImportRunID(startedAt),
+		Groups:    groups,
+		StartedAt: startedAt,
+		EndedAt:   s.timeSource.Now().UTC(),
+		Error:     err.Error(),
+	}
+
+	if err := s.importRunRepository.SaveImportRuns(ctx, []models.ImportRun{run}); err != nil {
+		s.logger.Error("error saving import runs",
+			slog.String("runId", run.RunID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func selectGroups(
+	configured []importerconfig.GroupConfig,
+	names []string,
+) ([]importerconfig.GroupConfig, error) {
+	if len(names) == 0 {
+		groups := make([]importerconfig.GroupConfig, 0, len(configured))
+		for _, group := range configured {
+			if !group.Disabled {
+				groups = append(groups, group)
+			}
+		}
+		return groups, nil
+	}
+
+	groups := make([]importerconfig.GroupConfig, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(configured, func(g importerconfig.GroupConfig) bool {
+			return g.URLName == name
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownGroup, name)
+		}
+		if configured[i].Disabled {
+			return nil, fmt.Errorf("%w: %s", ErrGroupDisabled, name)
+		}
+		groups = append(groups, configured[i])
+	}
+
+	return groups, nil
 }
 
 func (s *Service) importWorker(
 	ctx context.Context,
-	group string,
-	beforeDate time.Time,
-	results chan<- error,
+	runID string,
+	group importerconfig.GroupConfig,
+	dryRun bool,
+	results chan<- groupImportOutcome,
 	signal <-chan struct{},
 ) {
 	defer func() { <-signal }()
 
-	err := s.importForGroup(ctx, group, beforeDate)
+	run := &models.ImportRun{
+		Scope:     group.URLName,
+		RunID:     runID,
+		StartedAt: s.timeSource.Now().UTC(),
+	}
+
+	report := &GroupReport{Group: group.URLName}
+	changed := hostSet{}
+
+	err := s.importForGroup(ctx, group, dryRun, run, report, changed)
 	if err != nil {
-		s.logger.Error("error fetching events", slog.String("group", group))
-		results <- err
-	} else {
-		results <- nil
+		// Throttled groups are expected to catch up on the next run, and unavailable ones are
+		// logged once for the whole import.
+		switch {
+		case errors.Is(err, ErrMeetupThrottled):
+			s.logger.Warn("meetup rate limited import", slog.String("group", group.URLName))
+		case errors.Is(err, ErrMeetupUnavailable):
+			s.logger.Debug("meetup unavailable for import", slog.String("group", group.URLName))
+		default:
+			s.logger.Error("error fetching events", slog.String("group", group.URLName))
+		}
+		run.Error = err.Error()
+		report.Error = err.Error()
 	}
+	run.EndedAt = s.timeSource.Now().UTC()
+
+	results <- groupImportOutcome{run: run, report: report, changed: changed, err: err}
 }
 
-func (s *Service) importForGroup(ctx context.Context, group string, beforeDate time.Time) error {
+func (s *Service) importForGroup(
+	ctx context.Context,
+	groupConfig importerconfig.GroupConfig,
+	dryRun bool,
+	run *models.ImportRun,
+	report *GroupReport,
+	changed hostSet,
+) error {
+	group := groupConfig.URLName
+
 	savedEvents, err := s.eventRepository.GetUpcomingEventsForGroup(ctx, group)
 	if err != nil {
 		return err
 	}
 
 	missingEventIds := make([]string, 0)
-	incomingEvents, err := s.meetupRepository.GetEventsUntilDateForGroup(ctx, group, beforeDate)
+	incomingEvents, pages, err := s.meetupRepository.GetEventsUntilDateForGroup(
+		ctx,
+		group,
+		s.horizon(groupConfig),
+	)
+	run.MeetupPages = pages
 	if err != nil {
 		return err
 	}
 
+	savedEventsByID := make(map[string]models.MeetupEvent, len(savedEvents))
+	for _, savedEvent := range savedEvents {
+		savedEventsByID[savedEvent.ID] = savedEvent
+	}
+
+	// Meetup lists a co-hosted event under every host with its owner as the group, so each
+	// import only adds its own group as a co-host and applies the owner's config. The event
+	// repository keeps the co-hosts other imports have stored.
+	for i := range incomingEvents {
+		event := &incomingEvents[i]
+		applyGroupConfig(event, ownerGroupConfig(s.config.Groups, event))
+		event.AddCoHost(group)
+	}
+
+	series := assignSeries(incomingEvents, savedEvents, s.timeSource.Now().UTC())
+
 	incomingEventIds := make(map[string]struct{}, len(incomingEvents))
 	for _, incomingEvent := range incomingEvents {
 		incomingEventIds[incomingEvent.ID] = struct{}{}
+
+		savedEvent, ok := savedEventsByID[incomingEvent.ID]
+		if !ok {
+			report.Inserts = append(report.Inserts, newEventChange(incomingEvent, nil))
+			changed.add(incomingEvent)
+			continue
+		}
+
+		// Compare the event as it'll be stored, with the co-hosts recorded so far.
+		storedEvent := incomingEvent
+		for _, coHost := range savedEvent.CoHosts() {
+			storedEvent.AddCoHost(coHost)
+		}
+
+		fields, err := diffEvents(savedEvent, storedEvent)
+		if err != nil {
+			return err
+		}
+
+		if len(fields) == 0 {
+			run.Unchanged++
+			continue
+		}
+
+		report.Updates = append(report.Updates, newEventChange(storedEvent, fields))
+		changed.add(savedEvent)
+		changed.add(storedEvent)
 	}
 
+	// An event missing from a co-host's listing is only archived by its owner's import
+	var droppedCoHostEvents []models.MeetupEvent
 	for _, savedEvent := range savedEvents {
-		if _, ok := incomingEventIds[savedEvent.ID]; !ok {
-			missingEventIds = append(missingEventIds, savedEvent.ID)
+		if _, ok := incomingEventIds[savedEvent.ID]; ok {
+			continue
+		}
+
+		if savedEvent.GroupID != group {
+			updatedEvent := savedEvent
+			updatedEvent.RemoveCoHost(group)
+
+			fields, err := diffEvents(savedEvent, updatedEvent)
+			if err != nil {
+				return err
+			}
+
+			droppedCoHostEvents = append(droppedCoHostEvents, savedEvent)
+			report.Updates = append(report.Updates, newEventChange(updatedEvent, fields))
+			changed.add(savedEvent)
+			continue
 		}
+
+		missingEventIds = append(missingEventIds, savedEvent.ID)
+		report.Archives = append(report.Archives, newEventChange(savedEvent, nil))
+	}
+
+	run.Fetched = len(incomingEvents)
+	run.Upserted = len(report.Inserts) + len(report.Updates)
+
+	archiveBlocked := s.config.ArchiveGuard.blocks(group, len(savedEvents), len(missingEventIds))
+	report.ArchiveSkipped = archiveBlocked
+
+	if dryRun {
+		if !archiveBlocked {
+			run.Archived = len(missingEventIds)
+		}
+
+		s.logger.Info("dry run, skipped writing events for group",
+			slog.String("group", group),
+			slog.Int("eventsInDb", len(savedEvents)),
+			slog.Int("eventsToInsert", len(report.Inserts)),
+			slog.Int("eventsToUpdate", len(report.Updates)),
+			slog.Int("eventsToArchive", run.Archived),
+		)
+
+		return nil
 	}
 
 	if err = s.eventRepository.UpsertEvents(ctx, incomingEvents); err != nil {
 		return err
 	}
 
+	if err = s.seriesRepository.UpsertSeries(ctx, series); err != nil {
+		return err
+	}
+
+	if len(droppedCoHostEvents) > 0 {
+		if err = s.eventRepository.RemoveCoHost(ctx, group, droppedCoHostEvents); err != nil {
+			return err
+		}
+	}
+
+	if archiveBlocked {
+		s.logger.Error("skipped archiving events, archive guard threshold exceeded",
+			slog.String("group", group),
+			slog.Int("eventsInDb", len(savedEvents)),
+			slog.Int("eventsFromMeetup", len(incomingEvents)),
+			slog.Int("eventsToArchive", len(missingEventIds)),
+			slog.Any("eventIds", missingEventIds),
+		)
+
+		run.Anomalies = append(run.Anomalies, models.ImportAnomaly{
+			Type:     models.ImportAnomalyArchiveSkipped,
+			Message:  "archive guard threshold exceeded",
+			EventIDs: missingEventIds,
+		})
+		missingEventIds = missingEventIds[:0]
+	}
+
+	for _, id := range missingEventIds {
+		changed.add(savedEventsByID[id])
+	}
+
 	if err = s.eventRepository.ArchiveEvents(ctx, missingEventIds); err != nil {
 		return err
 	}
 
+	run.Archived = len(missingEventIds)
+
+	// Past events belong to the archive table, so they're picked up by advancing the group's
+	// backfill a few pages rather than compared against the live table.
+	if groupConfig.ImportPastEvents {
+		var backfillReport GroupBackfillReport
+		err = s.backfiller.backfillGroup(
+			ctx,
+			groupConfig,
+			BackfillOptions{MaxPages: pastEventPagesPerImport, CatchUp: true},
+			&backfillReport,
+		)
+		run.MeetupPages += backfillReport.Pages
+		if err != nil {
+			return err
+		}
+	}
+
 	s.logger.Info("successfully imported events for group",
 		slog.String("group", group),
 		slog.Int("eventsInDb", len(savedEvents)),
@@ -124,3 +552,60 @@ func (s *Service) importForGroup(ctx context.Context, group string, beforeDate t
 
 	return nil
 }
+
+// horizon returns the date after which upcoming events aren't imported for the group.
+func (s *Service) horizon(group importerconfig.GroupConfig) time.Time {
+	if group.HorizonDays > 0 {
+		return s.timeSource.Now().AddDate(0, 0, group.HorizonDays)
+	}
+	return s.timeSource.Now().AddDate(0, 6, 0)
+}
+
+// ownerGroupConfig returns the config of the group owning the event, or the zero config when
+// the owner isn't configured for import.
+func ownerGroupConfig(
+	groups []importerconfig.GroupConfig,
+	event *models.MeetupEvent,
+) importerconfig.GroupConfig {
+	i := slices.IndexFunc(groups, func(g importerconfig.GroupConfig) bool {
+		return g.URLName == event.GroupID
+	})
+	if i < 0 {
+		return importerconfig.GroupConfig{}
+	}
+	return groups[i]
+}
+
+func applyGroupConfig(event *models.MeetupEvent, group importerconfig.GroupConfig) {
+	if group.DisplayName != "" {
+		event.GroupName = group.DisplayName
+	}
+	if len(group.Tags) > 0 {
+		event.Tags = slices.Clone(group.Tags)
+	}
+}
+
+// newImportRunID returns an ID that sorts by startedAt, with a random suffix so concurrent
+// invocations don't collide.
+func newImportRunID(startedAt time.Time) string {
+	return startedAt.UTC().Format("20060102T150405.000Z") + "-" + uuid.NewString()[:8]
+}
+
+// blocks reports whether archiving archiveCount of savedCount events should be refused.
+// The percentage check only applies once more than one event would be archived so a group
+// cancelling its only upcoming event isn't flagged.
+func (c ArchiveGuardConfig) blocks(group string, savedCount, archiveCount int) bool {
+	if archiveCount == 0 || slices.Contains(c.ForceGroups, group) {
+		return false
+	}
+
+	if c.MaxCount > 0 && archiveCount > c.MaxCount {
+		return true
+	}
+
+	if c.MaxPercent > 0 && archiveCount > 1 && savedCount > 0 {
+		return archiveCount*100 > c.MaxPercent*savedCount
+	}
+
+	return false
+}
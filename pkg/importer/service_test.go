@@ -3,6 +3,9 @@ package importer
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
 	"testing"
 	"time"
 
@@ -19,12 +22,20 @@ import (
 
 func TestNewServiceConfig(t *testing.T) {
 	cfg := &importerconfig.Config{
-		MeetupGroupNames: []string{"Test1", "Test2"},
+		Groups:             groupConfigs("Test1", "Test2"),
+		ImportConcurrency:  4,
+		ArchiveMaxCount:    5,
+		ArchiveMaxPercent:  25,
+		ArchiveForceGroups: []string{"Test2"},
 	}
 
 	serviceConfig := NewServiceConfig(cfg)
 
-	assert.ElementsMatch(t, cfg.MeetupGroupNames, serviceConfig.GroupNames)
+	assert.Equal(t, cfg.Groups, serviceConfig.Groups)
+	assert.Equal(t, cfg.ImportConcurrency, serviceConfig.Concurrency)
+	assert.Equal(t, cfg.ArchiveMaxCount, serviceConfig.ArchiveGuard.MaxCount)
+	assert.Equal(t, cfg.ArchiveMaxPercent, serviceConfig.ArchiveGuard.MaxPercent)
+	assert.Equal(t, cfg.ArchiveForceGroups, serviceConfig.ArchiveGuard.ForceGroups)
 }
 
 type MockEventRepository struct {
@@ -39,6 +50,14 @@ func (m *MockEventRepository) GetUpcomingEventsForGroup(
 	return args.Get(0).([]models.MeetupEvent), args.Error(1)
 }
 
+func (m *MockEventRepository) UpsertArchivedEvents(
+	ctx context.Context,
+	events []models.MeetupEvent,
+) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
 func (m *MockEventRepository) ArchiveEvents(ctx context.Context, eventIds []string) error {
 	args := m.Called(ctx, eventIds)
 	return args.Error(0)
@@ -49,6 +68,15 @@ func (m *MockEventRepository) UpsertEvents(ctx context.Context, events []models.
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) RemoveCoHost(
+	ctx context.Context,
+	group string,
+	events []models.MeetupEvent,
+) error {
+	args := m.Called(ctx, group, events)
+	return args.Error(0)
+}
+
 type MockMeetupRepository struct {
 	mock.Mock
 }
@@ -57,9 +85,85 @@ func (m *MockMeetupRepository) GetEventsUntilDateForGroup(
 	ctx context.Context,
 	group string,
 	beforeDate time.Time,
-) ([]models.MeetupEvent, error) {
+) ([]models.MeetupEvent, int, error) {
 	args := m.Called(ctx, group, beforeDate)
-	return args.Get(0).([]models.MeetupEvent), args.Error(1)
+	return args.Get(0).([]models.MeetupEvent), args.Int(1), args.Error(2)
+}
+
+func (m *MockMeetupRepository) GetPastEventsPage(
+	ctx context.Context,
+	group string,
+	cursor string,
+) (*EventsPage, error) {
+	args := m.Called(ctx, group, cursor)
+	page, _ := args.Get(0).(*EventsPage)
+	return page, args.Error(1)
+}
+
+type MockImportRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportRunRepository) SaveImportRuns(
+	ctx context.Context,
+	runs []models.ImportRun,
+) error {
+	args := m.Called(ctx, runs)
+	return args.Error(0)
+}
+
+func newMockImportRunRepository() *MockImportRunRepository {
+	importRunRepo := new(MockImportRunRepository)
+	importRunRepo.On("SaveImportRuns", mock.Anything, mock.Anything).Return(nil)
+	return importRunRepo
+}
+
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) UpsertSeries(
+	ctx context.Context,
+	series []models.EventSeries,
+) error {
+	args := m.Called(ctx, series)
+	return args.Error(0)
+}
+
+func newMockSeriesRepository() *MockSeriesRepository {
+	seriesRepo := new(MockSeriesRepository)
+	seriesRepo.On("UpsertSeries", mock.Anything, mock.Anything).Return(nil)
+	return seriesRepo
+}
+
+type MockGroupVersionRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupVersionRepository) SaveGroupVersions(
+	ctx context.Context,
+	changed, unchanged []models.GroupVersion,
+) error {
+	args := m.Called(ctx, changed, unchanged)
+	return args.Error(0)
+}
+
+func newMockGroupVersionRepository() *MockGroupVersionRepository {
+	groupVersionRepo := new(MockGroupVersionRepository)
+	groupVersionRepo.On("SaveGroupVersions", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	return groupVersionRepo
+}
+
+func groupConfigs(names ...string) []importerconfig.GroupConfig {
+	groups := make([]importerconfig.GroupConfig, len(names))
+	for i, name := range names {
+		groups[i] = importerconfig.GroupConfig{
+			URLName: name,
+			Source:  importerconfig.GroupSourceMeetup,
+		}
+	}
+	return groups
 }
 
 func TestService_Import(t *testing.T) {
@@ -76,7 +180,7 @@ func TestService_Import(t *testing.T) {
 
 		for _, group := range groupNames {
 			meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
-				Return(meetupFaker.CreateEvents(group, 2), nil)
+				Return(meetupFaker.CreateEvents(group, 2), 1, nil)
 			eventRepo.On("GetUpcomingEventsForGroup", ctx, group).
 				Return(meetupFaker.CreateEvents(group, 1), nil)
 			eventRepo.On("UpsertEvents", ctx, mock.Anything).Return(nil)
@@ -84,11 +188,15 @@ func TestService_Import(t *testing.T) {
 		}
 
 		svc := NewService(
-			ServiceConfig{GroupNames: groupNames},
+			ServiceConfig{Groups: groupConfigs(groupNames...), Concurrency: 3},
 			mockTimeSource,
 			logging.NewMockLogger(),
 			eventRepo,
 			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
 		)
 
 		err := svc.Import(ctx)
@@ -108,18 +216,22 @@ func TestService_Import(t *testing.T) {
 		incomingEvents := savedEvents[1:]
 
 		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
-			Return(incomingEvents, nil)
+			Return(incomingEvents, 1, nil)
 		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).
 			Return(savedEvents, nil)
 		eventRepo.On("UpsertEvents", ctx, incomingEvents).Return(nil)
 		eventRepo.On("ArchiveEvents", ctx, []string{savedEvents[0].ID}).Return(nil)
 
 		svc := NewService(
-			ServiceConfig{GroupNames: []string{group}},
+			ServiceConfig{Groups: groupConfigs(group), Concurrency: 3},
 			mockTimeSource,
 			logging.NewMockLogger(),
 			eventRepo,
 			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
 		)
 
 		err := svc.Import(ctx)
@@ -133,7 +245,7 @@ func TestService_Import(t *testing.T) {
 		eventRepo := new(MockEventRepository)
 		meetupRepo := new(MockMeetupRepository)
 		group := "error-group"
-		cfg := ServiceConfig{GroupNames: []string{group}}
+		cfg := ServiceConfig{Groups: groupConfigs(group), Concurrency: 3}
 		expectedErr := errors.New("db error")
 
 		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).
@@ -145,6 +257,10 @@ func TestService_Import(t *testing.T) {
 			logging.NewMockLogger(),
 			eventRepo,
 			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
 		)
 
 		err := svc.Import(ctx)
@@ -160,10 +276,10 @@ func TestService_Import(t *testing.T) {
 		eventRepo := new(MockEventRepository)
 		meetupRepo := new(MockMeetupRepository)
 		group := "empty-group"
-		cfg := ServiceConfig{GroupNames: []string{group}}
+		cfg := ServiceConfig{Groups: groupConfigs(group), Concurrency: 3}
 
 		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
-			Return([]models.MeetupEvent{}, nil)
+			Return([]models.MeetupEvent{}, 1, nil)
 		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).
 			Return([]models.MeetupEvent{}, nil)
 		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
@@ -175,6 +291,10 @@ func TestService_Import(t *testing.T) {
 			logging.NewMockLogger(),
 			eventRepo,
 			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
 		)
 
 		err := svc.Import(ctx)
@@ -184,3 +304,1099 @@ func TestService_Import(t *testing.T) {
 		eventRepo.AssertNotCalled(t, "ArchiveEvents")
 	})
 }
+
+func TestService_Import_ArchiveGuard(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+	group := "guarded-group"
+
+	t.Run("skips archiving and logs error when threshold exceeded", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		logHandler := logging.NewMockHandler()
+
+		savedEvents := meetupFaker.CreateEvents(group, 4)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).Return(savedEvents, nil)
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+
+		svc := NewService(
+			ServiceConfig{
+				Groups:       groupConfigs(group),
+				Concurrency:  3,
+				ArchiveGuard: ArchiveGuardConfig{MaxCount: 10, MaxPercent: 50},
+			},
+			clock.NewMockTimeSource(now),
+			slog.New(logHandler),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+
+		eventRepo.AssertExpectations(t)
+		require.Len(t, logHandler.Entries(slog.LevelError), 1)
+		assert.Equal(t, group, logHandler.Entries(slog.LevelError)[0].Attrs["group"])
+	})
+
+	t.Run("records skipped archives as an anomaly on the runs", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+
+		savedEvents := meetupFaker.CreateEvents(group, 4)
+		savedEventIDs := make([]string, len(savedEvents))
+		for i, event := range savedEvents {
+			savedEventIDs[i] = event.ID
+		}
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).Return(savedEvents, nil)
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+
+		var runs []models.ImportRun
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { runs = args.Get(1).([]models.ImportRun) }).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{
+				Groups:       groupConfigs(group),
+				Concurrency:  3,
+				ArchiveGuard: ArchiveGuardConfig{MaxCount: 10, MaxPercent: 50},
+			},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+		require.Len(t, runs, 2)
+
+		for _, r := range runs {
+			assert.Zero(t, r.Archived)
+			require.Len(t, r.Anomalies, 1)
+			assert.Equal(t, models.ImportAnomalyArchiveSkipped, r.Anomalies[0].Type)
+			assert.ElementsMatch(t, savedEventIDs, r.Anomalies[0].EventIDs)
+		}
+	})
+
+	t.Run("archives when group is forced", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		savedEvents := meetupFaker.CreateEvents(group, 4)
+		savedIDs := make([]string, len(savedEvents))
+		for i, event := range savedEvents {
+			savedIDs[i] = event.ID
+		}
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).Return(savedEvents, nil)
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, savedIDs).Return(nil)
+
+		svc := NewService(
+			ServiceConfig{
+				Groups:      groupConfigs(group),
+				Concurrency: 3,
+				ArchiveGuard: ArchiveGuardConfig{
+					MaxCount:    1,
+					MaxPercent:  10,
+					ForceGroups: []string{group},
+				},
+			},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+
+		eventRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_Import_ImportRuns(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+	group := "tracked-group"
+
+	t.Run("saves group and invocation runs with counts", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+
+		savedEvents := meetupFaker.CreateEvents(group, 3)
+		changedEvent := savedEvents[2]
+		changedEvent.Title = "Changed title"
+		incomingEvents := []models.MeetupEvent{
+			savedEvents[1],
+			changedEvent,
+			meetupFaker.CreateEvent(group, now.AddDate(0, 1, 0)),
+		}
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
+			Return(incomingEvents, 2, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).Return(savedEvents, nil)
+		eventRepo.On("UpsertEvents", ctx, incomingEvents).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{savedEvents[0].ID}).Return(nil)
+
+		var runs []models.ImportRun
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { runs = args.Get(1).([]models.ImportRun) }).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(group), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+		require.Len(t, runs, 2)
+
+		groupRun, run := runs[0], runs[1]
+		assert.Equal(t, group, groupRun.Scope)
+		assert.Equal(t, models.ImportRunScopeAll, run.Scope)
+		assert.Equal(t, run.RunID, groupRun.RunID)
+		assert.Equal(t, []string{group}, run.Groups)
+
+		for _, r := range runs {
+			assert.Equal(t, 3, r.Fetched)
+			assert.Equal(t, 2, r.Upserted)
+			assert.Equal(t, 1, r.Unchanged)
+			assert.Equal(t, 1, r.Archived)
+			assert.Equal(t, 2, r.MeetupPages)
+			assert.True(t, r.Succeeded())
+			assert.Equal(t, now.UTC(), r.StartedAt)
+			assert.Equal(t, now.UTC(), r.EndedAt)
+		}
+	})
+
+	t.Run("records errors on the run", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+		expectedErr := errors.New("meetup unavailable")
+
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).
+			Return([]models.MeetupEvent{}, nil)
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent(nil), 1, expectedErr)
+
+		var runs []models.ImportRun
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { runs = args.Get(1).([]models.ImportRun) }).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(group), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		assert.ErrorIs(t, svc.Import(ctx), expectedErr)
+		require.Len(t, runs, 2)
+
+		for _, r := range runs {
+			assert.False(t, r.Succeeded())
+			assert.Contains(t, r.Error, expectedErr.Error())
+			assert.Equal(t, 1, r.MeetupPages)
+		}
+	})
+
+	t.Run("reports an unavailable upstream once", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+		groups := []string{"group-a", "group-b", "group-c"}
+		unavailableErr := fmt.Errorf("%w: circuit breaker is open", ErrMeetupUnavailable)
+
+		for _, g := range groups {
+			eventRepo.On("GetUpcomingEventsForGroup", ctx, g).
+				Return([]models.MeetupEvent{}, nil)
+			meetupRepo.On("GetEventsUntilDateForGroup", ctx, g, now.AddDate(0, 6, 0)).
+				Return([]models.MeetupEvent(nil), 0, unavailableErr)
+		}
+
+		var runs []models.ImportRun
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { runs = args.Get(1).([]models.ImportRun) }).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(groups...), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		err := svc.Import(ctx)
+		assert.ErrorIs(t, err, ErrMeetupUnavailable)
+		assert.EqualError(t, err, "meetup upstream unavailable for group-a, group-b, group-c")
+
+		require.Len(t, runs, 4)
+		assert.Equal(t, err.Error(), runs[3].Error)
+		for _, r := range runs[:3] {
+			assert.Contains(t, r.Error, "circuit breaker is open")
+		}
+	})
+
+	t.Run("logs but does not fail when saving runs fails", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+		logHandler := logging.NewMockHandler()
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, group, now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, group).
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).Return(errors.New("db error"))
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(group), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			slog.New(logHandler),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+		assert.Len(t, logHandler.Entries(slog.LevelError), 1)
+	})
+}
+
+func TestService_Import_GroupVersions(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+
+	t.Run("bumps changed groups and their co-hosts", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		groupVersionRepo := new(MockGroupVersionRepository)
+
+		coHostedEvent := meetupFaker.CreateEvent("group1", now.Add(time.Hour))
+		coHostedEvent.AddCoHost("group3")
+		changedEvent := coHostedEvent
+		changedEvent.GroupIDs = nil
+		changedEvent.Title = "Changed title"
+		unchangedEvent := meetupFaker.CreateEvent("group2", now.Add(time.Hour))
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{changedEvent}, 1, nil)
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group2", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{unchangedEvent}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{coHostedEvent}, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group2").
+			Return([]models.MeetupEvent{unchangedEvent}, nil)
+		eventRepo.On("UpsertEvents", ctx, mock.Anything).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, mock.Anything).Return(nil)
+
+		var changed, unchanged []models.GroupVersion
+		groupVersionRepo.On("SaveGroupVersions", ctx, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				changed = args.Get(1).([]models.GroupVersion)
+				unchanged = args.Get(2).([]models.GroupVersion)
+			}).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs("group1", "group2"), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			groupVersionRepo,
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+
+		require.Len(t, changed, 2)
+		require.Len(t, unchanged, 1)
+		assert.Equal(t, "group1", changed[0].GroupID)
+		assert.Equal(t, "group3", changed[1].GroupID, "co-hosts get a new version")
+		assert.Equal(t, "group2", unchanged[0].GroupID)
+
+		for _, version := range slices.Concat(changed, unchanged) {
+			assert.Equal(t, changed[0].Version, version.Version)
+			assert.Equal(t, now.UTC(), version.UpdatedAt)
+		}
+		assert.NotEmpty(t, changed[0].Version)
+	})
+
+	t.Run("failed groups keep their version", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		groupVersionRepo := new(MockGroupVersionRepository)
+
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, errors.New("db error"))
+		groupVersionRepo.On("SaveGroupVersions", ctx, []models.GroupVersion{}, mock.Anything).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs("group1"), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			new(MockMeetupRepository),
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			groupVersionRepo,
+			nil,
+		)
+
+		require.Error(t, svc.Import(ctx))
+
+		groupVersionRepo.AssertExpectations(t)
+		assert.Empty(t, groupVersionRepo.Calls[0].Arguments.Get(2))
+	})
+
+	t.Run("dry run doesn't save versions", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		groupVersionRepo := new(MockGroupVersionRepository)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return(meetupFaker.CreateEvents("group1", 1), 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs("group1"), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			groupVersionRepo,
+			nil,
+		)
+
+		_, err := svc.ImportWithOptions(ctx, ImportOptions{DryRun: true})
+		require.NoError(t, err)
+
+		groupVersionRepo.AssertNotCalled(t, "SaveGroupVersions", mock.Anything, mock.Anything,
+			mock.Anything)
+	})
+}
+
+func TestService_ImportWithOptions(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+	groupNames := []string{"group1", "group2"}
+
+	t.Run("imports only requested groups", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group2", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group2").
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(groupNames...), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		_, err := svc.ImportWithOptions(ctx, ImportOptions{Groups: []string{"group2"}})
+		require.NoError(t, err)
+
+		meetupRepo.AssertNumberOfCalls(t, "GetEventsUntilDateForGroup", 1)
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects groups that are not configured", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+
+		var runs []models.ImportRun
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { runs = args.Get(1).([]models.ImportRun) }).
+			Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(groupNames...), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		_, err := svc.ImportWithOptions(ctx, ImportOptions{Groups: []string{"unknown"}})
+
+		assert.ErrorIs(t, err, ErrUnknownGroup)
+		meetupRepo.AssertNotCalled(t, "GetEventsUntilDateForGroup")
+
+		require.Len(t, runs, 1, "the rejected import is saved so it shows in the history")
+		assert.Equal(t, models.ImportRunScopeAll, runs[0].Scope)
+		assert.Equal(t, []string{"unknown"}, runs[0].Groups)
+		assert.Equal(t, err.Error(), runs[0].Error)
+		assert.False(t, runs[0].Succeeded())
+	})
+
+	t.Run("dry run doesn't save rejected imports", func(t *testing.T) {
+		importRunRepo := new(MockImportRunRepository)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(groupNames...), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			new(MockEventRepository),
+			new(MockMeetupRepository),
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		_, err := svc.ImportWithOptions(ctx, ImportOptions{
+			Groups: []string{"unknown"},
+			DryRun: true,
+		})
+
+		assert.ErrorIs(t, err, ErrUnknownGroup)
+		importRunRepo.AssertNotCalled(t, "SaveImportRuns")
+	})
+
+	t.Run("dry run reports changes without writing", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		importRunRepo := new(MockImportRunRepository)
+
+		savedEvents := meetupFaker.CreateEvents("group1", 2)
+		updatedEvent := savedEvents[1]
+		updatedEvent.Title = "Updated title"
+		newEvent := meetupFaker.CreateEvent("group1", now.AddDate(0, 1, 0))
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{updatedEvent, newEvent}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").Return(savedEvents, nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs(groupNames...), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		report, err := svc.ImportWithOptions(ctx, ImportOptions{
+			Groups: []string{"group1"},
+			DryRun: true,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Groups, 1)
+		groupReport := report.Groups[0]
+
+		require.Len(t, groupReport.Inserts, 1)
+		assert.Equal(t, newEvent.ID, groupReport.Inserts[0].ID)
+
+		require.Len(t, groupReport.Updates, 1)
+		assert.Equal(t, updatedEvent.ID, groupReport.Updates[0].ID)
+		assert.Equal(t, []FieldDiff{{
+			Field:  "title",
+			Before: savedEvents[1].Title,
+			After:  "Updated title",
+		}}, groupReport.Updates[0].Fields)
+
+		require.Len(t, groupReport.Archives, 1)
+		assert.Equal(t, savedEvents[0].ID, groupReport.Archives[0].ID)
+		assert.False(t, groupReport.ArchiveSkipped)
+
+		eventRepo.AssertNotCalled(t, "UpsertEvents", mock.Anything, mock.Anything)
+		eventRepo.AssertNotCalled(t, "ArchiveEvents", mock.Anything, mock.Anything)
+		importRunRepo.AssertNotCalled(t, "SaveImportRuns", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run reports archives blocked by the guard", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		savedEvents := meetupFaker.CreateEvents("group1", 3)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").Return(savedEvents, nil)
+
+		svc := NewService(
+			ServiceConfig{
+				Groups:       groupConfigs(groupNames...),
+				Concurrency:  3,
+				ArchiveGuard: ArchiveGuardConfig{MaxCount: 1},
+			},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		report, err := svc.ImportWithOptions(ctx, ImportOptions{
+			Groups: []string{"group1"},
+			DryRun: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, report.Groups, 1)
+		assert.True(t, report.Groups[0].ArchiveSkipped)
+		assert.Len(t, report.Groups[0].Archives, 3)
+	})
+}
+
+func TestService_ImportGroupConfig(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+
+	t.Run("skips disabled groups", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, mock.Anything).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, mock.Anything).Return(nil)
+
+		groups := groupConfigs("group1", "group2")
+		groups[1].Disabled = true
+
+		svc := NewService(
+			ServiceConfig{Groups: groups, Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		report, err := svc.ImportWithOptions(ctx, ImportOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, report.Groups, 1)
+		assert.Equal(t, "group1", report.Groups[0].Group)
+
+		_, err = svc.ImportWithOptions(ctx, ImportOptions{Groups: []string{"group2"}})
+		assert.ErrorIs(t, err, ErrGroupDisabled)
+	})
+
+	t.Run("uses the group's horizon", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 0, 30)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, mock.Anything).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, mock.Anything).Return(nil)
+
+		groups := groupConfigs("group1")
+		groups[0].HorizonDays = 30
+
+		svc := NewService(
+			ServiceConfig{Groups: groups, Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+		meetupRepo.AssertExpectations(t)
+	})
+
+	t.Run("applies display name and tags to events", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		incomingEvents := meetupFaker.CreateEvents("group1", 2)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return(incomingEvents, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, mock.MatchedBy(func(events []models.MeetupEvent) bool {
+			for _, event := range events {
+				if event.GroupName != "Group One" || !slices.Equal(event.Tags, []string{"tech"}) {
+					return false
+				}
+			}
+			return len(events) == 2
+		})).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, mock.Anything).Return(nil)
+
+		groups := groupConfigs("group1")
+		groups[0].DisplayName = "Group One"
+		groups[0].Tags = []string{"tech"}
+
+		svc := NewService(
+			ServiceConfig{Groups: groups, Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("backfills a few pages of past events into the archive", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		checkpointRepo := new(MockBackfillCheckpointRepository)
+
+		upcomingEvents := meetupFaker.CreateEvents("group1", 1)
+		pastEvents := []models.MeetupEvent{
+			meetupFaker.CreateEvent("group1", now.AddDate(0, -2, 0)),
+			meetupFaker.CreateEvent("group1", now.AddDate(0, -1, 0)),
+		}
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return(upcomingEvents, 1, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "cursor-1").
+			Return(&EventsPage{Events: pastEvents, EndCursor: "cursor-2", HasNextPage: true}, nil)
+		meetupRepo.On("GetPastEventsPage", ctx, "group1", "cursor-2").
+			Return(&EventsPage{Events: pastEvents, EndCursor: "cursor-3", HasNextPage: true}, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").Return(upcomingEvents, nil)
+		eventRepo.On("UpsertEvents", ctx, upcomingEvents).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+		eventRepo.On("UpsertArchivedEvents", ctx, pastEvents).Return(nil)
+		checkpointRepo.On("GetCheckpoint", ctx, "group1").Return(&models.BackfillCheckpoint{
+			GroupID:   "group1",
+			Cursor:    "cursor-1",
+			Completed: true,
+		}, nil)
+		checkpointRepo.On("SaveCheckpoint", ctx, mock.Anything).Return(nil)
+
+		groups := groupConfigs("group1")
+		groups[0].ImportPastEvents = true
+		config := ServiceConfig{Groups: groups, Concurrency: 3}
+		timeSource := clock.NewMockTimeSource(now)
+		importRunRepo := new(MockImportRunRepository)
+
+		var runs []models.ImportRun
+		importRunRepo.On("SaveImportRuns", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { runs = args.Get(1).([]models.ImportRun) }).
+			Return(nil)
+
+		svc := NewService(
+			config,
+			timeSource,
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			importRunRepo,
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			NewBackfiller(
+				config,
+				timeSource,
+				logging.NewMockLogger(),
+				eventRepo,
+				meetupRepo,
+				checkpointRepo,
+			),
+		)
+
+		report, err := svc.ImportWithOptions(ctx, ImportOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, report.Groups, 1)
+		assert.Empty(t, report.Groups[0].Inserts)
+		assert.Empty(t, report.Groups[0].Archives)
+		meetupRepo.AssertNumberOfCalls(t, "GetPastEventsPage", pastEventPagesPerImport)
+		eventRepo.AssertNumberOfCalls(t, "UpsertArchivedEvents", pastEventPagesPerImport)
+		checkpointRepo.AssertNumberOfCalls(t, "SaveCheckpoint", pastEventPagesPerImport)
+		require.Len(t, runs, 2)
+		for _, r := range runs {
+			assert.Equal(t, 1+pastEventPagesPerImport, r.MeetupPages)
+		}
+	})
+
+	t.Run("doesn't backfill past events in a dry run", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		upcomingEvents := meetupFaker.CreateEvents("group1", 1)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return(upcomingEvents, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").Return(upcomingEvents, nil)
+
+		groups := groupConfigs("group1")
+		groups[0].ImportPastEvents = true
+
+		svc := NewService(
+			ServiceConfig{Groups: groups, Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		_, err := svc.ImportWithOptions(ctx, ImportOptions{DryRun: true})
+		require.NoError(t, err)
+		meetupRepo.AssertNotCalled(
+			t,
+			"GetPastEventsPage",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		)
+	})
+}
+
+func TestService_ImportSeries(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+
+	newSeriesEvent := func(dateTime time.Time) models.MeetupEvent {
+		event := meetupFaker.CreateEvent("group1", dateTime)
+		event.Title = "Code & Demo Night"
+		event.Venue = &models.MeetupVenue{Name: "efactory"}
+		return event
+	}
+
+	t.Run("assigns series and saves them", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		seriesRepo := new(MockSeriesRepository)
+
+		incomingEvents := []models.MeetupEvent{
+			newSeriesEvent(now.AddDate(0, 0, 7)),
+			newSeriesEvent(now.AddDate(0, 0, 35)),
+		}
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return(incomingEvents, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, mock.MatchedBy(func(events []models.MeetupEvent) bool {
+			return events[0].SeriesID != "" && events[0].SeriesID == events[1].SeriesID
+		})).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, mock.Anything).Return(nil)
+		seriesRepo.On("UpsertSeries", ctx, mock.MatchedBy(func(series []models.EventSeries) bool {
+			return len(series) == 1 && series[0].SeriesID == incomingEvents[0].SeriesID
+		})).Return(nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs("group1"), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			seriesRepo,
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		require.NoError(t, svc.Import(ctx))
+		eventRepo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run doesn't save series", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+		seriesRepo := new(MockSeriesRepository)
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{
+				newSeriesEvent(now.AddDate(0, 0, 7)),
+				newSeriesEvent(now.AddDate(0, 0, 35)),
+			}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{}, nil)
+
+		svc := NewService(
+			ServiceConfig{Groups: groupConfigs("group1"), Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			seriesRepo,
+			newMockGroupVersionRepository(),
+			nil,
+		)
+
+		_, err := svc.ImportWithOptions(ctx, ImportOptions{DryRun: true})
+		require.NoError(t, err)
+
+		seriesRepo.AssertNotCalled(t, "UpsertSeries", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_ImportCoHostedEvents(t *testing.T) {
+	ctx := context.Background()
+	meetupFaker := fakers.NewMeetupFaker(0)
+	now := time.Now()
+	groups := []importerconfig.GroupConfig{
+		{URLName: "group1", Source: importerconfig.GroupSourceMeetup, DisplayName: "Group One"},
+		{URLName: "group2", Source: importerconfig.GroupSourceMeetup, DisplayName: "Group Two"},
+	}
+
+	newService := func(eventRepo *MockEventRepository, meetupRepo *MockMeetupRepository) *Service {
+		return NewService(
+			ServiceConfig{Groups: groups, Concurrency: 3},
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+			eventRepo,
+			meetupRepo,
+			newMockImportRunRepository(),
+			newMockSeriesRepository(),
+			newMockGroupVersionRepository(),
+			nil,
+		)
+	}
+
+	t.Run("records the co-host and keeps the owner's config", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		event := meetupFaker.CreateEvent("group1", now.Add(time.Hour))
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group2", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{event}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group2").
+			Return([]models.MeetupEvent{}, nil)
+		eventRepo.On("UpsertEvents", ctx, mock.MatchedBy(func(events []models.MeetupEvent) bool {
+			return len(events) == 1 &&
+				events[0].GroupID == "group1" &&
+				events[0].GroupName == "Group One" &&
+				slices.Equal(events[0].GroupIDs, []string{"group1", "group2"})
+		})).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+
+		_, err := newService(eventRepo, meetupRepo).
+			ImportWithOptions(ctx, ImportOptions{Groups: []string{"group2"}})
+		require.NoError(t, err)
+
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps co-hosts recorded by other imports", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		savedEvent := meetupFaker.CreateEvent("group1", now.Add(time.Hour))
+		savedEvent.GroupName = "Group One"
+		savedEvent.AddCoHost("group2")
+		incomingEvent := savedEvent
+		incomingEvent.GroupIDs = nil
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group1", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{incomingEvent}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group1").
+			Return([]models.MeetupEvent{savedEvent}, nil)
+		// The repository keeps the stored co-hosts, so the import only writes its own.
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{incomingEvent}).Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+
+		report, err := newService(eventRepo, meetupRepo).
+			ImportWithOptions(ctx, ImportOptions{Groups: []string{"group1"}})
+		require.NoError(t, err)
+
+		eventRepo.AssertExpectations(t)
+		assert.Empty(t, report.Groups[0].Updates)
+	})
+
+	t.Run("removes a co-host rather than archiving the event", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		meetupRepo := new(MockMeetupRepository)
+
+		savedEvent := meetupFaker.CreateEvent("group1", now.Add(time.Hour))
+		savedEvent.AddCoHost("group2")
+
+		meetupRepo.On("GetEventsUntilDateForGroup", ctx, "group2", now.AddDate(0, 6, 0)).
+			Return([]models.MeetupEvent{}, 1, nil)
+		eventRepo.On("GetUpcomingEventsForGroup", ctx, "group2").
+			Return([]models.MeetupEvent{savedEvent}, nil)
+		eventRepo.On("UpsertEvents", ctx, []models.MeetupEvent{}).Return(nil)
+		eventRepo.On("RemoveCoHost", ctx, "group2", []models.MeetupEvent{savedEvent}).
+			Return(nil)
+		eventRepo.On("ArchiveEvents", ctx, []string{}).Return(nil)
+
+		report, err := newService(eventRepo, meetupRepo).
+			ImportWithOptions(ctx, ImportOptions{Groups: []string{"group2"}})
+		require.NoError(t, err)
+
+		eventRepo.AssertExpectations(t)
+		require.Len(t, report.Groups[0].Updates, 1)
+		assert.Equal(t, "groupIds", report.Groups[0].Updates[0].Fields[0].Field)
+		assert.Empty(t, report.Groups[0].Archives)
+	})
+}
+
+func TestArchiveGuardConfig_blocks(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       ArchiveGuardConfig
+		group        string
+		savedCount   int
+		archiveCount int
+		expected     bool
+	}{
+		{
+			name:         "nothing to archive",
+			config:       ArchiveGuardConfig{MaxCount: 1, MaxPercent: 1},
+			savedCount:   10,
+			archiveCount: 0,
+			expected:     false,
+		},
+		{
+			name:         "under both thresholds",
+			config:       ArchiveGuardConfig{MaxCount: 5, MaxPercent: 50},
+			savedCount:   10,
+			archiveCount: 3,
+			expected:     false,
+		},
+		{
+			name:         "over max count",
+			config:       ArchiveGuardConfig{MaxCount: 5, MaxPercent: 100},
+			savedCount:   20,
+			archiveCount: 6,
+			expected:     true,
+		},
+		{
+			name:         "over max percent",
+			config:       ArchiveGuardConfig{MaxCount: 10, MaxPercent: 50},
+			savedCount:   4,
+			archiveCount: 3,
+			expected:     true,
+		},
+		{
+			name:         "exactly max percent",
+			config:       ArchiveGuardConfig{MaxCount: 10, MaxPercent: 50},
+			savedCount:   4,
+			archiveCount: 2,
+			expected:     false,
+		},
+		{
+			name:         "single event ignores percent",
+			config:       ArchiveGuardConfig{MaxCount: 10, MaxPercent: 50},
+			savedCount:   1,
+			archiveCount: 1,
+			expected:     false,
+		},
+		{
+			name:         "zero thresholds disable guard",
+			config:       ArchiveGuardConfig{},
+			savedCount:   100,
+			archiveCount: 100,
+			expected:     false,
+		},
+		{
+			name:         "forced group bypasses guard",
+			config:       ArchiveGuardConfig{MaxCount: 1, ForceGroups: []string{"forced"}},
+			group:        "forced",
+			savedCount:   10,
+			archiveCount: 10,
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.blocks(tt.group, tt.savedCount, tt.archiveCount))
+		})
+	}
+}
@@ -29,7 +29,24 @@ func InitService(ctx context.Context) (*Service, error) {
 		EventRepositoryProviders,
 		GraphQLHandlerProviders,
 		MeetupRepositoryProviders,
+		ImportRunRepositoryProviders,
+		SeriesRepositoryProviders,
+		GroupVersionRepositoryProviders,
+		BackfillCheckpointRepositoryProviders,
 		NewServiceConfig,
+		NewBackfiller,
 		NewService,
 	))
 }
+
+func InitBackfiller(ctx context.Context) (*Backfiller, error) {
+	panic(wire.Build(
+		CommonProviders,
+		EventRepositoryProviders,
+		GraphQLHandlerProviders,
+		MeetupRepositoryProviders,
+		BackfillCheckpointRepositoryProviders,
+		NewServiceConfig,
+		NewBackfiller,
+	))
+}
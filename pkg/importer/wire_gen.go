@@ -38,13 +38,56 @@ func InitService(ctx context.Context) (*Service, error) {
 		return nil, err
 	}
 	dynamoDBEventRepository := NewDynamoDBEventRepository(dynamoDBEventRepositoryConfig, client, realTimeSource, logger)
-	lambdaProxyGraphQLHandlerConfig := NewLambdaProxyGraphQLHandlerConfig(config)
-	lambdaProxyGraphQLHandler := NewLambdaProxyGraphQLHandler(lambdaProxyGraphQLHandlerConfig, logger)
-	graphQLMeetupRepository := NewGraphQLMeetupRepository(lambdaProxyGraphQLHandler, logger)
-	service := NewService(serviceConfig, realTimeSource, logger, dynamoDBEventRepository, graphQLMeetupRepository)
+	httpClient := httpclient.DefaultClient(realTimeSource, logger)
+	graphQLHandler, err := NewGraphQLHandler(ctx, config, httpClient, realTimeSource, logger)
+	if err != nil {
+		return nil, err
+	}
+	graphQLMeetupRepository := NewGraphQLMeetupRepository(graphQLHandler, logger)
+	dynamoDBImportRunRepositoryConfig := NewDynamoDBImportRunRepositoryConfig(config)
+	dynamoDBImportRunRepository := NewDynamoDBImportRunRepository(dynamoDBImportRunRepositoryConfig, client)
+	dynamoDBSeriesRepositoryConfig := NewDynamoDBSeriesRepositoryConfig(config)
+	dynamoDBSeriesRepository := NewDynamoDBSeriesRepository(dynamoDBSeriesRepositoryConfig, client)
+	dynamoDBGroupVersionRepositoryConfig := NewDynamoDBGroupVersionRepositoryConfig(config)
+	dynamoDBGroupVersionRepository := NewDynamoDBGroupVersionRepository(dynamoDBGroupVersionRepositoryConfig, client)
+	dynamoDBBackfillCheckpointRepositoryConfig := NewDynamoDBBackfillCheckpointRepositoryConfig(config)
+	dynamoDBBackfillCheckpointRepository := NewDynamoDBBackfillCheckpointRepository(dynamoDBBackfillCheckpointRepositoryConfig, client)
+	backfiller := NewBackfiller(serviceConfig, realTimeSource, logger, dynamoDBEventRepository, graphQLMeetupRepository, dynamoDBBackfillCheckpointRepository)
+	service := NewService(serviceConfig, realTimeSource, logger, dynamoDBEventRepository, graphQLMeetupRepository, dynamoDBImportRunRepository, dynamoDBSeriesRepository, dynamoDBGroupVersionRepository, backfiller)
 	return service, nil
 }
 
+func InitBackfiller(ctx context.Context) (*Backfiller, error) {
+	awsConfigManagerImpl := appconfig.NewAwsConfigManager()
+	config, err := importerconfig.NewConfig(ctx, awsConfigManagerImpl)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig := NewServiceConfig(config)
+	realTimeSource := clock.NewRealTimeSource()
+	common := config.Common
+	loggingConfig := common.Logging
+	logger := logging.DefaultLogger(ctx, loggingConfig)
+	dynamoDBEventRepositoryConfig := NewDynamoDBEventRepositoryConfig(config)
+	dbConfig := common.DynamoDB
+	awsConfig := appconfig.AwsConfigProvider(awsConfigManagerImpl)
+	client, err := db.NewClient(ctx, dbConfig, awsConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	dynamoDBEventRepository := NewDynamoDBEventRepository(dynamoDBEventRepositoryConfig, client, realTimeSource, logger)
+	httpClient := httpclient.DefaultClient(realTimeSource, logger)
+	graphQLHandler, err := NewGraphQLHandler(ctx, config, httpClient, realTimeSource, logger)
+	if err != nil {
+		return nil, err
+	}
+	graphQLMeetupRepository := NewGraphQLMeetupRepository(graphQLHandler, logger)
+	dynamoDBBackfillCheckpointRepositoryConfig := NewDynamoDBBackfillCheckpointRepositoryConfig(config)
+	dynamoDBBackfillCheckpointRepository := NewDynamoDBBackfillCheckpointRepository(dynamoDBBackfillCheckpointRepositoryConfig, client)
+	backfiller := NewBackfiller(serviceConfig, realTimeSource, logger, dynamoDBEventRepository, graphQLMeetupRepository, dynamoDBBackfillCheckpointRepository)
+	return backfiller, nil
+}
+
 // wire.go:
 
 var CommonProviders = wire.NewSet(importerconfig.ConfigProviders, logging.DefaultLogger, clock.RealClockProvider, httpclient.DefaultClient, db.Providers)
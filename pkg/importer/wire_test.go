@@ -14,8 +14,29 @@ func TestInitService(t *testing.T) {
 	t.Setenv("ARCHIVED_EVENTS_TABLE_NAME", "archived-events")
 	t.Setenv("EVENTS_TABLE_NAME", "events")
 	t.Setenv("GROUP_ID_DATE_TIME_INDEX_NAME", "group-index")
+	t.Setenv("IMPORT_RUNS_TABLE_NAME", "import-runs")
+	t.Setenv("BACKFILL_CHECKPOINTS_TABLE_NAME", "backfill-checkpoints")
+	t.Setenv("EVENT_SERIES_TABLE_NAME", "event-series")
+	t.Setenv("GROUP_VERSIONS_TABLE_NAME", "group-versions")
 
 	_, err := InitService(ctx)
 
 	require.NoError(t, err)
 }
+
+func TestInitBackfiller(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("MEETUP_PROXY_FUNCTION_NAME", "meetupproxy")
+	t.Setenv("ARCHIVED_EVENTS_TABLE_NAME", "archived-events")
+	t.Setenv("EVENTS_TABLE_NAME", "events")
+	t.Setenv("GROUP_ID_DATE_TIME_INDEX_NAME", "group-index")
+	t.Setenv("IMPORT_RUNS_TABLE_NAME", "import-runs")
+	t.Setenv("BACKFILL_CHECKPOINTS_TABLE_NAME", "backfill-checkpoints")
+	t.Setenv("EVENT_SERIES_TABLE_NAME", "event-series")
+	t.Setenv("GROUP_VERSIONS_TABLE_NAME", "group-versions")
+
+	_, err := InitBackfiller(ctx)
+
+	require.NoError(t, err)
+}
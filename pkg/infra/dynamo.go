@@ -20,6 +20,20 @@ var GroupIdDateTimeIndex = awsdynamodb.GlobalSecondaryIndexProps{
 	},
 }
 
+// SeriesIdDateTimeIndex only holds events assigned to a series since seriesId is omitted
+// from the others.
+var SeriesIdDateTimeIndex = awsdynamodb.GlobalSecondaryIndexProps{
+	IndexName: jsii.String("SeriesIdDateTimeIndex"),
+	PartitionKey: &awsdynamodb.Attribute{
+		Name: jsii.String("seriesId"),
+		Type: awsdynamodb.AttributeType_STRING,
+	},
+	SortKey: &awsdynamodb.Attribute{
+		Name: jsii.String("dateTime"),
+		Type: awsdynamodb.AttributeType_STRING,
+	},
+}
+
 var EventsTableProps = &customconstructs.DynamoTableProps{
 	TableProps: awsdynamodb.TableProps{
 		TableName: jsii.String("MeetupEvents"),
@@ -32,6 +46,7 @@ var EventsTableProps = &customconstructs.DynamoTableProps{
 	},
 	GlobalSecondaryIndexes: []awsdynamodb.GlobalSecondaryIndexProps{
 		GroupIdDateTimeIndex,
+		SeriesIdDateTimeIndex,
 	},
 }
 
@@ -62,8 +77,99 @@ var ApiUsersTableProps = &customconstructs.DynamoTableProps{
 	},
 }
 
+var ImportRunsTableProps = &customconstructs.DynamoTableProps{
+	TableProps: awsdynamodb.TableProps{
+		TableName: jsii.String("MeetupImportRuns"),
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("scope"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		SortKey: &awsdynamodb.Attribute{
+			Name: jsii.String("runId"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		RemovalPolicy: awscdk.RemovalPolicy_DESTROY,
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+	},
+}
+
+var BackfillCheckpointsTableProps = &customconstructs.DynamoTableProps{
+	TableProps: awsdynamodb.TableProps{
+		TableName: jsii.String("MeetupBackfillCheckpoints"),
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("groupId"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		RemovalPolicy: awscdk.RemovalPolicy_DESTROY,
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+	},
+}
+
+var EventSeriesTableProps = &customconstructs.DynamoTableProps{
+	TableProps: awsdynamodb.TableProps{
+		TableName: jsii.String("MeetupEventSeries"),
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("seriesId"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		RemovalPolicy: awscdk.RemovalPolicy_DESTROY,
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+	},
+}
+
+// GroupVersionsTableProps holds one models.GroupVersion per group, read by the API on every
+// events request to answer conditional requests.
+var GroupVersionsTableProps = &customconstructs.DynamoTableProps{
+	TableProps: awsdynamodb.TableProps{
+		TableName: jsii.String("MeetupGroupVersions"),
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("groupId"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		RemovalPolicy: awscdk.RemovalPolicy_DESTROY,
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+	},
+}
+
+// ResponseCacheTableProps holds cached Meetup proxy responses, which DynamoDB deletes once
+// they pass expiresAt.
+var ResponseCacheTableProps = &customconstructs.DynamoTableProps{
+	TableProps: awsdynamodb.TableProps{
+		TableName: jsii.String("MeetupProxyResponseCache"),
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("cacheKey"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		TimeToLiveAttribute: jsii.String("expiresAt"),
+		RemovalPolicy:       awscdk.RemovalPolicy_DESTROY,
+		BillingMode:         awsdynamodb.BillingMode_PAY_PER_REQUEST,
+	},
+}
+
+// TokenStoreTableProps holds the Meetup proxy's OAuth tokens, encrypted with a KMS key rather
+// than the default AWS owned one. The token values are also encrypted by the proxy with the
+// stack's token key, so reading the table isn't enough to use them.
+var TokenStoreTableProps = &customconstructs.DynamoTableProps{
+	TableProps: awsdynamodb.TableProps{
+		TableName: jsii.String("MeetupProxyTokens"),
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("tokenId"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		Encryption:    awsdynamodb.TableEncryption_AWS_MANAGED,
+		RemovalPolicy: awscdk.RemovalPolicy_DESTROY,
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+	},
+}
+
 var Tables = []customconstructs.DynamoTableProps{
 	*EventsTableProps,
 	*ArchivedEventsTableProps,
 	*ApiUsersTableProps,
+	*ImportRunsTableProps,
+	*BackfillCheckpointsTableProps,
+	*EventSeriesTableProps,
+	*GroupVersionsTableProps,
+	*ResponseCacheTableProps,
+	*TokenStoreTableProps,
 }
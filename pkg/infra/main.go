@@ -12,6 +12,8 @@ import (
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsevents"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awseventstargets"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awskms"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awslogs"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsssm"
 	"github.com/aws/constructs-go/constructs/v10"
 	"github.com/aws/jsii-runtime-go"
@@ -35,6 +37,30 @@ func NewStack(scope constructs.Construct, id string, props *AppStackProps) awscd
 		ArchivedEventsTableProps,
 	)
 	apiUsersTable := customconstructs.NewDynamoTable(stack, props.AppEnv, ApiUsersTableProps)
+	importRunsTable := customconstructs.NewDynamoTable(stack, props.AppEnv, ImportRunsTableProps)
+	backfillCheckpointsTable := customconstructs.NewDynamoTable(
+		stack,
+		props.AppEnv,
+		BackfillCheckpointsTableProps,
+	)
+	eventSeriesTable := customconstructs.NewDynamoTable(stack, props.AppEnv, EventSeriesTableProps)
+	groupVersionsTable := customconstructs.NewDynamoTable(
+		stack,
+		props.AppEnv,
+		GroupVersionsTableProps,
+	)
+	responseCacheTable := customconstructs.NewDynamoTable(
+		stack,
+		props.AppEnv,
+		ResponseCacheTableProps,
+	)
+	tokenStoreTable := customconstructs.NewDynamoTable(stack, props.AppEnv, TokenStoreTableProps)
+	tokenKeyName := resource.NewNamer(stackName.FullName(), "MeetupProxyTokenKey")
+	tokenKey := awskms.NewKey(stack, jsii.String(tokenKeyName.Name()), &awskms.KeyProps{
+		Description:       jsii.String("Encrypts the Meetup proxy's stored OAuth tokens"),
+		EnableKeyRotation: jsii.Bool(true),
+		RemovalPolicy:     awscdk.RemovalPolicy_DESTROY,
+	})
 
 	commonEnvVars := map[string]*string{
 		"LOG_LEVEL":                      jsii.String("debug"),
@@ -55,15 +81,19 @@ func NewStack(scope constructs.Construct, id string, props *AppStackProps) awscd
 		&customconstructs.GoLambdaFunctionProps{
 			CodePath:     jsii.String("./cmd/meetupproxy"),
 			FunctionName: jsii.String(meetupProxyFunctionName.FullName()),
-			Environment: &map[string]*string{
+			Environment: mergeMaps(commonEnvVars, map[string]*string{
 				"MEETUP_PRIVATE_KEY_BASE64": jsii.String(""),
 				"MEETUP_USER_ID":            jsii.String(""),
 				"MEETUP_CLIENT_KEY":         jsii.String(""),
 				"MEETUP_SIGNING_KEY_ID":     jsii.String(""),
 				"MEETUP_AUTH_URL":           jsii.String(""),
 				"MEETUP_API_URL":            jsii.String(""),
+				"MEETUP_CLIENT_SECRET":      jsii.String(""),
+				"RESPONSE_CACHE_TABLE_NAME": &responseCacheTable.FullTableName,
+				"TOKEN_STORE_TABLE_NAME":    &tokenStoreTable.FullTableName,
+				"TOKEN_STORE_KMS_KEY_ID":    tokenKey.KeyArn(),
 				"SSM_PATH":                  jsii.String(meetupProxySSMPath),
-			},
+			}),
 		},
 	)
 
@@ -83,6 +113,22 @@ func NewStack(scope constructs.Construct, id string, props *AppStackProps) awscd
 		}),
 	)
 
+	// The proxy logs its circuit breaker's state changes with open set to 1 or 0, which are
+	// recorded as a metric to alarm on. The filter must match meetupproxy's circuitStateChanged.
+	awslogs.NewMetricFilter(
+		stack,
+		jsii.String("MeetupProxyCircuitOpenMetricFilter"),
+		&awslogs.MetricFilterProps{
+			LogGroup: meetupProxyFunction.Function.LogGroup(), //nolint:staticcheck
+			FilterPattern: awslogs.FilterPattern_Literal(
+				jsii.String(`{ $.msg = "meetup circuit breaker state changed" }`),
+			),
+			MetricNamespace: jsii.String(stackName.FullName()),
+			MetricName:      jsii.String("MeetupCircuitOpen"),
+			MetricValue:     jsii.String("$.open"),
+		},
+	)
+
 	meetupProxyFunctionPolicyNamer := resource.NewNamer(
 		props.AppEnv,
 		"MeetupProxyFunctionInvokePolicy",
@@ -130,12 +176,16 @@ func NewStack(scope constructs.Construct, id string, props *AppStackProps) awscd
 			CodePath:     jsii.String("./cmd/importer"),
 			FunctionName: jsii.String(importerFunctionName.FullName()),
 			Environment: mergeMaps(commonEnvVars, map[string]*string{
-				"MEETUP_GROUP_NAMES":            meetupGroupNames,
-				"MEETUP_PROXY_FUNCTION_NAME":    jsii.String(meetupProxyFunctionName.FullName()),
-				"EVENTS_TABLE_NAME":             &eventsTable.FullTableName,
-				"GROUP_ID_DATE_TIME_INDEX_NAME": GroupIdDateTimeIndex.IndexName,
-				"ARCHIVED_EVENTS_TABLE_NAME":    &archivedEventsTable.FullTableName,
-				"SSM_PATH":                      jsii.String(importerSSMPath),
+				"MEETUP_GROUP_NAMES":              meetupGroupNames,
+				"MEETUP_PROXY_FUNCTION_NAME":      jsii.String(meetupProxyFunctionName.FullName()),
+				"EVENTS_TABLE_NAME":               &eventsTable.FullTableName,
+				"GROUP_ID_DATE_TIME_INDEX_NAME":   GroupIdDateTimeIndex.IndexName,
+				"ARCHIVED_EVENTS_TABLE_NAME":      &archivedEventsTable.FullTableName,
+				"IMPORT_RUNS_TABLE_NAME":          &importRunsTable.FullTableName,
+				"BACKFILL_CHECKPOINTS_TABLE_NAME": &backfillCheckpointsTable.FullTableName,
+				"EVENT_SERIES_TABLE_NAME":         &eventSeriesTable.FullTableName,
+				"GROUP_VERSIONS_TABLE_NAME":       &groupVersionsTable.FullTableName,
+				"SSM_PATH":                        jsii.String(importerSSMPath),
 			}),
 		},
 	)
@@ -167,13 +217,18 @@ func NewStack(scope constructs.Construct, id string, props *AppStackProps) awscd
 			CodePath:     jsii.String("./cmd/api"),
 			FunctionName: jsii.String(apiFunctionName.FullName()),
 			Environment: mergeMaps(commonEnvVars, map[string]*string{
-				"EVENTS_TABLE_NAME":             &eventsTable.FullTableName,
-				"GROUP_ID_DATE_TIME_INDEX_NAME": GroupIdDateTimeIndex.IndexName,
-				"API_USERS_TABLE_NAME":          &apiUsersTable.FullTableName,
-				"APP_URL":                       jsii.String("https://" + props.DomainName),
-				"JWT_ISSUER":                    jsii.String(props.DomainName),
-				"JWT_SECRET":                    jsii.String(""),
-				"SSM_PATH":                      jsii.String(apiSSMPath),
+				"EVENTS_TABLE_NAME":              &eventsTable.FullTableName,
+				"GROUP_ID_DATE_TIME_INDEX_NAME":  GroupIdDateTimeIndex.IndexName,
+				"SERIES_ID_DATE_TIME_INDEX_NAME": SeriesIdDateTimeIndex.IndexName,
+				"API_USERS_TABLE_NAME":           &apiUsersTable.FullTableName,
+				"IMPORT_RUNS_TABLE_NAME":         &importRunsTable.FullTableName,
+				"EVENT_SERIES_TABLE_NAME":        &eventSeriesTable.FullTableName,
+				"GROUP_VERSIONS_TABLE_NAME":      &groupVersionsTable.FullTableName,
+				"IMPORTER_FUNCTION_NAME":         jsii.String(importerFunctionName.FullName()),
+				"APP_URL":                        jsii.String("https://" + props.DomainName),
+				"JWT_ISSUER":                     jsii.String(props.DomainName),
+				"JWT_SECRET":                     jsii.String(""),
+				"SSM_PATH":                       jsii.String(apiSSMPath),
 			}),
 		},
 	)
@@ -193,11 +248,24 @@ func NewStack(scope constructs.Construct, id string, props *AppStackProps) awscd
 	}))
 
 	meetupProxyFunction.Function.GrantInvoke(importerFunction.Function)     //nolint:staticcheck
+	importerFunction.Function.GrantInvoke(apiFunction.Function)             //nolint:staticcheck
 	eventsTable.Table.GrantReadWriteData(importerFunction.Function)         //nolint:staticcheck
 	eventsTable.Table.GrantReadWriteData(apiFunction.Function)              //nolint:staticcheck
 	archivedEventsTable.Table.GrantReadWriteData(importerFunction.Function) //nolint:staticcheck
 	archivedEventsTable.Table.GrantReadWriteData(apiFunction.Function)      //nolint:staticcheck
 	apiUsersTable.Table.GrantReadWriteData(apiFunction.Function)            //nolint:staticcheck
+	importRunsTable.Table.GrantReadWriteData(importerFunction.Function)     //nolint:staticcheck
+	importRunsTable.Table.GrantReadData(apiFunction.Function)               //nolint:staticcheck
+	//nolint:staticcheck
+	backfillCheckpointsTable.Table.GrantReadWriteData(importerFunction.Function)
+	eventSeriesTable.Table.GrantReadWriteData(importerFunction.Function)   //nolint:staticcheck
+	eventSeriesTable.Table.GrantReadData(apiFunction.Function)             //nolint:staticcheck
+	groupVersionsTable.Table.GrantReadWriteData(importerFunction.Function) //nolint:staticcheck
+	groupVersionsTable.Table.GrantReadData(apiFunction.Function)           //nolint:staticcheck
+	//nolint:staticcheck
+	responseCacheTable.Table.GrantReadWriteData(meetupProxyFunction.Function)
+	tokenStoreTable.Table.GrantReadWriteData(meetupProxyFunction.Function) //nolint:staticcheck
+	tokenKey.GrantEncryptDecrypt(meetupProxyFunction.Function)             //nolint:staticcheck
 
 	importScheduleRule := awsevents.NewRule(
 		stack,
@@ -15,9 +15,20 @@ import (
 	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/google/wire"
 )
 
+const (
+	// refreshLockTTL is how long an instance may hold the token store's refresh lock, which
+	// covers retrying the token request.
+	refreshLockTTL = time.Minute
+	// refreshWaitTimeout is how long to wait for another instance's refreshed token before
+	// fetching one without sharing it.
+	refreshWaitTimeout = 10 * time.Second
+	refreshPollDelay   = 250 * time.Millisecond
+)
+
 type AuthHandler interface {
 	GetAccessToken(ctx context.Context) (string, error)
 }
@@ -26,8 +37,10 @@ type MeetupHttpAuthHandlerConfig struct {
 	URL          string
 	UserID       string
 	ClientKey    string
+	ClientSecret string
 	SigningKeyID string
 	PrivateKey   []byte
+	RetryPolicy  RetryPolicy
 }
 
 func NewMeetupAuthHandlerConfig(config *meetupproxyconfig.Config) MeetupHttpAuthHandlerConfig {
@@ -35,8 +48,10 @@ func NewMeetupAuthHandlerConfig(config *meetupproxyconfig.Config) MeetupHttpAuth
 		URL:          config.MeetupAuthURL,
 		UserID:       config.MeetupUserID,
 		ClientKey:    config.MeetupClientKey,
+		ClientSecret: config.MeetupClientSecret,
 		SigningKeyID: config.MeetupSigningKeyID,
 		PrivateKey:   config.MeetupPrivateKey,
+		RetryPolicy:  DefaultRetryPolicy,
 	}
 }
 
@@ -45,17 +60,26 @@ type MeetupHttpAuthHandler struct {
 	token      *authToken
 	config     MeetupHttpAuthHandlerConfig
 	httpClient *http.Client
-	logger     *slog.Logger
+	store      TokenStore
+	breaker    *CircuitBreaker
+	// owner identifies this instance when locking the token store.
+	owner  string
+	logger *slog.Logger
 }
 
 func NewMeetupHttpAuthHandler(
 	config MeetupHttpAuthHandlerConfig,
 	httpClient *http.Client,
+	store TokenStore,
+	breaker *CircuitBreaker,
 	logger *slog.Logger,
 ) *MeetupHttpAuthHandler {
 	return &MeetupHttpAuthHandler{
 		config:     config,
 		httpClient: httpClient,
+		store:      store,
+		breaker:    breaker,
+		owner:      uuid.NewString(),
 		logger:     logger,
 	}
 }
@@ -73,8 +97,7 @@ func (ah *MeetupHttpAuthHandler) GetAccessToken(ctx context.Context) (string, er
 	defer ah.lock.Unlock()
 
 	if ah.token == nil || ah.token.isExpiring(time.Now()) {
-		ah.logger.Info("fetching new access token from meetup")
-		newToken, err := ah.getNewAccessToken(ctx)
+		newToken, err := ah.sharedToken(ctx)
 		if err != nil {
 			ah.logger.Error("Error fetching token", "err", err)
 			return "", err
@@ -86,6 +109,100 @@ func (ah *MeetupHttpAuthHandler) GetAccessToken(ctx context.Context) (string, er
 	return ah.token.AccessToken, nil
 }
 
+// sharedToken returns the token store's token while it's valid. Otherwise the instance that
+// takes the store's refresh lock replaces it, and the rest wait for its new token. Since the
+// store only saves calls to Meetup, its errors fall back to fetching a token without sharing it.
+func (ah *MeetupHttpAuthHandler) sharedToken(ctx context.Context) (*authToken, error) {
+	stored := ah.storedToken(ctx)
+	if stored != nil && !stored.isExpiring(time.Now()) {
+		return stored, nil
+	}
+
+	locked, err := ah.store.Lock(ctx, ah.owner, refreshLockTTL)
+	if err != nil {
+		ah.logger.Warn("failed to lock token store", "err", err)
+		return ah.newToken(ctx, nil)
+	}
+
+	if !locked {
+		if token := ah.awaitRefreshedToken(ctx); token != nil {
+			return token, nil
+		}
+
+		// The refresh token is left alone since the lock holder may be using it.
+		return ah.newToken(ctx, nil)
+	}
+
+	// Another instance may have stored a new token before the lock was taken.
+	if stored = ah.storedToken(ctx); stored != nil && !stored.isExpiring(time.Now()) {
+		ah.unlockStore(ctx, nil)
+		return stored, nil
+	}
+
+	token, err := ah.newToken(ctx, stored)
+	ah.unlockStore(ctx, token)
+
+	return token, err
+}
+
+func (ah *MeetupHttpAuthHandler) storedToken(ctx context.Context) *authToken {
+	token, err := ah.store.Token(ctx)
+	if err != nil {
+		ah.logger.Warn("failed to read stored token", "err", err)
+		return nil
+	}
+
+	return token
+}
+
+func (ah *MeetupHttpAuthHandler) unlockStore(ctx context.Context, token *authToken) {
+	if err := ah.store.Unlock(ctx, ah.owner, token); err != nil {
+		ah.logger.Warn("failed to unlock token store", "err", err)
+	}
+}
+
+// awaitRefreshedToken polls the token store until the instance holding the refresh lock stores
+// a valid token, giving up after refreshWaitTimeout.
+func (ah *MeetupHttpAuthHandler) awaitRefreshedToken(ctx context.Context) *authToken {
+	timeout := time.After(refreshWaitTimeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout:
+			ah.logger.Warn("timed out waiting for refreshed token")
+			return nil
+		case <-time.After(refreshPollDelay):
+		}
+
+		if token := ah.storedToken(ctx); token != nil && !token.isExpiring(time.Now()) {
+			return token
+		}
+	}
+}
+
+// newToken uses the stored token's refresh token when it has one, falling back to signing a
+// new JWT assertion.
+func (ah *MeetupHttpAuthHandler) newToken(
+	ctx context.Context,
+	stored *authToken,
+) (*authToken, error) {
+	if stored != nil && stored.RefreshToken != "" {
+		ah.logger.Info("refreshing access token from meetup")
+		token, err := ah.refreshAccessToken(ctx, stored.RefreshToken)
+		if err == nil {
+			return token, nil
+		}
+
+		ah.logger.Warn("failed to refresh access token", "err", err)
+	}
+
+	ah.logger.Info("fetching new access token from meetup")
+	return ah.getNewAccessToken(ctx)
+}
+
+// getNewAccessToken exchanges a signed JWT for an access token.
 func (ah *MeetupHttpAuthHandler) getNewAccessToken(ctx context.Context) (*authToken, error) {
 	signedJwt, err := ah.createSignedJWT()
 	if err != nil {
@@ -96,6 +213,66 @@ func (ah *MeetupHttpAuthHandler) getNewAccessToken(ctx context.Context) (*authTo
 	form.Add("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
 	form.Add("assertion", signedJwt)
 
+	return ah.requestToken(ctx, form)
+}
+
+// refreshAccessToken exchanges a refresh token for a new access token. Meetup doesn't always
+// issue a new refresh token, in which case the current one is kept.
+func (ah *MeetupHttpAuthHandler) refreshAccessToken(
+	ctx context.Context,
+	refreshToken string,
+) (*authToken, error) {
+	form := url.Values{}
+	form.Add("grant_type", "refresh_token")
+	form.Add("refresh_token", refreshToken)
+	form.Add("client_id", ah.config.ClientKey)
+	if ah.config.ClientSecret != "" {
+		form.Add("client_secret", ah.config.ClientSecret)
+	}
+
+	token, err := ah.requestToken(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+
+	return token, nil
+}
+
+// requestToken posts form to Meetup's token endpoint, retrying rate limited and transient
+// failures while there's time left and the circuit breaker is closed.
+func (ah *MeetupHttpAuthHandler) requestToken(
+	ctx context.Context,
+	form url.Values,
+) (*authToken, error) {
+	var token *authToken
+	err := ah.config.RetryPolicy.retry(
+		ctx,
+		func(err error, wait time.Duration) {
+			ah.logger.Warn("retrying access token request", "err", err, "wait", wait)
+		},
+		func(ctx context.Context) error {
+			return ah.breaker.call(ctx, func(ctx context.Context) error {
+				var err error
+				token, err = ah.requestAccessToken(ctx, form)
+				return err
+			})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (ah *MeetupHttpAuthHandler) requestAccessToken(
+	ctx context.Context,
+	form url.Values,
+) (*authToken, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -113,13 +290,26 @@ func (ah *MeetupHttpAuthHandler) getNewAccessToken(ctx context.Context) (*authTo
 
 	resp, err := ah.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, &retryableError{err: err}
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
+	wait := retryAfter(resp.Header, time.Now())
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &retryableError{err: &ThrottledError{RetryAfter: wait}, wait: wait}
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("invalid status code when fetching token: %v", resp.StatusCode)
+		err = fmt.Errorf("invalid status code when fetching token: %v", resp.StatusCode)
+		if isTransientStatus(resp.StatusCode) {
+			return nil, &retryableError{err: err, wait: wait}
+		}
+		return nil, err
 	}
 
 	token, err := ah.parseAuthToken(resp.Body)
@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -39,6 +40,7 @@ func TestNewMeetupAuthHandlerConfig(t *testing.T) {
 	assert.Equal(t, cfg.MeetupClientKey, authHandlerConfig.ClientKey)
 	assert.Equal(t, cfg.MeetupSigningKeyID, authHandlerConfig.SigningKeyID)
 	assert.Equal(t, cfg.MeetupPrivateKey, authHandlerConfig.PrivateKey)
+	assert.Equal(t, DefaultRetryPolicy, authHandlerConfig.RetryPolicy)
 }
 
 func TestAuthHandler_GetAccessToken_InitialFetch(t *testing.T) {
@@ -55,7 +57,7 @@ func TestAuthHandler_GetAccessToken_InitialFetch(t *testing.T) {
 	ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
 		URL:        ts.URL,
 		PrivateKey: privateKey,
-	}, &http.Client{}, logging.NewMockLogger())
+	}, &http.Client{}, newMockTokenStore(), noCircuitBreaker(), logging.NewMockLogger())
 
 	token, err := ah.GetAccessToken(context.Background())
 
@@ -80,7 +82,7 @@ func TestAuthHandler_GetAccessToken_ValidUserAgent(t *testing.T) {
 	ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
 		URL:        ts.URL,
 		PrivateKey: privateKey,
-	}, &http.Client{}, logging.NewMockLogger())
+	}, &http.Client{}, newMockTokenStore(), noCircuitBreaker(), logging.NewMockLogger())
 
 	_, err := ah.GetAccessToken(context.Background())
 
@@ -109,7 +111,7 @@ func TestAuthHandler_GetAccessToken_ExpiredToken(t *testing.T) {
 	ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
 		URL:        ts.URL,
 		PrivateKey: privateKey,
-	}, &http.Client{}, logging.NewMockLogger())
+	}, &http.Client{}, newMockTokenStore(), noCircuitBreaker(), logging.NewMockLogger())
 
 	token, err := ah.GetAccessToken(context.Background())
 
@@ -134,13 +136,42 @@ func TestAuthHandler_GetAccessTokenn_HTTPErrorHandling(t *testing.T) {
 	ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
 		URL:        ts.URL,
 		PrivateKey: privateKey,
-	}, &http.Client{}, logging.NewMockLogger())
+	}, &http.Client{}, newMockTokenStore(), noCircuitBreaker(), logging.NewMockLogger())
 
 	_, err := ah.GetAccessToken(context.Background())
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "invalid status code")
 }
 
+func TestAuthHandler_GetAccessToken_RetriesTransientErrors(t *testing.T) {
+	var callCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch callCount.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_ = json.NewEncoder(w).Encode(authToken{AccessToken: "test-token", ExpiresIn: 3600})
+		}
+	}))
+	defer ts.Close()
+
+	privateKey, _ := generatePrivateKey()
+	ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
+		URL:         ts.URL,
+		PrivateKey:  privateKey,
+		RetryPolicy: testRetryPolicy,
+	}, &http.Client{}, newMockTokenStore(), noCircuitBreaker(), logging.NewMockLogger())
+
+	token, err := ah.GetAccessToken(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", token)
+	assert.Equal(t, int32(3), callCount.Load())
+}
+
 func TestAuthHandler_GetAccessToken_ConcurrentRequests(t *testing.T) {
 	var callCount int
 	var mu sync.Mutex
@@ -162,7 +193,7 @@ func TestAuthHandler_GetAccessToken_ConcurrentRequests(t *testing.T) {
 	ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
 		URL:        ts.URL,
 		PrivateKey: privateKey,
-	}, &http.Client{}, logging.NewMockLogger())
+	}, &http.Client{}, newMockTokenStore(), noCircuitBreaker(), logging.NewMockLogger())
 
 	var wg sync.WaitGroup
 	for i := 0; i < 10; i++ {
@@ -215,6 +246,8 @@ func TestAuthHandler_TestParseAuthToken(t *testing.T) {
 	ah := NewMeetupHttpAuthHandler(
 		MeetupHttpAuthHandlerConfig{},
 		&http.Client{},
+		newMockTokenStore(),
+		noCircuitBreaker(),
 		logging.NewMockLogger(),
 	)
 
@@ -305,3 +338,206 @@ func generatePrivateKey() ([]byte, error) {
 
 	return privateKeyToBytes(privateKey)
 }
+
+type mockTokenStore struct {
+	lock       sync.Mutex
+	token      *authToken
+	lockOwner  string
+	err        error
+	tokenCalls int
+	// onToken is called with the number of Token calls so far, before the token is returned.
+	onToken func(calls int)
+}
+
+func newMockTokenStore() *mockTokenStore {
+	return &mockTokenStore{}
+}
+
+func (m *mockTokenStore) Token(ctx context.Context) (*authToken, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.tokenCalls++
+	if m.onToken != nil {
+		m.onToken(m.tokenCalls)
+	}
+	return m.token, m.err
+}
+
+func (m *mockTokenStore) Lock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.lockOwner != "" && m.lockOwner != owner {
+		return false, nil
+	}
+	m.lockOwner = owner
+	return true, nil
+}
+
+func (m *mockTokenStore) Unlock(ctx context.Context, owner string, token *authToken) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.lockOwner != owner {
+		return ErrTokenLockLost
+	}
+	if token != nil {
+		m.token = token
+	}
+	m.lockOwner = ""
+	return m.err
+}
+
+func TestAuthHandler_GetAccessToken_TokenStore(t *testing.T) {
+	type tokenRequest struct {
+		grantType    string
+		refreshToken string
+		clientID     string
+		clientSecret string
+	}
+
+	setup := func(t *testing.T, store *mockTokenStore) (*MeetupHttpAuthHandler, *[]tokenRequest) {
+		t.Helper()
+
+		var lock sync.Mutex
+		var requests []tokenRequest
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			require.NoError(t, r.ParseForm())
+			request := tokenRequest{
+				grantType:    r.PostForm.Get("grant_type"),
+				refreshToken: r.PostForm.Get("refresh_token"),
+				clientID:     r.PostForm.Get("client_id"),
+				clientSecret: r.PostForm.Get("client_secret"),
+			}
+			requests = append(requests, request)
+
+			if request.refreshToken == "rejected" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(authToken{
+				AccessToken:  fmt.Sprintf("token-%d", len(requests)),
+				RefreshToken: fmt.Sprintf("refresh-%d", len(requests)),
+				ExpiresIn:    3600,
+				TokenType:    "Bearer",
+			})
+		}))
+		t.Cleanup(ts.Close)
+
+		privateKey, _ := generatePrivateKey()
+		ah := NewMeetupHttpAuthHandler(MeetupHttpAuthHandlerConfig{
+			URL:          ts.URL,
+			ClientKey:    "client",
+			ClientSecret: "secret",
+			PrivateKey:   privateKey,
+		}, &http.Client{}, store, noCircuitBreaker(), logging.NewMockLogger())
+
+		return ah, &requests
+	}
+
+	const assertionGrant = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	t.Run("uses the stored token while it's valid", func(t *testing.T) {
+		store := newMockTokenStore()
+		store.token = &authToken{AccessToken: "stored", ExpiresAt: time.Now().Add(time.Hour)}
+		ah, requests := setup(t, store)
+
+		token, err := ah.GetAccessToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "stored", token)
+		assert.Empty(t, *requests)
+	})
+
+	t.Run("stores new tokens", func(t *testing.T) {
+		store := newMockTokenStore()
+		ah, requests := setup(t, store)
+
+		token, err := ah.GetAccessToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "token-1", token)
+		require.Len(t, *requests, 1)
+		assert.Equal(t, assertionGrant, (*requests)[0].grantType)
+		assert.Equal(t, "token-1", store.token.AccessToken)
+		assert.Equal(t, "refresh-1", store.token.RefreshToken)
+		assert.Empty(t, store.lockOwner)
+	})
+
+	t.Run("refreshes expired tokens with the refresh token", func(t *testing.T) {
+		store := newMockTokenStore()
+		store.token = &authToken{
+			AccessToken:  "expired",
+			RefreshToken: "refresh-0",
+			ExpiresAt:    time.Now().Add(-time.Minute),
+		}
+		ah, requests := setup(t, store)
+
+		token, err := ah.GetAccessToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "token-1", token)
+		assert.Equal(t, []tokenRequest{{
+			grantType:    "refresh_token",
+			refreshToken: "refresh-0",
+			clientID:     "client",
+			clientSecret: "secret",
+		}}, *requests)
+		assert.Equal(t, "token-1", store.token.AccessToken)
+	})
+
+	t.Run("signs a new assertion when refreshing fails", func(t *testing.T) {
+		store := newMockTokenStore()
+		store.token = &authToken{
+			AccessToken:  "expired",
+			RefreshToken: "rejected",
+			ExpiresAt:    time.Now().Add(-time.Minute),
+		}
+		ah, requests := setup(t, store)
+
+		token, err := ah.GetAccessToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "token-2", token)
+		require.Len(t, *requests, 2)
+		assert.Equal(t, assertionGrant, (*requests)[1].grantType)
+		assert.Equal(t, "token-2", store.token.AccessToken)
+	})
+
+	t.Run("waits for the token refreshed by the lock holder", func(t *testing.T) {
+		store := newMockTokenStore()
+		store.lockOwner = "another-instance"
+		store.onToken = func(calls int) {
+			if calls == 2 {
+				store.token = &authToken{
+					AccessToken: "refreshed-elsewhere",
+					ExpiresAt:   time.Now().Add(time.Hour),
+				}
+			}
+		}
+		ah, requests := setup(t, store)
+
+		token, err := ah.GetAccessToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "refreshed-elsewhere", token)
+		assert.Empty(t, *requests)
+	})
+
+	t.Run("fetches a token when the store fails", func(t *testing.T) {
+		store := newMockTokenStore()
+		store.err = fmt.Errorf("store error")
+		ah, requests := setup(t, store)
+
+		token, err := ah.GetAccessToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "token-1", token)
+		assert.Len(t, *requests, 1)
+	})
+}
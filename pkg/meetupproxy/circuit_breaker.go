@@ -0,0 +1,191 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+
+	"github.com/google/wire"
+)
+
+// CircuitState is whether a CircuitBreaker lets calls to Meetup through.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// circuitStateChanged is logged on every state change, with open set to 1 while the circuit
+// isn't closed. The stack's MeetupProxyCircuitOpenMetricFilter records open from these logs as
+// the MeetupCircuitOpen CloudWatch metric, so the message and field must match it.
+const circuitStateChanged = "meetup circuit breaker state changed"
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many consecutive failures open the circuit. Zero never opens it.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a probe is let through.
+	Cooldown time.Duration
+}
+
+func NewCircuitBreakerConfig(config *meetupproxyconfig.Config) CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: config.CircuitBreakerThreshold,
+		Cooldown:         config.CircuitBreakerCooldown,
+	}
+}
+
+// CircuitBreaker stops calling Meetup once it keeps failing, so callers fail fast with a
+// CircuitOpenError instead of each waiting out their own retries. After the cooldown a single
+// probe is let through, which closes the circuit when it succeeds and reopens it when it fails.
+// The service and auth handler share a breaker since they call the same upstream.
+type CircuitBreaker struct {
+	lock       sync.Mutex
+	config     CircuitBreakerConfig
+	state      CircuitState
+	failures   int
+	openedAt   time.Time
+	probing    bool
+	timeSource clock.TimeSource
+	logger     *slog.Logger
+}
+
+func NewCircuitBreaker(
+	config CircuitBreakerConfig,
+	timeSource clock.TimeSource,
+	logger *slog.Logger,
+) *CircuitBreaker {
+	return &CircuitBreaker{
+		config:     config,
+		state:      CircuitClosed,
+		timeSource: timeSource,
+		logger:     logger,
+	}
+}
+
+func (b *CircuitBreaker) State() CircuitState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.state
+}
+
+// EffectiveState is the state the next call would see. An open circuit whose cooldown has
+// passed counts as half-open even though it only moves there once a call comes in, so
+// instances that get no traffic while they're open don't look unavailable forever.
+func (b *CircuitBreaker) EffectiveState() CircuitState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == CircuitOpen && !b.timeSource.Now().Before(b.openedAt.Add(b.config.Cooldown)) {
+		return CircuitHalfOpen
+	}
+
+	return b.state
+}
+
+// call makes a single attempt at calling Meetup unless the circuit is open, recording whether
+// Meetup failed.
+func (b *CircuitBreaker) call(ctx context.Context, attempt func(ctx context.Context) error) error {
+	if b.config.FailureThreshold <= 0 {
+		return attempt(ctx)
+	}
+
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := attempt(ctx)
+	b.record(ctx, err)
+
+	return err
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		wait := b.openedAt.Add(b.config.Cooldown).Sub(b.timeSource.Now())
+		if wait > 0 {
+			return &CircuitOpenError{RetryAfter: wait}
+		}
+
+		b.transition(CircuitHalfOpen)
+		b.probing = true
+	case CircuitHalfOpen:
+		// Only one probe is let through at a time.
+		if b.probing {
+			return &CircuitOpenError{}
+		}
+
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record counts retryable failures other than rate limiting, which Meetup only returns while
+// it's up. Attempts cut short by their context say nothing about Meetup, so they're ignored.
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.probing = false
+
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	var retryable *retryableError
+	var throttled *ThrottledError
+	if !errors.As(err, &retryable) || errors.As(err, &throttled) {
+		b.failures = 0
+		if b.state != CircuitClosed {
+			b.transition(CircuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.openedAt = b.timeSource.Now()
+		if b.state != CircuitOpen {
+			b.transition(CircuitOpen)
+		}
+	}
+}
+
+func (b *CircuitBreaker) transition(state CircuitState) {
+	from := b.state
+	b.state = state
+
+	open := 1
+	level := slog.LevelWarn
+	if state == CircuitClosed {
+		open = 0
+		level = slog.LevelInfo
+	}
+
+	b.logger.Log(
+		context.Background(),
+		level,
+		circuitStateChanged,
+		"from", from,
+		"to", state,
+		"failures", b.failures,
+		"open", open,
+	)
+}
+
+var CircuitBreakerProviders = wire.NewSet(
+	NewCircuitBreakerConfig,
+	NewCircuitBreaker,
+)
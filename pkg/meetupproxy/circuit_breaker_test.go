@@ -0,0 +1,201 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noCircuitBreaker never opens, for tests that aren't about the circuit breaker.
+func noCircuitBreaker() *CircuitBreaker {
+	return NewCircuitBreaker(
+		CircuitBreakerConfig{},
+		clock.NewRealTimeSource(),
+		logging.NewMockLogger(),
+	)
+}
+
+var errTransient = &retryableError{err: errors.New("transient")}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	config := CircuitBreakerConfig{FailureThreshold: 3, Cooldown: time.Minute}
+
+	fail := func(context.Context) error { return errTransient }
+	succeed := func(context.Context) error { return nil }
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		logHandler := logging.NewMockHandler()
+		breaker := NewCircuitBreaker(config, clock.NewMockTimeSource(now), slog.New(logHandler))
+
+		for range 3 {
+			assert.ErrorIs(t, breaker.call(context.Background(), fail), errTransient)
+		}
+		assert.Equal(t, CircuitOpen, breaker.State())
+
+		var calls int
+		err := breaker.call(context.Background(), func(context.Context) error {
+			calls++
+			return nil
+		})
+
+		var openErr *CircuitOpenError
+		require.ErrorAs(t, err, &openErr)
+		assert.Equal(t, time.Minute, openErr.RetryAfter)
+		assert.Zero(t, calls)
+
+		entries := logHandler.Entries(slog.LevelWarn)
+		require.Len(t, entries, 1)
+		assert.Equal(t, circuitStateChanged, entries[0].Message)
+		assert.Equal(t, CircuitOpen, entries[0].Attrs["to"])
+		assert.EqualValues(t, 1, entries[0].Attrs["open"])
+	})
+
+	t.Run("successes reset the failure count", func(t *testing.T) {
+		breaker := NewCircuitBreaker(config, clock.NewMockTimeSource(now), logging.NewMockLogger())
+
+		for _, attempt := range []func(context.Context) error{fail, fail, succeed, fail, fail} {
+			_ = breaker.call(context.Background(), attempt)
+		}
+
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("only counts upstream failures", func(t *testing.T) {
+		breaker := NewCircuitBreaker(config, clock.NewMockTimeSource(now), logging.NewMockLogger())
+
+		throttled := &retryableError{err: &ThrottledError{}}
+		permanent := errors.New("bad request")
+		for _, err := range []error{throttled, permanent, throttled, permanent} {
+			_ = breaker.call(context.Background(), func(context.Context) error { return err })
+		}
+		assert.Equal(t, CircuitClosed, breaker.State())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for range 3 {
+			_ = breaker.call(ctx, fail)
+		}
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("counts an open circuit as half-open once the cooldown has passed", func(t *testing.T) {
+		timeSource := clock.NewMockTimeSource(now)
+		breaker := NewCircuitBreaker(config, timeSource, logging.NewMockLogger())
+
+		for range 3 {
+			_ = breaker.call(context.Background(), fail)
+		}
+		assert.Equal(t, CircuitOpen, breaker.EffectiveState())
+
+		timeSource.SetTime(now.Add(time.Minute))
+
+		assert.Equal(t, CircuitHalfOpen, breaker.EffectiveState())
+		assert.Equal(t, CircuitOpen, breaker.State(), "only a call moves the circuit")
+	})
+
+	t.Run("probes once the cooldown has passed", func(t *testing.T) {
+		timeSource := clock.NewMockTimeSource(now)
+		breaker := NewCircuitBreaker(config, timeSource, logging.NewMockLogger())
+
+		for range 3 {
+			_ = breaker.call(context.Background(), fail)
+		}
+
+		timeSource.SetTime(now.Add(time.Minute))
+
+		err := breaker.call(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, CircuitHalfOpen, breaker.State())
+
+			// Other calls are rejected while the probe is in flight.
+			var openErr *CircuitOpenError
+			assert.ErrorAs(t, breaker.call(ctx, succeed), &openErr)
+
+			return fail(ctx)
+		})
+		require.ErrorIs(t, err, errTransient)
+		assert.Equal(t, CircuitOpen, breaker.State(), "a failed probe reopens the circuit")
+
+		timeSource.SetTime(now.Add(2 * time.Minute))
+
+		require.NoError(t, breaker.call(context.Background(), succeed))
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("a zero threshold never opens", func(t *testing.T) {
+		breaker := noCircuitBreaker()
+
+		for range 10 {
+			_ = breaker.call(context.Background(), fail)
+		}
+
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+}
+
+func TestCircuitBreaker_SharedByServiceAndAuth(t *testing.T) {
+	var apiRequests, tokenRequests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenRequests.Add(1)
+		} else {
+			apiRequests.Add(1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	timeSource := clock.NewMockTimeSource(time.Now())
+	breaker := NewCircuitBreaker(
+		CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute},
+		timeSource,
+		logging.NewMockLogger(),
+	)
+
+	privateKey, err := generatePrivateKey()
+	require.NoError(t, err)
+
+	auth := NewMeetupHttpAuthHandler(
+		MeetupHttpAuthHandlerConfig{URL: ts.URL + "/token", PrivateKey: privateKey},
+		&http.Client{},
+		newMockTokenStore(),
+		breaker,
+		logging.NewMockLogger(),
+	)
+
+	proxy := NewService(
+		ServiceConfig{
+			URL:                      ts.URL + "/gql",
+			RetryPolicy:              testRetryPolicy,
+			AllowUnregisteredQueries: true,
+		},
+		&http.Client{},
+		&mockAuth{token: "token"},
+		newMockCache(),
+		NewDefaultQueryRegistry(),
+		breaker,
+		timeSource,
+		logging.NewMockLogger(),
+	)
+
+	_, err = proxy.HandleRequest(context.Background(), Request{Query: "query { self { id } }"})
+
+	var openErr *CircuitOpenError
+	require.ErrorAs(t, err, &openErr, "retries stop once the circuit opens")
+	assert.EqualValues(t, 2, apiRequests.Load())
+
+	_, err = auth.GetAccessToken(context.Background())
+	require.ErrorAs(t, err, &openErr)
+	assert.Zero(t, tokenRequests.Load())
+}
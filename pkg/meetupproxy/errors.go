@@ -0,0 +1,88 @@
+package meetupproxy
+
+import (
+	"fmt"
+	"time"
+)
+
+// ThrottledError is returned when Meetup is rate limiting requests and the limit doesn't reset
+// in time to retry. Lambda reports the type name as the failed invocation's errorType, which
+// lets callers tell throttling apart from other failures.
+type ThrottledError struct {
+	// RetryAfter is how long Meetup asked us to wait, or zero if it didn't say.
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("meetup rate limit exceeded, retry after %v", e.RetryAfter)
+	}
+	return "meetup rate limit exceeded"
+}
+
+// UpstreamError is returned when a request to Meetup fails for any reason other than rate
+// limiting. Transient failures are only returned once there's no time left to retry them.
+type UpstreamError struct {
+	// StatusCode is Meetup's response status, or zero if no response was received.
+	StatusCode int
+	Transient  bool
+	Err        error
+	body       string
+}
+
+func (e *UpstreamError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("expected status code 200, got %v", e.StatusCode)
+	}
+	return fmt.Sprintf("meetup request failed: %v", e.Err)
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+// RequestErrorCode says why a request was rejected. Codes are stable so callers can match on
+// them rather than on messages.
+type RequestErrorCode string
+
+const (
+	RequestErrorEmptyQuery          RequestErrorCode = "EMPTY_QUERY"
+	RequestErrorQueryTooLarge       RequestErrorCode = "QUERY_TOO_LARGE"
+	RequestErrorInvalidVariables    RequestErrorCode = "INVALID_VARIABLES"
+	RequestErrorOperationNotAllowed RequestErrorCode = "OPERATION_NOT_ALLOWED"
+	RequestErrorUnregisteredQuery   RequestErrorCode = "UNREGISTERED_QUERY"
+	RequestErrorInvalidRequest      RequestErrorCode = "INVALID_REQUEST"
+)
+
+// RequestError is returned for requests rejected before anything is sent to Meetup. Every
+// rejection has the same envelope: Lambda reports RequestError as the errorType, and the
+// errorMessage starts with the code.
+type RequestError struct {
+	Code RequestErrorCode
+	Err  error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Code, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// CircuitOpenError is returned without calling Meetup while the circuit breaker is open after
+// repeated failures. Like ThrottledError, its type name is reported as the errorType.
+type CircuitOpenError struct {
+	// RetryAfter is how long until the circuit lets a probe through, or zero if one is in flight.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf(
+			"meetup is unavailable, circuit breaker is open, retry after %v",
+			e.RetryAfter.Round(time.Second),
+		)
+	}
+	return "meetup is unavailable, circuit breaker is open"
+}
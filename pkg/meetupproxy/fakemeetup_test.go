@@ -0,0 +1,115 @@
+package meetupproxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/fakemeetup"
+	"sgf-meetup-api/pkg/fakemeetup/fakemeetupconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestService_FakeMeetup runs persisted queries against the fake Meetup server, covering the
+// token exchange and pagination end to end.
+func TestService_FakeMeetup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fakeConfig := &fakemeetupconfig.Config{
+		GroupNames:     []string{"sgfdevs"},
+		EventsPerGroup: 10,
+		Seed:           1,
+		TokenTTL:       time.Hour,
+	}
+	timeSource := clock.NewRealTimeSource()
+
+	events, err := fakemeetup.NewEventStore(fakeConfig, timeSource)
+	require.NoError(t, err)
+
+	failures := &fakemeetup.Failures{}
+	fake := httptest.NewServer(fakemeetup.NewRouter(
+		logging.NewMockLogger(),
+		fakemeetup.NewServer(
+			events,
+			fakemeetup.NewTokenIssuer(fakeConfig, timeSource),
+			failures,
+			timeSource,
+			logging.NewMockLogger(),
+		),
+	))
+	defer fake.Close()
+
+	privateKey, err := generatePrivateKey()
+	require.NoError(t, err)
+
+	proxy := NewService(
+		ServiceConfig{URL: fake.URL + fakemeetup.GraphQLPath, RetryPolicy: testRetryPolicy},
+		&http.Client{},
+		NewMeetupHttpAuthHandler(
+			MeetupHttpAuthHandlerConfig{
+				URL:          fake.URL + fakemeetup.TokenPath,
+				UserID:       "user-id",
+				ClientKey:    "client-key",
+				SigningKeyID: "signing-key",
+				PrivateKey:   privateKey,
+				RetryPolicy:  testRetryPolicy,
+			},
+			&http.Client{},
+			newMockTokenStore(),
+			noCircuitBreaker(),
+			logging.NewMockLogger(),
+		),
+		newMockCache(),
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		timeSource,
+		logging.NewMockLogger(),
+	)
+
+	// Every page is preceded by a rate limited response the proxy retries.
+	require.NoError(t, failures.Add(fakemeetup.Failure{
+		Mode:     fakemeetup.FailureRateLimited,
+		Endpoint: fakemeetup.EndpointGraphQL,
+		Times:    3,
+	}))
+
+	var ids []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 10)
+
+		variables := map[string]any{"urlname": "sgfdevs", "itemsNum": 2}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+
+		resp, err := proxy.HandleRequest(context.Background(), Request{
+			QueryID:   GroupFutureEventsQueryID,
+			Variables: variables,
+		})
+		require.NoError(t, err)
+
+		data := (*resp)["data"].(map[string]any)
+		page := data["groupByUrlname"].(map[string]any)["events"].(map[string]any)
+		for _, edge := range page["edges"].([]any) {
+			node := edge.(map[string]any)["node"].(map[string]any)
+			ids = append(ids, node["id"].(string))
+		}
+
+		pageInfo := page["pageInfo"].(map[string]any)
+		if !pageInfo["hasNextPage"].(bool) {
+			break
+		}
+		cursor = pageInfo["endCursor"].(string)
+	}
+
+	assert.Len(t, ids, 5)
+	assert.Empty(t, failures.List())
+}
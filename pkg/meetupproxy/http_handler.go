@@ -0,0 +1,213 @@
+package meetupproxy
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+const (
+	// GraphQLPath takes GraphQL requests, with a queryId naming a persisted query in place of
+	// the query text.
+	GraphQLPath = "/graphql"
+	// LivenessPath responds while the server is running, ReadinessPath only while the circuit
+	// breaker lets requests through to Meetup.
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+
+	maxRequestBytes = 1 << 20
+)
+
+// Codes for errors that aren't RequestErrors, reported alongside RequestErrorCode values.
+const (
+	errorCodeUnauthenticated = "UNAUTHENTICATED"
+	errorCodeThrottled       = "THROTTLED"
+	errorCodeUnavailable     = "UPSTREAM_UNAVAILABLE"
+	errorCodeUpstream        = "UPSTREAM_ERROR"
+	errorCodeInternal        = "INTERNAL"
+)
+
+var ErrNoTokens = errors.New("serving HTTP requires at least one bearer token")
+
+type HTTPHandlerConfig struct {
+	Tokens []string
+}
+
+func NewHTTPHandlerConfig(config *meetupproxyconfig.Config) HTTPHandlerConfig {
+	return HTTPHandlerConfig{Tokens: config.Tokens}
+}
+
+// HTTPHandler serves the proxy over HTTP for callers that can't invoke its Lambda. Errors are
+// returned in GraphQL's format, with the code and Go error type in each error's extensions.
+// The statuses match what importer.HTTPProxyGraphQLHandler expects: 429 when Meetup is rate
+// limiting and 503 while the circuit breaker is open.
+type HTTPHandler struct {
+	config  HTTPHandlerConfig
+	service *Service
+	breaker *CircuitBreaker
+	logger  *slog.Logger
+}
+
+func NewHTTPHandler(
+	config HTTPHandlerConfig,
+	service *Service,
+	breaker *CircuitBreaker,
+	logger *slog.Logger,
+) (*HTTPHandler, error) {
+	if len(config.Tokens) == 0 {
+		return nil, ErrNoTokens
+	}
+
+	return &HTTPHandler{
+		config:  config,
+		service: service,
+		breaker: breaker,
+		logger:  logger,
+	}, nil
+}
+
+func (h *HTTPHandler) RegisterRoutes(r gin.IRouter) {
+	r.POST(GraphQLPath, h.authenticate, h.graphQL)
+	r.GET(LivenessPath, h.liveness)
+	r.GET(ReadinessPath, h.readiness)
+}
+
+// authenticate requires one of the configured bearer tokens, compared in constant time.
+func (h *HTTPHandler) authenticate(ctx *gin.Context) {
+	token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if ok {
+		for _, allowed := range h.config.Tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+				ctx.Next()
+				return
+			}
+		}
+	}
+
+	ctx.Header("WWW-Authenticate", "Bearer")
+	writeError(ctx, http.StatusUnauthorized, errorCodeUnauthenticated, "", "invalid bearer token")
+	ctx.Abort()
+}
+
+func (h *HTTPHandler) graphQL(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxRequestBytes)
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		var requestErr *RequestError
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &requestErr):
+		case errors.As(err, &maxBytesErr):
+			requestErr = &RequestError{
+				Code: RequestErrorQueryTooLarge,
+				Err:  fmt.Errorf("request body is larger than %d bytes", maxBytesErr.Limit),
+			}
+		default:
+			requestErr = &RequestError{Code: RequestErrorInvalidRequest, Err: err}
+		}
+
+		h.writeServiceError(ctx, requestErr)
+		return
+	}
+
+	resp, err := h.service.HandleRequest(ctx.Request.Context(), req)
+	if err != nil {
+		h.writeServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTPHandler) writeServiceError(ctx *gin.Context, err error) {
+	var requestErr *RequestError
+	var throttledErr *ThrottledError
+	var circuitOpenErr *CircuitOpenError
+	var upstreamErr *UpstreamError
+
+	switch {
+	case errors.As(err, &requestErr):
+		status := http.StatusBadRequest
+		if requestErr.Code == RequestErrorQueryTooLarge {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeError(ctx, status, string(requestErr.Code), "RequestError", err.Error())
+	case errors.As(err, &throttledErr):
+		setRetryAfter(ctx, throttledErr.RetryAfter)
+		writeError(
+			ctx,
+			http.StatusTooManyRequests,
+			errorCodeThrottled,
+			"ThrottledError",
+			err.Error(),
+		)
+	case errors.As(err, &circuitOpenErr):
+		setRetryAfter(ctx, circuitOpenErr.RetryAfter)
+		writeError(
+			ctx,
+			http.StatusServiceUnavailable,
+			errorCodeUnavailable,
+			"CircuitOpenError",
+			err.Error(),
+		)
+	case errors.As(err, &upstreamErr):
+		writeError(ctx, http.StatusBadGateway, errorCodeUpstream, "UpstreamError", err.Error())
+	default:
+		h.logger.Error("meetup proxy request failed", "err", err)
+		writeError(ctx, http.StatusInternalServerError, errorCodeInternal, "", "internal error")
+	}
+}
+
+// setRetryAfter rounds up to whole seconds so clients don't retry early.
+func setRetryAfter(ctx *gin.Context, wait time.Duration) {
+	if wait > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+}
+
+func writeError(ctx *gin.Context, status int, code, errorType, message string) {
+	extensions := gin.H{"code": code}
+	if errorType != "" {
+		extensions["type"] = errorType
+	}
+
+	ctx.JSON(status, gin.H{
+		"errors": []gin.H{{"message": message, "extensions": extensions}},
+	})
+}
+
+func (h *HTTPHandler) liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readiness reports the circuit breaker's state, failing while it's open so load balancers can
+// route around an instance that can't reach Meetup. Once the cooldown passes it reports ready
+// again, letting traffic back in to probe Meetup.
+func (h *HTTPHandler) readiness(ctx *gin.Context) {
+	state := h.breaker.EffectiveState()
+	if state == CircuitOpen {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "circuit": state})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok", "circuit": state})
+}
+
+var HTTPHandlerProviders = wire.NewSet(
+	NewHTTPHandlerConfig,
+	NewHTTPHandler,
+	NewRouter,
+	NewHTTPServer,
+)
@@ -0,0 +1,301 @@
+package meetupproxy
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLErrorResponse struct {
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+			Type string `json:"type"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestHTTPHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const token = "caller-token"
+
+	setup := func(
+		t *testing.T,
+		upstream http.HandlerFunc,
+	) (*gin.Engine, *CircuitBreaker, *clock.MockTimeSource) {
+		t.Helper()
+
+		ts := httptest.NewServer(upstream)
+		t.Cleanup(ts.Close)
+
+		breakerTimeSource := clock.NewMockTimeSource(time.Now())
+		breaker := NewCircuitBreaker(
+			CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute},
+			breakerTimeSource,
+			logging.NewMockLogger(),
+		)
+
+		service := NewService(
+			ServiceConfig{URL: ts.URL, AllowUnregisteredQueries: true, MaxQueryBytes: 1024},
+			&http.Client{},
+			&mockAuth{token: "meetup-token"},
+			newMockCache(),
+			NewDefaultQueryRegistry(),
+			breaker,
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+
+		handler, err := NewHTTPHandler(
+			HTTPHandlerConfig{Tokens: []string{"other-token", token}},
+			service,
+			breaker,
+			logging.NewMockLogger(),
+		)
+		require.NoError(t, err)
+
+		return NewRouter(logging.NewMockLogger(), handler), breaker, breakerTimeSource
+	}
+
+	succeed := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"groupByUrlname": null}}`))
+	}
+
+	post := func(router *gin.Engine, authorization, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, GraphQLPath, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	decodeError := func(t *testing.T, w *httptest.ResponseRecorder) (code, errorType string) {
+		t.Helper()
+
+		var resp graphQLErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Errors, 1)
+		assert.NotEmpty(t, resp.Errors[0].Message)
+
+		return resp.Errors[0].Extensions.Code, resp.Errors[0].Extensions.Type
+	}
+
+	persistedQuery := `{"queryId": "groupFutureEvents@v1", ` +
+		`"variables": {"urlname": "sgfdevs", "itemsNum": 20}}`
+
+	t.Run("runs queries for authenticated callers", func(t *testing.T) {
+		router, _, _ := setup(t, succeed)
+
+		w := post(router, "Bearer "+token, persistedQuery)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data": {"groupByUrlname": null}}`, w.Body.String())
+	})
+
+	t.Run("rejects missing and unknown tokens", func(t *testing.T) {
+		router, _, _ := setup(t, succeed)
+
+		for _, authorization := range []string{"", "Bearer wrong", token} {
+			w := post(router, authorization, persistedQuery)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			code, _ := decodeError(t, w)
+			assert.Equal(t, "UNAUTHENTICATED", code)
+		}
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			body   string
+			status int
+			code   RequestErrorCode
+		}{
+			{
+				name:   "malformed json",
+				body:   `{"query": `,
+				status: http.StatusBadRequest,
+				code:   RequestErrorInvalidRequest,
+			},
+			{
+				name:   "variables that aren't an object",
+				body:   `{"query": "{ self { id } }", "variables": [1]}`,
+				status: http.StatusBadRequest,
+				code:   RequestErrorInvalidVariables,
+			},
+			{
+				name:   "mutation",
+				body:   `{"query": "mutation { delete { id } }"}`,
+				status: http.StatusBadRequest,
+				code:   RequestErrorOperationNotAllowed,
+			},
+			{
+				name:   "oversized query",
+				body:   `{"query": "{ ` + strings.Repeat("id ", 500) + `}"}`,
+				status: http.StatusRequestEntityTooLarge,
+				code:   RequestErrorQueryTooLarge,
+			},
+			{
+				name:   "oversized body",
+				body:   `{"query": "` + strings.Repeat(" ", maxRequestBytes) + `"}`,
+				status: http.StatusRequestEntityTooLarge,
+				code:   RequestErrorQueryTooLarge,
+			},
+		}
+
+		router, _, _ := setup(t, succeed)
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := post(router, "Bearer "+token, tt.body)
+
+				assert.Equal(t, tt.status, w.Code)
+				code, errorType := decodeError(t, w)
+				assert.Equal(t, string(tt.code), code)
+				assert.Equal(t, "RequestError", errorType)
+			})
+		}
+	})
+
+	t.Run("throttled requests get 429", func(t *testing.T) {
+		router, _, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		w := post(router, "Bearer "+token, persistedQuery)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "30", w.Header().Get("Retry-After"))
+		code, errorType := decodeError(t, w)
+		assert.Equal(t, "THROTTLED", code)
+		assert.Equal(t, "ThrottledError", errorType)
+	})
+
+	t.Run("an open circuit gets 503 and fails readiness", func(t *testing.T) {
+		router, breaker, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		w := post(router, "Bearer "+token, persistedQuery)
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+		code, _ := decodeError(t, w)
+		assert.Equal(t, "UPSTREAM_ERROR", code)
+		require.Equal(t, CircuitOpen, breaker.State())
+
+		w = post(router, "Bearer "+token, persistedQuery)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		code, errorType := decodeError(t, w)
+		assert.Equal(t, "UPSTREAM_UNAVAILABLE", code)
+		assert.Equal(t, "CircuitOpenError", errorType)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"status": "unavailable", "circuit": "open"}`, w.Body.String())
+	})
+
+	t.Run("readiness recovers once the cooldown passes without traffic", func(t *testing.T) {
+		router, breaker, timeSource := setup(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		post(router, "Bearer "+token, persistedQuery)
+		require.Equal(t, CircuitOpen, breaker.State())
+
+		timeSource.SetTime(timeSource.Now().Add(time.Minute))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status": "ok", "circuit": "half_open"}`, w.Body.String())
+	})
+
+	t.Run("health checks don't need a token", func(t *testing.T) {
+		router, _, _ := setup(t, succeed)
+
+		for _, path := range []string{LivenessPath, ReadinessPath} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code, path)
+		}
+	})
+
+	t.Run("requires tokens", func(t *testing.T) {
+		_, err := NewHTTPHandler(HTTPHandlerConfig{}, nil, nil, logging.NewMockLogger())
+
+		assert.ErrorIs(t, err, ErrNoTokens)
+	})
+}
+
+func TestHTTPServer_Serve(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	server := &HTTPServer{
+		Server: &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				<-release
+				w.WriteHeader(http.StatusOK)
+			}),
+			ReadHeaderTimeout: time.Second,
+		},
+		ShutdownTimeout: 5 * time.Second,
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(ctx, listener) }()
+
+	responses := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			responses <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		responses <- resp.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	// The request in flight finishes before Serve returns.
+	select {
+	case err := <-served:
+		t.Fatalf("Serve returned before the request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	assert.Equal(t, http.StatusOK, <-responses)
+	assert.NoError(t, <-served)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"time"
 
 	"sgf-meetup-api/pkg/shared/appconfig"
 
@@ -13,13 +14,25 @@ import (
 )
 
 const (
-	meetupPrivateKeyBase64Key = "MEETUP_PRIVATE_KEY_BASE64"
-	meetupPrivateKeyKey       = "MEETUP_PRIVATE_KEY"
-	meetupUserIdKey           = "MEETUP_USER_ID"
-	meetupClientKeyKey        = "MEETUP_CLIENT_KEY"
-	meetupSigningKeyIdKey     = "MEETUP_SIGNING_KEY_ID"
-	meetupAuthUrlKey          = "MEETUP_AUTH_URL"
-	meetupApiUrlKey           = "MEETUP_API_URL"
+	meetupPrivateKeyBase64Key   = "MEETUP_PRIVATE_KEY_BASE64"
+	meetupPrivateKeyKey         = "MEETUP_PRIVATE_KEY"
+	meetupUserIdKey             = "MEETUP_USER_ID"
+	meetupClientKeyKey          = "MEETUP_CLIENT_KEY"
+	meetupClientSecretKey       = "MEETUP_CLIENT_SECRET"
+	meetupSigningKeyIdKey       = "MEETUP_SIGNING_KEY_ID"
+	meetupAuthUrlKey            = "MEETUP_AUTH_URL"
+	meetupApiUrlKey             = "MEETUP_API_URL"
+	responseCacheTableNameKey   = "RESPONSE_CACHE_TABLE_NAME"
+	responseCacheTTLKey         = "RESPONSE_CACHE_TTL"
+	tokenStoreTableNameKey      = "TOKEN_STORE_TABLE_NAME"
+	tokenStoreKMSKeyIDKey       = "TOKEN_STORE_KMS_KEY_ID"
+	allowUnregisteredQueriesKey = "ALLOW_UNREGISTERED_QUERIES"
+	maxQueryBytesKey            = "MAX_QUERY_BYTES"
+	circuitBreakerThresholdKey  = "CIRCUIT_BREAKER_THRESHOLD"
+	circuitBreakerCooldownKey   = "CIRCUIT_BREAKER_COOLDOWN"
+	portKey                     = "MEETUP_PROXY_PORT"
+	tokensKey                   = "MEETUP_PROXY_TOKENS"
+	shutdownTimeoutKey          = "MEETUP_PROXY_SHUTDOWN_TIMEOUT"
 )
 
 var configKeys = []string{
@@ -27,19 +40,53 @@ var configKeys = []string{
 	meetupPrivateKeyKey,
 	meetupUserIdKey,
 	meetupClientKeyKey,
+	meetupClientSecretKey,
 	meetupSigningKeyIdKey,
 	meetupAuthUrlKey,
 	meetupApiUrlKey,
+	responseCacheTableNameKey,
+	responseCacheTTLKey,
+	tokenStoreTableNameKey,
+	tokenStoreKMSKeyIDKey,
+	allowUnregisteredQueriesKey,
+	maxQueryBytesKey,
+	circuitBreakerThresholdKey,
+	circuitBreakerCooldownKey,
+	portKey,
+	tokensKey,
+	shutdownTimeoutKey,
 }
 
 type Config struct {
-	appconfig.Common   `       mapstructure:",squash"`
-	MeetupPrivateKey   []byte `mapstructure:"meetup_private_key"`
-	MeetupUserID       string `mapstructure:"meetup_user_id"`
-	MeetupClientKey    string `mapstructure:"meetup_client_key"`
-	MeetupSigningKeyID string `mapstructure:"meetup_signing_key_id"`
-	MeetupAuthURL      string `mapstructure:"meetup_auth_url"`
-	MeetupAPIURL       string `mapstructure:"meetup_api_url"`
+	appconfig.Common       `       mapstructure:",squash"`
+	MeetupPrivateKey       []byte `mapstructure:"meetup_private_key"`
+	MeetupUserID           string `mapstructure:"meetup_user_id"`
+	MeetupClientKey        string `mapstructure:"meetup_client_key"`
+	MeetupClientSecret     string `mapstructure:"meetup_client_secret"`
+	MeetupSigningKeyID     string `mapstructure:"meetup_signing_key_id"`
+	MeetupAuthURL          string `mapstructure:"meetup_auth_url"`
+	MeetupAPIURL           string `mapstructure:"meetup_api_url"`
+	ResponseCacheTableName string `mapstructure:"response_cache_table_name"`
+	TokenStoreTableName    string `mapstructure:"token_store_table_name"`
+	// TokenStoreKMSKeyID is the KMS key stored Meetup tokens are encrypted with. They're stored
+	// unencrypted without one, which is only meant for local development.
+	TokenStoreKMSKeyID string `mapstructure:"token_store_kms_key_id"`
+	// ResponseCacheTTL is how long Meetup responses are cached for. Zero disables the cache.
+	ResponseCacheTTL time.Duration `mapstructure:"response_cache_ttl"`
+	// AllowUnregisteredQueries passes query text through to Meetup instead of only accepting
+	// persisted query IDs.
+	AllowUnregisteredQueries bool `mapstructure:"allow_unregistered_queries"`
+	// MaxQueryBytes is the longest query text the proxy will send to Meetup.
+	MaxQueryBytes int `mapstructure:"max_query_bytes"`
+	// CircuitBreakerThreshold is how many consecutive Meetup failures stop requests being sent
+	// for CircuitBreakerCooldown. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int           `mapstructure:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  time.Duration `mapstructure:"circuit_breaker_cooldown"`
+	// Port, Tokens and ShutdownTimeout are only used when serving HTTP. Callers must send one
+	// of Tokens as a bearer token.
+	Port            int           `mapstructure:"meetup_proxy_port"`
+	Tokens          []string      `mapstructure:"meetup_proxy_tokens"`
+	ShutdownTimeout time.Duration `mapstructure:"meetup_proxy_shutdown_timeout"`
 }
 
 func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager) (*Config, error) {
@@ -71,6 +118,13 @@ func NewConfig(ctx context.Context, awsConfigFactory appconfig.AwsConfigManager)
 func setDefaults(_ context.Context, v *viper.Viper) error {
 	v.SetDefault(strings.ToLower(meetupAuthUrlKey), "https://secure.meetup.com/oauth2/access")
 	v.SetDefault(strings.ToLower(meetupApiUrlKey), "https://api.meetup.com/gql-ext")
+	v.SetDefault(strings.ToLower(responseCacheTTLKey), "10m")
+	v.SetDefault(strings.ToLower(allowUnregisteredQueriesKey), false)
+	v.SetDefault(strings.ToLower(maxQueryBytesKey), 16*1024)
+	v.SetDefault(strings.ToLower(circuitBreakerThresholdKey), 5)
+	v.SetDefault(strings.ToLower(circuitBreakerCooldownKey), "30s")
+	v.SetDefault(strings.ToLower(portKey), 8091)
+	v.SetDefault(strings.ToLower(shutdownTimeoutKey), "30s")
 
 	meetupPrivateKeyBase64 := v.Get(strings.ToLower(meetupPrivateKeyBase64Key)).(string)
 	meetupPrivateKey, err := base64.StdEncoding.DecodeString(meetupPrivateKeyBase64)
@@ -94,11 +148,41 @@ func (config *Config) validate() error {
 	if config.MeetupSigningKeyID == "" {
 		missing = append(missing, meetupSigningKeyIdKey)
 	}
+	if config.ResponseCacheTableName == "" {
+		missing = append(missing, responseCacheTableNameKey)
+	}
+	if config.TokenStoreTableName == "" {
+		missing = append(missing, tokenStoreTableNameKey)
+	}
 
 	if len(missing) > 0 {
 		return fmt.Errorf("missing required env vars: %v", strings.Join(missing, ", "))
 	}
 
+	if config.ResponseCacheTTL < 0 {
+		return fmt.Errorf("%s must not be negative", responseCacheTTLKey)
+	}
+
+	if config.MaxQueryBytes <= 0 {
+		return fmt.Errorf("%s must be positive", maxQueryBytesKey)
+	}
+
+	if config.CircuitBreakerThreshold < 0 || config.CircuitBreakerCooldown < 0 {
+		return fmt.Errorf(
+			"%s and %s must not be negative",
+			circuitBreakerThresholdKey,
+			circuitBreakerCooldownKey,
+		)
+	}
+
+	if config.Port <= 0 || config.Port > 65535 {
+		return fmt.Errorf("%s must be a port number", portKey)
+	}
+
+	if config.ShutdownTimeout < 0 {
+		return fmt.Errorf("%s must not be negative", shutdownTimeoutKey)
+	}
+
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"sgf-meetup-api/pkg/shared/appconfig"
 
@@ -25,6 +26,8 @@ func TestNewConfig(t *testing.T) {
 		t.Setenv(meetupUserIdKey, "user123")
 		t.Setenv(meetupClientKeyKey, "client123")
 		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
 
 		cfg, err := NewConfig(ctx, awsConfigManager)
 		require.NoError(t, err)
@@ -33,6 +36,136 @@ func TestNewConfig(t *testing.T) {
 		assert.Equal(t, []byte("private_key"), cfg.MeetupPrivateKey)
 		assert.Equal(t, "https://secure.meetup.com/oauth2/access", cfg.MeetupAuthURL)
 		assert.Equal(t, "https://api.meetup.com/gql-ext", cfg.MeetupAPIURL)
+		assert.Equal(t, "response-cache", cfg.ResponseCacheTableName)
+		assert.Equal(t, "token-store", cfg.TokenStoreTableName)
+		assert.Empty(t, cfg.TokenStoreKMSKeyID)
+		assert.Empty(t, cfg.MeetupClientSecret)
+		assert.Equal(t, 10*time.Minute, cfg.ResponseCacheTTL)
+		assert.Equal(t, 16*1024, cfg.MaxQueryBytes)
+		assert.Equal(t, 5, cfg.CircuitBreakerThreshold)
+		assert.Equal(t, 30*time.Second, cfg.CircuitBreakerCooldown)
+		assert.Equal(t, 8091, cfg.Port)
+		assert.Empty(t, cfg.Tokens)
+		assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("parses http server settings", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(portKey, "9000")
+		t.Setenv(tokensKey, "importer-token,debug-token")
+		t.Setenv(shutdownTimeoutKey, "5s")
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(t, 9000, cfg.Port)
+		assert.Equal(t, []string{"importer-token", "debug-token"}, cfg.Tokens)
+		assert.Equal(t, 5*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("parses the response cache ttl", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(responseCacheTTLKey, "90s")
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(t, 90*time.Second, cfg.ResponseCacheTTL)
+	})
+
+	t.Run("parses the token store kms key", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(tokenStoreKMSKeyIDKey, "alias/meetup-tokens")
+
+		cfg, err := NewConfig(ctx, awsConfigManager)
+		require.NoError(t, err)
+
+		assert.Equal(t, "alias/meetup-tokens", cfg.TokenStoreKMSKeyID)
+	})
+
+	t.Run("negative response cache ttl is invalid", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(responseCacheTTLKey, "-1m")
+
+		_, err := NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), responseCacheTTLKey)
+	})
+
+	t.Run("max query bytes must be positive", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(maxQueryBytesKey, "0")
+
+		_, err := NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), maxQueryBytesKey)
+	})
+
+	t.Run("circuit breaker settings must not be negative", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(circuitBreakerCooldownKey, "-1s")
+
+		_, err := NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), circuitBreakerCooldownKey)
+	})
+
+	t.Run("port must be a port number", func(t *testing.T) {
+		switchToTempTestDir(t)
+		validKey := base64.StdEncoding.EncodeToString([]byte("private_key"))
+		t.Setenv(meetupPrivateKeyBase64Key, validKey)
+		t.Setenv(meetupUserIdKey, "user123")
+		t.Setenv(meetupClientKeyKey, "client123")
+		t.Setenv(meetupSigningKeyIdKey, "signing123")
+		t.Setenv(responseCacheTableNameKey, "response-cache")
+		t.Setenv(tokenStoreTableNameKey, "token-store")
+		t.Setenv(portKey, "70000")
+
+		_, err := NewConfig(ctx, awsConfigManager)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), portKey)
 	})
 
 	t.Run("successful load from .env file", func(t *testing.T) {
@@ -45,6 +178,8 @@ func TestNewConfig(t *testing.T) {
 			meetupUserIdKey + "=env_user",
 			meetupClientKeyKey + "=env_client",
 			meetupSigningKeyIdKey + "=env_signing",
+			responseCacheTableNameKey + "=env_cache",
+			tokenStoreTableNameKey + "=env_token_store",
 		}, "\n")
 
 		require.NoError(t, os.WriteFile(envPath, []byte(envContent), 0o600))
@@ -65,6 +200,8 @@ func TestNewConfig(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), meetupUserIdKey)
 		assert.Contains(t, err.Error(), meetupClientKeyKey)
+		assert.Contains(t, err.Error(), responseCacheTableNameKey)
+		assert.Contains(t, err.Error(), tokenStoreTableNameKey)
 	})
 
 	t.Run("invalid base64 in private key", func(t *testing.T) {
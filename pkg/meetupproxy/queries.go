@@ -0,0 +1,222 @@
+package meetupproxy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/google/wire"
+)
+
+// IDs of the persisted queries registered by default. A changed query is registered under a new
+// version alongside the old one so callers can move over to it.
+const (
+	GroupFutureEventsQueryID = "groupFutureEvents@v1"
+	GroupPastEventsQueryID   = "groupPastEvents@v1"
+)
+
+var ErrUnregisteredQuery = errors.New("query is not registered")
+
+// VariableType is the GraphQL scalar type a persisted query's variable takes.
+type VariableType string
+
+const (
+	VariableTypeString  VariableType = "String"
+	VariableTypeID      VariableType = "ID"
+	VariableTypeInt     VariableType = "Int"
+	VariableTypeBoolean VariableType = "Boolean"
+)
+
+type VariableSpec struct {
+	Type     VariableType
+	Required bool
+}
+
+// PersistedQuery is a query callers request by ID instead of sending its text. IDs are the
+// query's name and version, like groupFutureEvents@v1.
+type PersistedQuery struct {
+	ID        string
+	Query     string
+	Variables map[string]VariableSpec
+}
+
+// InvalidVariablesError is returned when a persisted query's variables don't match the ones it
+// declares.
+type InvalidVariablesError struct {
+	QueryID  string
+	Problems []string
+}
+
+func (e *InvalidVariablesError) Error() string {
+	return fmt.Sprintf("invalid variables for %s: %s", e.QueryID, strings.Join(e.Problems, "; "))
+}
+
+// validateVariables checks variables are declared by the query, required ones are set, and each
+// value has its declared type.
+func (q PersistedQuery) validateVariables(variables map[string]any) error {
+	var problems []string
+
+	for name, value := range variables {
+		spec, declared := q.Variables[name]
+		if !declared {
+			problems = append(problems, fmt.Sprintf("%s is not declared", name))
+			continue
+		}
+
+		if value != nil && !spec.Type.accepts(value) {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", name, spec.Type))
+		}
+	}
+
+	for name, spec := range q.Variables {
+		if spec.Required && variables[name] == nil {
+			problems = append(problems, fmt.Sprintf("%s is required", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return &InvalidVariablesError{QueryID: q.ID, Problems: problems}
+	}
+
+	return nil
+}
+
+// accepts reports whether value has the type. Numbers may be any Go integer type or, as they
+// are when decoded from JSON, a float64 without a fractional part.
+func (t VariableType) accepts(value any) bool {
+	switch t {
+	case VariableTypeString, VariableTypeID:
+		_, ok := value.(string)
+		return ok
+	case VariableTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case VariableTypeInt:
+		switch number := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		case float64:
+			return number == math.Trunc(number) &&
+				number >= math.MinInt32 && number <= math.MaxInt32
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+type QueryRegistry struct {
+	queries map[string]PersistedQuery
+}
+
+func NewQueryRegistry(queries ...PersistedQuery) *QueryRegistry {
+	registry := &QueryRegistry{queries: make(map[string]PersistedQuery, len(queries))}
+	for _, query := range queries {
+		registry.queries[query.ID] = query
+	}
+
+	return registry
+}
+
+// NewDefaultQueryRegistry returns a registry of the queries the importer uses.
+func NewDefaultQueryRegistry() *QueryRegistry {
+	return NewQueryRegistry(groupFutureEventsQuery, groupPastEventsQuery)
+}
+
+func (r *QueryRegistry) Lookup(id string) (PersistedQuery, bool) {
+	query, ok := r.queries[id]
+	return query, ok
+}
+
+// groupEventsQuery is formatted with the event status to filter by.
+const groupEventsQuery = `
+  query ($urlname: String!, $itemsNum: Int!, $cursor: String) {
+	groupByUrlname(urlname: $urlname) {
+	  events(first: $itemsNum, after: $cursor, filter: { status: [%s] }) {
+		totalCount
+		pageInfo {
+		  endCursor
+		  hasNextPage
+		}
+		edges {
+		  node {
+			id
+			title
+			eventUrl
+			description
+			dateTime
+			duration
+			createdTime
+			updated
+			going
+			maxTickets
+			eventType
+			onlineVenue {
+			  url
+			}
+			feeSettings {
+			  amount
+			  currency
+			  required
+			}
+			topics {
+			  edges {
+				node {
+				  name
+				}
+			  }
+			}
+			venue {
+			  name
+			  address
+			  city
+			  state
+			  postalCode
+			  lat
+			  lng
+			}
+			group {
+			  name
+			  urlname
+			}
+			eventHosts {
+			  name
+			}
+			featuredEventPhoto {
+			  id
+			  baseUrl
+			}
+			series {
+			  id
+			}
+		  }
+		}
+	  }
+	}
+  }
+`
+
+var groupEventsVariables = map[string]VariableSpec{
+	"urlname":  {Type: VariableTypeString, Required: true},
+	"itemsNum": {Type: VariableTypeInt, Required: true},
+	"cursor":   {Type: VariableTypeString},
+}
+
+var (
+	groupFutureEventsQuery = PersistedQuery{
+		ID:        GroupFutureEventsQueryID,
+		Query:     fmt.Sprintf(groupEventsQuery, "ACTIVE"),
+		Variables: groupEventsVariables,
+	}
+	groupPastEventsQuery = PersistedQuery{
+		ID:        GroupPastEventsQueryID,
+		Query:     fmt.Sprintf(groupEventsQuery, "PAST"),
+		Variables: groupEventsVariables,
+	}
+)
+
+var QueryRegistryProviders = wire.NewSet(NewDefaultQueryRegistry)
@@ -0,0 +1,108 @@
+package meetupproxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryRegistry_Lookup(t *testing.T) {
+	registry := NewDefaultQueryRegistry()
+
+	future, ok := registry.Lookup(GroupFutureEventsQueryID)
+	require.True(t, ok)
+	assert.Contains(t, future.Query, "status: [ACTIVE]")
+
+	past, ok := registry.Lookup(GroupPastEventsQueryID)
+	require.True(t, ok)
+	assert.Contains(t, past.Query, "status: [PAST]")
+
+	_, ok = registry.Lookup("groupFutureEvents")
+	assert.False(t, ok)
+}
+
+func TestPersistedQuery_ValidateVariables(t *testing.T) {
+	query := PersistedQuery{
+		ID: "test@v1",
+		Variables: map[string]VariableSpec{
+			"name":   {Type: VariableTypeString, Required: true},
+			"id":     {Type: VariableTypeID},
+			"count":  {Type: VariableTypeInt},
+			"active": {Type: VariableTypeBoolean},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		variables map[string]any
+		problems  []string
+	}{
+		{
+			name: "valid",
+			variables: map[string]any{
+				"name":   "sgfdevs",
+				"id":     "123",
+				"count":  float64(50),
+				"active": true,
+			},
+		},
+		{
+			name:      "go integers",
+			variables: map[string]any{"name": "sgfdevs", "count": 50},
+		},
+		{
+			name:      "null optional variables",
+			variables: map[string]any{"name": "sgfdevs", "count": nil},
+		},
+		{
+			name:      "missing required variable",
+			variables: map[string]any{"count": 50},
+			problems:  []string{"name is required"},
+		},
+		{
+			name:      "null required variable",
+			variables: map[string]any{"name": nil},
+			problems:  []string{"name is required"},
+		},
+		{
+			name:      "undeclared variable",
+			variables: map[string]any{"name": "sgfdevs", "extra": "value"},
+			problems:  []string{"extra is not declared"},
+		},
+		{
+			name: "wrong types",
+			variables: map[string]any{
+				"name":   50,
+				"count":  1.5,
+				"active": "true",
+			},
+			problems: []string{
+				"active must be of type Boolean",
+				"count must be of type Int",
+				"name must be of type String",
+			},
+		},
+		{
+			name:      "int out of range",
+			variables: map[string]any{"name": "sgfdevs", "count": float64(1 << 40)},
+			problems:  []string{"count must be of type Int"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := query.validateVariables(tt.variables)
+
+			if tt.problems == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var invalidErr *InvalidVariablesError
+			require.ErrorAs(t, err, &invalidErr)
+			assert.Equal(t, "test@v1", invalidErr.QueryID)
+			assert.Equal(t, tt.problems, invalidErr.Problems)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package meetupproxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/db"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+type ResponseCache interface {
+	// Get returns the cached response for key, or nil if there isn't one or it has expired.
+	Get(ctx context.Context, key string) (*Response, error)
+	Put(ctx context.Context, key string, resp *Response, expiresAt time.Time) error
+}
+
+// cacheKey identifies a request by its query with whitespace collapsed and its variables, so
+// the same query formatted differently shares a cache entry.
+func cacheKey(req Request) (string, error) {
+	// Map keys are marshalled in sorted order, so equal variables always produce equal JSON.
+	variables, err := json.Marshal(req.Variables)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(strings.Join(strings.Fields(req.Query), " ")))
+	hash.Write([]byte{0})
+	hash.Write(variables)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// cachedResponse is a response cache item. ExpiresAt is in unix seconds since it's the table's
+// TTL attribute.
+type cachedResponse struct {
+	CacheKey  string    `dynamodbav:"cacheKey"`
+	Response  string    `dynamodbav:"response"`
+	CachedAt  time.Time `dynamodbav:"cachedAt"`
+	ExpiresAt int64     `dynamodbav:"expiresAt"`
+}
+
+type DynamoDBResponseCacheConfig struct {
+	ResponseCacheTableName string
+}
+
+func NewDynamoDBResponseCacheConfig(
+	config *meetupproxyconfig.Config,
+) DynamoDBResponseCacheConfig {
+	return DynamoDBResponseCacheConfig{
+		ResponseCacheTableName: config.ResponseCacheTableName,
+	}
+}
+
+type DynamoDBResponseCache struct {
+	config     DynamoDBResponseCacheConfig
+	db         *db.Client
+	timeSource clock.TimeSource
+}
+
+func NewDynamoDBResponseCache(
+	config DynamoDBResponseCacheConfig,
+	db *db.Client,
+	timeSource clock.TimeSource,
+) *DynamoDBResponseCache {
+	return &DynamoDBResponseCache{
+		config:     config,
+		db:         db,
+		timeSource: timeSource,
+	}
+}
+
+func (c *DynamoDBResponseCache) Get(ctx context.Context, key string) (*Response, error) {
+	result, err := c.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(c.config.ResponseCacheTableName),
+		Key: map[string]types.AttributeValue{
+			"cacheKey": &types.AttributeValueMemberS{Value: key},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var item cachedResponse
+	if err = attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, err
+	}
+
+	// DynamoDB can take a while to delete expired items, so they're checked here too.
+	if c.timeSource.Now().Unix() >= item.ExpiresAt {
+		return nil, nil
+	}
+
+	var resp Response
+	if err = json.Unmarshal([]byte(item.Response), &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *DynamoDBResponseCache) Put(
+	ctx context.Context,
+	key string,
+	resp *Response,
+	expiresAt time.Time,
+) error {
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	item, err := attributevalue.MarshalMap(cachedResponse{
+		CacheKey:  key,
+		Response:  string(respJSON),
+		CachedAt:  c.timeSource.Now(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(c.config.ResponseCacheTableName),
+		Item:      item,
+	})
+
+	return err
+}
+
+var ResponseCacheProviders = wire.NewSet(
+	wire.Bind(new(ResponseCache), new(*DynamoDBResponseCache)),
+	NewDynamoDBResponseCacheConfig,
+	NewDynamoDBResponseCache,
+)
@@ -0,0 +1,68 @@
+package meetupproxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDynamoDBResponseCacheConfig(t *testing.T) {
+	cfg := &meetupproxyconfig.Config{ResponseCacheTableName: "response-cache"}
+
+	cacheConfig := NewDynamoDBResponseCacheConfig(cfg)
+
+	assert.Equal(t, cfg.ResponseCacheTableName, cacheConfig.ResponseCacheTableName)
+}
+
+func TestDynamoDBResponseCache(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	timeSource := clock.NewMockTimeSource(now)
+
+	cache := NewDynamoDBResponseCache(
+		DynamoDBResponseCacheConfig{
+			ResponseCacheTableName: *infra.ResponseCacheTableProps.TableName,
+		},
+		testDB.Client,
+		timeSource,
+	)
+
+	t.Run("returns nil for uncached keys", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		resp, err := cache.Get(ctx, "missing")
+		require.NoError(t, err)
+
+		assert.Nil(t, resp)
+	})
+
+	t.Run("returns cached responses until they expire", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+		defer timeSource.Reset()
+
+		cached := &Response{"data": map[string]any{"groupByUrlname": map[string]any{"id": "1"}}}
+		require.NoError(t, cache.Put(ctx, "key", cached, now.Add(time.Minute)))
+
+		resp, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, cached, resp)
+
+		timeSource.SetTime(now.Add(time.Minute))
+
+		resp, err = cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+}
@@ -0,0 +1,154 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests to Meetup are retried. Delays grow exponentially
+// from BaseDelay up to MaxDelay with full jitter unless Meetup says how long to wait, and no
+// retry is started that would end after Timeout has elapsed or the context's deadline. The zero
+// value doesn't retry.
+type RetryPolicy struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Timeout   time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	BaseDelay: 250 * time.Millisecond,
+	MaxDelay:  10 * time.Second,
+	Timeout:   30 * time.Second,
+}
+
+// retryableError marks a failed attempt as worth retrying. wait is how long Meetup asked us to
+// wait first, or zero to back off.
+type retryableError struct {
+	err  error
+	wait time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// retry calls attempt until it succeeds or fails with an error that isn't retryable. Once
+// there's no time left to retry, the last attempt's error is returned, or the one before it if
+// the context ended the last attempt.
+func (p RetryPolicy) retry(
+	ctx context.Context,
+	onRetry func(err error, wait time.Duration),
+	attempt func(ctx context.Context) error,
+) error {
+	deadline := time.Now().Add(p.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	var lastErr error
+	for i := 0; ; i++ {
+		err := attempt(ctx)
+		if err != nil && ctx.Err() != nil && lastErr != nil {
+			return lastErr
+		}
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return err
+		}
+
+		wait := retryable.wait
+		if wait <= 0 {
+			wait = p.delay(i)
+		}
+
+		if !time.Now().Add(wait).Before(deadline) {
+			return retryable.err
+		}
+
+		lastErr = retryable.err
+		onRetry(retryable.err, wait)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.MaxDelay
+	if attempt < 32 {
+		if exponential := p.BaseDelay << attempt; exponential > 0 && exponential < p.MaxDelay {
+			backoff = exponential
+		}
+	}
+
+	return rand.N(backoff + 1)
+}
+
+// isTransientStatus reports whether a response status is a server failure that may not happen
+// again.
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns how long the response asks clients to wait, from its Retry-After header
+// or, once the rate limit has been used up, Meetup's X-RateLimit-Reset header. It's zero when
+// neither is set.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0)
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0)
+		}
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if seconds, err := strconv.Atoi(header.Get("X-RateLimit-Reset")); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0)
+		}
+	}
+
+	return 0
+}
+
+// graphQLRateLimit reports whether Meetup rejected a query for exceeding its rate limit, along
+// with how long until the limit resets if it said.
+func graphQLRateLimit(resp Response, now time.Time) (time.Duration, bool) {
+	graphQLErrors, _ := resp["errors"].([]any)
+	for _, graphQLError := range graphQLErrors {
+		fields, _ := graphQLError.(map[string]any)
+		extensions, _ := fields["extensions"].(map[string]any)
+		if extensions["code"] != "RATE_LIMITED" {
+			continue
+		}
+
+		resetAt, _ := extensions["resetAt"].(string)
+		if resetTime, err := time.Parse(time.RFC3339, resetAt); err == nil {
+			return max(resetTime.Sub(now), 0), true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,198 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	BaseDelay: time.Millisecond,
+	MaxDelay:  5 * time.Millisecond,
+	Timeout:   time.Second,
+}
+
+func TestRetryPolicy_Retry(t *testing.T) {
+	noRetryLog := func(error, time.Duration) {}
+
+	t.Run("retries until the attempt succeeds", func(t *testing.T) {
+		attempts := 0
+		err := testRetryPolicy.retry(context.Background(), noRetryLog, func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return &retryableError{err: errors.New("transient")}
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		attempts := 0
+		err := testRetryPolicy.retry(context.Background(), noRetryLog, func(context.Context) error {
+			attempts++
+			return errors.New("permanent")
+		})
+
+		assert.EqualError(t, err, "permanent")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("returns the last error when the wait would pass the deadline", func(t *testing.T) {
+		attempts := 0
+		throttled := &ThrottledError{RetryAfter: time.Minute}
+		err := testRetryPolicy.retry(context.Background(), noRetryLog, func(context.Context) error {
+			attempts++
+			return &retryableError{err: throttled, wait: time.Minute}
+		})
+
+		assert.Same(t, throttled, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("stops at the context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		policy := RetryPolicy{
+			BaseDelay: 5 * time.Millisecond,
+			MaxDelay:  5 * time.Millisecond,
+			Timeout:   time.Minute,
+		}
+
+		start := time.Now()
+		err := policy.retry(ctx, noRetryLog, func(context.Context) error {
+			return &retryableError{err: errors.New("transient")}
+		})
+
+		assert.EqualError(t, err, "transient")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("returns the previous error when the deadline ends an attempt", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		transient := errors.New("transient")
+		attempts := 0
+		err := testRetryPolicy.retry(ctx, noRetryLog, func(ctx context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return &retryableError{err: transient}
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		assert.Same(t, transient, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("zero policy doesn't retry", func(t *testing.T) {
+		attempts := 0
+		err := RetryPolicy{}.retry(context.Background(), noRetryLog, func(context.Context) error {
+			attempts++
+			return &retryableError{err: errors.New("transient")}
+		})
+
+		assert.EqualError(t, err, "transient")
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{name: "no headers", header: http.Header{}, want: 0},
+		{
+			name:   "retry after seconds",
+			header: http.Header{"Retry-After": {"30"}},
+			want:   30 * time.Second,
+		},
+		{
+			name:   "retry after date",
+			header: http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}},
+			want:   time.Minute,
+		},
+		{
+			name:   "retry after date in the past",
+			header: http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}},
+			want:   0,
+		},
+		{
+			name: "rate limit used up",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"12"},
+			},
+			want: 12 * time.Second,
+		},
+		{
+			name: "rate limit remaining",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"4"},
+				"X-Ratelimit-Reset":     {"12"},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryAfter(tt.header, now))
+		})
+	}
+}
+
+func TestGraphQLRateLimit(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+
+	rateLimitError := func(extensions map[string]any) Response {
+		return Response{"errors": []any{
+			map[string]any{"message": "Too many requests", "extensions": extensions},
+		}}
+	}
+
+	t.Run("not rate limited", func(t *testing.T) {
+		_, rateLimited := graphQLRateLimit(Response{"data": map[string]any{}}, now)
+		assert.False(t, rateLimited)
+
+		_, rateLimited = graphQLRateLimit(
+			rateLimitError(map[string]any{"code": "NOT_FOUND"}),
+			now,
+		)
+		assert.False(t, rateLimited)
+	})
+
+	t.Run("rate limited with reset time", func(t *testing.T) {
+		resetIn, rateLimited := graphQLRateLimit(rateLimitError(map[string]any{
+			"code":    "RATE_LIMITED",
+			"resetAt": now.Add(45 * time.Second).Format(time.RFC3339),
+		}), now)
+
+		assert.True(t, rateLimited)
+		assert.Equal(t, 45*time.Second, resetIn)
+	})
+
+	t.Run("rate limited without reset time", func(t *testing.T) {
+		resetIn, rateLimited := graphQLRateLimit(
+			rateLimitError(map[string]any{"code": "RATE_LIMITED"}),
+			now,
+		)
+
+		assert.True(t, rateLimited)
+		assert.Zero(t, resetIn)
+	})
+}
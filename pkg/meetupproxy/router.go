@@ -0,0 +1,83 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+
+	"github.com/gin-gonic/gin"
+	sloggin "github.com/samber/slog-gin"
+)
+
+func NewRouter(logger *slog.Logger, handler *HTTPHandler) *gin.Engine {
+	r := gin.New()
+
+	r.Use(sloggin.New(logger.WithGroup("http")))
+	r.Use(gin.Recovery())
+
+	handler.RegisterRoutes(r)
+
+	return r
+}
+
+// HTTPServer is the proxy's HTTP server along with how long it waits for requests in flight
+// when shutting down.
+type HTTPServer struct {
+	*http.Server
+	ShutdownTimeout time.Duration
+}
+
+func NewHTTPServer(config *meetupproxyconfig.Config, router *gin.Engine) *HTTPServer {
+	return &HTTPServer{
+		Server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.Port),
+			Handler:           router,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		ShutdownTimeout: config.ShutdownTimeout,
+	}
+}
+
+// Serve accepts connections on listener until ctx is done, then stops accepting new requests
+// and waits up to ShutdownTimeout for the ones in flight to finish.
+func (s *HTTPServer) Serve(ctx context.Context, listener net.Listener) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// ListenAndServe listens on the server's address and serves until ctx is done.
+func (s *HTTPServer) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(ctx, listener)
+}
@@ -1,26 +1,50 @@
 package meetupproxy
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"strings"
+	"time"
 
 	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+
+	"golang.org/x/sync/singleflight"
 )
 
-const userAgent = "curl/8.7.1"
+const (
+	userAgent = "curl/8.7.1"
+	// fetchTimeout bounds a call to Meetup shared by concurrent requests, which doesn't end
+	// when any one of their contexts does. It's under the Lambda's 60 second timeout.
+	fetchTimeout = 50 * time.Second
+	// fetchDeadlineMargin ends a shared fetch before the deadline of the request that started
+	// it, leaving time to return Meetup's error rather than the request timing out.
+	fetchDeadlineMargin = 100 * time.Millisecond
+)
 
 type ServiceConfig struct {
-	URL string
+	URL         string
+	CacheTTL    time.Duration
+	RetryPolicy RetryPolicy
+	// AllowUnregisteredQueries passes requests' query text through to Meetup. Otherwise only
+	// persisted queries can be requested.
+	AllowUnregisteredQueries bool
+	// MaxQueryBytes is the longest query text that's accepted. Zero doesn't limit it.
+	MaxQueryBytes int
 }
 
 func NewServiceConfig(config *meetupproxyconfig.Config) ServiceConfig {
 	return ServiceConfig{
-		URL: config.MeetupAPIURL,
+		URL:                      config.MeetupAPIURL,
+		CacheTTL:                 config.ResponseCacheTTL,
+		RetryPolicy:              DefaultRetryPolicy,
+		AllowUnregisteredQueries: config.AllowUnregisteredQueries,
+		MaxQueryBytes:            config.MaxQueryBytes,
 	}
 }
 
@@ -29,49 +53,257 @@ type Service struct {
 	logger     *slog.Logger
 	httpClient *http.Client
 	auth       AuthHandler
+	cache      ResponseCache
+	queries    *QueryRegistry
+	breaker    *CircuitBreaker
+	timeSource clock.TimeSource
+	requests   singleflight.Group
 }
 
 func NewService(
 	config ServiceConfig,
 	httpClient *http.Client,
 	auth AuthHandler,
+	cache ResponseCache,
+	queries *QueryRegistry,
+	breaker *CircuitBreaker,
+	timeSource clock.TimeSource,
 	logger *slog.Logger,
 ) *Service {
 	return &Service{
 		config:     config,
 		httpClient: httpClient,
 		auth:       auth,
+		cache:      cache,
+		queries:    queries,
+		breaker:    breaker,
+		timeSource: timeSource,
 		logger:     logger,
 	}
 }
 
 type Request struct {
-	Query     string         `json:"query"`
+	// QueryID names the persisted query to run. Query is only used when it's empty.
+	QueryID   string         `json:"queryId,omitempty"`
+	Query     string         `json:"query,omitempty"`
 	Variables map[string]any `json:"variables"`
+	// Refresh skips the cache and fetches from Meetup, caching the new response.
+	Refresh bool `json:"refresh,omitempty"`
 }
 
 type Response map[string]any
 
+// HandleRequest returns Meetup's response to the request, from the cache when it has one.
+// Identical requests made while one is in flight share its response rather than each calling
+// Meetup. Invalid requests are rejected with a RequestError before a token is fetched.
 func (s *Service) HandleRequest(ctx context.Context, req Request) (*Response, error) {
+	req, err := s.resolveQuery(req)
+	if err == nil {
+		err = s.validateQuery(req.Query)
+	}
+	if err != nil {
+		s.logger.Warn("rejected meetup proxy request", "queryId", req.QueryID, "err", err)
+		return nil, err
+	}
+
+	key, err := cacheKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Refresh {
+		if resp := s.cachedResponse(ctx, key); resp != nil {
+			return resp, nil
+		}
+	}
+
+	// Refreshes don't share a call started without one, since it may return a cached response.
+	flightKey := key
+	if req.Refresh {
+		flightKey = "refresh#" + key
+	}
+
+	// The fetch is shared with every request that joins it, so it isn't canceled with this
+	// request. Each caller still stops waiting when its own context ends.
+	flight := s.requests.DoChan(flightKey, func() (any, error) {
+		fetchCtx, cancel := detachedContext(ctx)
+		defer cancel()
+
+		resp, err := s.fetch(fetchCtx, req)
+		if err != nil || resp == nil {
+			return resp, err
+		}
+
+		s.cacheResponse(fetchCtx, key, resp)
+
+		return resp, nil
+	})
+
+	var result singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result = <-flight:
+	}
+
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	if result.Shared {
+		s.logger.Debug("shared meetup response with concurrent request", "cacheKey", key)
+	}
+
+	return result.Val.(*Response), nil
+}
+
+// detachedContext returns a context that isn't canceled with ctx but ends just before its
+// deadline, capped at fetchTimeout, so retries still stop in time to return Meetup's error
+// within the deadline.
+func detachedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline := time.Now().Add(fetchTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok {
+		if ctxDeadline = ctxDeadline.Add(-fetchDeadlineMargin); ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+	}
+
+	return context.WithDeadline(context.WithoutCancel(ctx), deadline)
+}
+
+// resolveQuery sets the request's query to the text of the persisted query it names, after
+// checking its variables. Requests without a query ID are rejected unless unregistered queries
+// are allowed.
+func (s *Service) resolveQuery(req Request) (Request, error) {
+	if req.QueryID == "" {
+		if !s.config.AllowUnregisteredQueries {
+			return req, &RequestError{
+				Code: RequestErrorUnregisteredQuery,
+				Err:  fmt.Errorf("%w: requests must set a query id", ErrUnregisteredQuery),
+			}
+		}
+		return req, nil
+	}
+
+	query, ok := s.queries.Lookup(req.QueryID)
+	if !ok {
+		return req, &RequestError{
+			Code: RequestErrorUnregisteredQuery,
+			Err:  fmt.Errorf("%w: %s", ErrUnregisteredQuery, req.QueryID),
+		}
+	}
+
+	if err := query.validateVariables(req.Variables); err != nil {
+		return req, &RequestError{Code: RequestErrorInvalidVariables, Err: err}
+	}
+
+	req.Query = query.Query
+
+	return req, nil
+}
+
+func (s *Service) cachedResponse(ctx context.Context, key string) *Response {
+	if s.config.CacheTTL == 0 {
+		return nil
+	}
+
+	resp, err := s.cache.Get(ctx, key)
+	if err != nil {
+		s.logger.Warn("failed to read cached meetup response", "cacheKey", key, "err", err)
+		return nil
+	}
+
+	if resp != nil {
+		s.logger.Debug("using cached meetup response", "cacheKey", key)
+	}
+
+	return resp
+}
+
+// cacheResponse caches successful responses. Responses with GraphQL errors aren't cached so
+// the next request tries again.
+func (s *Service) cacheResponse(ctx context.Context, key string, resp *Response) {
+	if s.config.CacheTTL == 0 {
+		return
+	}
+
+	if _, hasErrors := (*resp)["errors"]; hasErrors {
+		return
+	}
+
+	expiresAt := s.timeSource.Now().Add(s.config.CacheTTL)
+	if err := s.cache.Put(ctx, key, resp, expiresAt); err != nil {
+		s.logger.Warn("failed to cache meetup response", "cacheKey", key, "err", err)
+	}
+}
+
+// fetch requests the query from Meetup, retrying rate limited and transient failures while
+// there's time left. Retries stop as soon as the circuit breaker opens.
+func (s *Service) fetch(ctx context.Context, req Request) (*Response, error) {
 	token, err := s.auth.GetAccessToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.Query == "" {
+	reqBodyJson, err := json.Marshal(struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}{
+		Query:     req.Query,
+		Variables: req.Variables,
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	reqBodyJson, err := json.Marshal(req)
+	var resp *Response
+	err = s.config.RetryPolicy.retry(
+		ctx,
+		func(err error, wait time.Duration) {
+			s.logger.Warn("retrying meetup request", "err", err, "wait", wait)
+		},
+		func(ctx context.Context) error {
+			return s.breaker.call(ctx, func(ctx context.Context) error {
+				resp, err = s.send(ctx, token, reqBodyJson)
+				return err
+			})
+		},
+	)
+
+	var upstreamErr *UpstreamError
+	var throttledErr *ThrottledError
+	var circuitOpenErr *CircuitOpenError
+	switch {
+	case errors.As(err, &upstreamErr):
+		s.logger.Error(
+			"Error fetching data from meetup",
+			"statusCode",
+			upstreamErr.StatusCode,
+			"body",
+			upstreamErr.body,
+			"err",
+			upstreamErr.Err,
+		)
+	case errors.As(err, &throttledErr):
+		s.logger.Warn("meetup rate limit exceeded", "retryAfter", throttledErr.RetryAfter)
+	case errors.As(err, &circuitOpenErr):
+		s.logger.Warn("meetup circuit breaker is open", "retryAfter", circuitOpenErr.RetryAfter)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	return resp, nil
+}
+
+// send makes a single request to Meetup. Failures that may succeed later are returned as
+// retryable errors.
+func (s *Service) send(ctx context.Context, token string, body []byte) (*Response, error) {
 	meetupReq, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
 		s.config.URL,
-		strings.NewReader(string(reqBodyJson)),
+		bytes.NewReader(body),
 	)
 	if err != nil {
 		return nil, err
@@ -84,21 +316,31 @@ func (s *Service) HandleRequest(ctx context.Context, req Request) (*Response, er
 
 	meetupResp, err := s.httpClient.Do(meetupReq)
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, &retryableError{err: &UpstreamError{Transient: true, Err: err}}
 	}
 
 	defer func() { _ = meetupResp.Body.Close() }()
 
+	wait := retryAfter(meetupResp.Header, s.timeSource.Now())
+
+	if meetupResp.StatusCode == http.StatusTooManyRequests {
+		return nil, &retryableError{err: &ThrottledError{RetryAfter: wait}, wait: wait}
+	}
+
 	if meetupResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(meetupResp.Body)
-		s.logger.Error(
-			"Error fetching data from meetup",
-			"statusCode",
-			meetupResp.StatusCode,
-			"body",
-			string(body),
-		)
-		return nil, fmt.Errorf("expected status code 200, got %v", meetupResp.StatusCode)
+		respBody, _ := io.ReadAll(meetupResp.Body)
+		upstreamErr := &UpstreamError{
+			StatusCode: meetupResp.StatusCode,
+			Transient:  isTransientStatus(meetupResp.StatusCode),
+			body:       string(respBody),
+		}
+		if upstreamErr.Transient {
+			return nil, &retryableError{err: upstreamErr, wait: wait}
+		}
+		return nil, upstreamErr
 	}
 
 	var resp Response
@@ -107,5 +349,10 @@ func (s *Service) HandleRequest(ctx context.Context, req Request) (*Response, er
 		return nil, err
 	}
 
+	if resetIn, rateLimited := graphQLRateLimit(resp, s.timeSource.Now()); rateLimited {
+		wait = max(wait, resetIn)
+		return nil, &retryableError{err: &ThrottledError{RetryAfter: wait}, wait: wait}
+	}
+
 	return &resp, nil
 }
@@ -7,10 +7,14 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
 	"sgf-meetup-api/pkg/shared/logging"
 
 	"github.com/stretchr/testify/assert"
@@ -19,12 +23,20 @@ import (
 
 func TestNewServiceConfig(t *testing.T) {
 	cfg := &meetupproxyconfig.Config{
-		MeetupAPIURL: "https://example.com",
+		MeetupAPIURL:             "https://example.com",
+		ResponseCacheTTL:         5 * time.Minute,
+		AllowUnregisteredQueries: true,
 	}
 
+	cfg.MaxQueryBytes = 1024
+
 	serviceConfig := NewServiceConfig(cfg)
 
 	assert.Equal(t, cfg.MeetupAPIURL, serviceConfig.URL)
+	assert.Equal(t, cfg.ResponseCacheTTL, serviceConfig.CacheTTL)
+	assert.Equal(t, DefaultRetryPolicy, serviceConfig.RetryPolicy)
+	assert.True(t, serviceConfig.AllowUnregisteredQueries)
+	assert.Equal(t, 1024, serviceConfig.MaxQueryBytes)
 }
 
 type mockAuth struct {
@@ -48,9 +60,16 @@ func TestService_HandleRequest_Success(t *testing.T) {
 	defer ts.Close()
 
 	proxy := NewService(
-		ServiceConfig{ts.URL},
+		ServiceConfig{
+			URL:                      ts.URL,
+			AllowUnregisteredQueries: true,
+		},
 		&http.Client{},
 		&mockAuth{token: "valid-token"},
+		newMockCache(),
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		clock.NewMockTimeSource(time.Now()),
 		logging.NewMockLogger(),
 	)
 
@@ -64,13 +83,20 @@ func TestService_HandleRequest_Success(t *testing.T) {
 func TestService_HandleRequest_AuthFailure(t *testing.T) {
 	auth := &mockAuth{err: fmt.Errorf("auth error")}
 	proxy := NewService(
-		ServiceConfig{"https://testurl"},
+		ServiceConfig{
+			URL:                      "https://testurl",
+			AllowUnregisteredQueries: true,
+		},
 		&http.Client{},
 		auth,
+		newMockCache(),
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		clock.NewMockTimeSource(time.Now()),
 		logging.NewMockLogger(),
 	)
 
-	_, err := proxy.HandleRequest(context.Background(), Request{})
+	_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
 
 	require.Error(t, err)
 
@@ -85,9 +111,16 @@ func TestService_HandleRequest_InvalidJSONResponse(t *testing.T) {
 	defer ts.Close()
 
 	proxy := NewService(
-		ServiceConfig{ts.URL},
+		ServiceConfig{
+			URL:                      ts.URL,
+			AllowUnregisteredQueries: true,
+		},
 		&http.Client{},
 		&mockAuth{token: "valid"},
+		newMockCache(),
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		clock.NewMockTimeSource(time.Now()),
 		logging.NewMockLogger(),
 	)
 
@@ -121,9 +154,16 @@ func TestService_HandleRequest_InvalidStatus(t *testing.T) {
 			defer ts.Close()
 
 			proxy := NewService(
-				ServiceConfig{ts.URL},
+				ServiceConfig{
+					URL:                      ts.URL,
+					AllowUnregisteredQueries: true,
+				},
 				&http.Client{},
 				&mockAuth{token: "valid"},
+				newMockCache(),
+				NewDefaultQueryRegistry(),
+				noCircuitBreaker(),
+				clock.NewMockTimeSource(time.Now()),
 				slog.New(handler),
 			)
 
@@ -153,9 +193,16 @@ func TestService_HandleRequest_Timeout(t *testing.T) {
 	defer cancel()
 
 	proxy := NewService(
-		ServiceConfig{ts.URL},
+		ServiceConfig{
+			URL:                      ts.URL,
+			AllowUnregisteredQueries: true,
+		},
 		&http.Client{},
 		&mockAuth{token: "valid-token"},
+		newMockCache(),
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		clock.NewMockTimeSource(time.Now()),
 		logging.NewMockLogger(),
 	)
 
@@ -165,3 +212,668 @@ func TestService_HandleRequest_Timeout(t *testing.T) {
 
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
+
+func TestService_HandleRequest_PersistedQueries(t *testing.T) {
+	validVariables := map[string]any{"urlname": "sgfdevs", "itemsNum": float64(50)}
+
+	setup := func(t *testing.T, allowUnregistered bool) (*Service, *[]string) {
+		t.Helper()
+
+		var queries []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Query string `json:"query"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			queries = append(queries, body.Query)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": "success"})
+		}))
+		t.Cleanup(ts.Close)
+
+		proxy := NewService(
+			ServiceConfig{URL: ts.URL, AllowUnregisteredQueries: allowUnregistered},
+			&http.Client{},
+			&mockAuth{token: "valid-token"},
+			newMockCache(),
+			NewDefaultQueryRegistry(),
+			noCircuitBreaker(),
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+
+		return proxy, &queries
+	}
+
+	t.Run("sends the registered query text", func(t *testing.T) {
+		proxy, queries := setup(t, false)
+
+		resp, err := proxy.HandleRequest(context.Background(), Request{
+			QueryID:   GroupFutureEventsQueryID,
+			Variables: validVariables,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "success", (*resp)["data"])
+		assert.Equal(t, []string{groupFutureEventsQuery.Query}, *queries)
+	})
+
+	t.Run("the query id takes precedence over query text", func(t *testing.T) {
+		proxy, queries := setup(t, true)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{
+			QueryID:   GroupPastEventsQueryID,
+			Query:     "query() {}",
+			Variables: validVariables,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{groupPastEventsQuery.Query}, *queries)
+	})
+
+	t.Run("rejects unknown query ids", func(t *testing.T) {
+		proxy, queries := setup(t, true)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{
+			QueryID:   "groupFutureEvents@v0",
+			Variables: validVariables,
+		})
+
+		assert.ErrorIs(t, err, ErrUnregisteredQuery)
+		assert.ErrorContains(t, err, "groupFutureEvents@v0")
+		assertRequestError(t, err, RequestErrorUnregisteredQuery)
+		assert.Empty(t, *queries)
+	})
+
+	t.Run("rejects query text unless unregistered queries are allowed", func(t *testing.T) {
+		proxy, queries := setup(t, false)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+
+		assert.ErrorIs(t, err, ErrUnregisteredQuery)
+		assert.Empty(t, *queries)
+	})
+
+	t.Run("rejects invalid variables", func(t *testing.T) {
+		proxy, queries := setup(t, false)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{
+			QueryID:   GroupFutureEventsQueryID,
+			Variables: map[string]any{"urlname": "sgfdevs", "itemsNum": "50"},
+		})
+
+		var invalidErr *InvalidVariablesError
+		require.ErrorAs(t, err, &invalidErr)
+		assert.Equal(t, GroupFutureEventsQueryID, invalidErr.QueryID)
+		assert.Equal(t, []string{"itemsNum must be of type Int"}, invalidErr.Problems)
+		assertRequestError(t, err, RequestErrorInvalidVariables)
+		assert.Empty(t, *queries)
+	})
+}
+
+func TestService_HandleRequest_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		code  RequestErrorCode
+	}{
+		{name: "empty query", query: "", code: RequestErrorEmptyQuery},
+		{name: "blank query", query: " \n\t", code: RequestErrorEmptyQuery},
+		{
+			name:  "oversized query",
+			query: "query { " + strings.Repeat("id ", 50) + "}",
+			code:  RequestErrorQueryTooLarge,
+		},
+		{
+			name:  "mutation",
+			query: "mutation { deleteEvent(id: 1) { id } }",
+			code:  RequestErrorOperationNotAllowed,
+		},
+		{
+			name:  "mutation after a query",
+			query: "query A { self { id } } mutation B { deleteEvent(id: 1) { id } }",
+			code:  RequestErrorOperationNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &mockAuth{err: fmt.Errorf("auth should not be called")}
+			proxy := NewService(
+				ServiceConfig{
+					URL:                      "http://127.0.0.1:0",
+					AllowUnregisteredQueries: true,
+					MaxQueryBytes:            100,
+				},
+				&http.Client{},
+				auth,
+				newMockCache(),
+				NewDefaultQueryRegistry(),
+				noCircuitBreaker(),
+				clock.NewMockTimeSource(time.Now()),
+				logging.NewMockLogger(),
+			)
+
+			resp, err := proxy.HandleRequest(context.Background(), Request{Query: tt.query})
+
+			assert.Nil(t, resp)
+			assertRequestError(t, err, tt.code)
+		})
+	}
+}
+
+func assertRequestError(t *testing.T, err error, code RequestErrorCode) {
+	t.Helper()
+
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, code, requestErr.Code)
+	assert.True(t, strings.HasPrefix(err.Error(), string(code)+": "), err.Error())
+}
+
+type mockCache struct {
+	lock      sync.Mutex
+	responses map[string]*Response
+	expiresAt map[string]time.Time
+	gets      atomic.Int32
+	err       error
+}
+
+func newMockCache() *mockCache {
+	return &mockCache{
+		responses: map[string]*Response{},
+		expiresAt: map[string]time.Time{},
+	}
+}
+
+func (m *mockCache) Get(ctx context.Context, key string) (*Response, error) {
+	m.gets.Add(1)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.responses[key], m.err
+}
+
+func (m *mockCache) Put(
+	ctx context.Context,
+	key string,
+	resp *Response,
+	expiresAt time.Time,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.responses[key] = resp
+	m.expiresAt[key] = expiresAt
+	return nil
+}
+
+func TestService_HandleRequest_Cache(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T, body map[string]any) (*Service, *mockCache, *atomic.Int32) {
+		t.Helper()
+
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			_ = json.NewEncoder(w).Encode(body)
+		}))
+		t.Cleanup(ts.Close)
+
+		cache := newMockCache()
+
+		proxy := NewService(
+			ServiceConfig{
+				URL:                      ts.URL,
+				CacheTTL:                 5 * time.Minute,
+				AllowUnregisteredQueries: true,
+			},
+			&http.Client{},
+			&mockAuth{token: "valid-token"},
+			cache,
+			NewDefaultQueryRegistry(),
+			noCircuitBreaker(),
+			clock.NewMockTimeSource(now),
+			logging.NewMockLogger(),
+		)
+
+		return proxy, cache, &calls
+	}
+
+	t.Run("repeated queries use the cached response", func(t *testing.T) {
+		proxy, cache, calls := setup(t, map[string]any{"data": "success"})
+
+		first, err := proxy.HandleRequest(context.Background(), Request{
+			Query:     "query($id: ID!) {\n  group(id: $id) { name }\n}",
+			Variables: map[string]any{"id": "1", "first": 10},
+		})
+		require.NoError(t, err)
+
+		second, err := proxy.HandleRequest(context.Background(), Request{
+			Query:     "query($id: ID!) { group(id: $id) { name } }",
+			Variables: map[string]any{"first": 10, "id": "1"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, first, second)
+		require.Len(t, cache.expiresAt, 1)
+		for _, expiresAt := range cache.expiresAt {
+			assert.Equal(t, now.Add(5*time.Minute), expiresAt)
+		}
+	})
+
+	t.Run("different variables are cached separately", func(t *testing.T) {
+		proxy, cache, calls := setup(t, map[string]any{"data": "success"})
+
+		_, err := proxy.HandleRequest(context.Background(), Request{
+			Query:     "query() {}",
+			Variables: map[string]any{"id": "1"},
+		})
+		require.NoError(t, err)
+
+		_, err = proxy.HandleRequest(context.Background(), Request{
+			Query:     "query() {}",
+			Variables: map[string]any{"id": "2"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Len(t, cache.responses, 2)
+	})
+
+	t.Run("refresh skips the cached response", func(t *testing.T) {
+		proxy, cache, calls := setup(t, map[string]any{"data": "fresh"})
+
+		req := Request{Query: "query() {}"}
+		key, err := cacheKey(req)
+		require.NoError(t, err)
+		cache.responses[key] = &Response{"data": "stale"}
+
+		req.Refresh = true
+		resp, err := proxy.HandleRequest(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, "fresh", (*resp)["data"])
+		assert.Equal(t, "fresh", (*cache.responses[key])["data"])
+	})
+
+	t.Run("responses with errors are not cached", func(t *testing.T) {
+		proxy, cache, calls := setup(t, map[string]any{
+			"errors": []any{map[string]any{"message": "not found"}},
+		})
+
+		for range 2 {
+			_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Empty(t, cache.responses)
+	})
+
+	t.Run("cache errors fall back to meetup", func(t *testing.T) {
+		proxy, cache, calls := setup(t, map[string]any{"data": "success"})
+		cache.err = fmt.Errorf("cache error")
+
+		resp, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, "success", (*resp)["data"])
+	})
+
+	t.Run("zero ttl disables the cache", func(t *testing.T) {
+		proxy, cache, calls := setup(t, map[string]any{"data": "success"})
+		proxy.config.CacheTTL = 0
+
+		for range 2 {
+			_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Empty(t, cache.responses)
+	})
+}
+
+func TestService_HandleRequest_CoalescesConcurrentRequests(t *testing.T) {
+	const requests = 5
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": "success"})
+	}))
+	defer ts.Close()
+
+	cache := newMockCache()
+
+	proxy := NewService(
+		ServiceConfig{
+			URL:                      ts.URL,
+			CacheTTL:                 5 * time.Minute,
+			AllowUnregisteredQueries: true,
+		},
+		&http.Client{},
+		&mockAuth{token: "valid-token"},
+		cache,
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		clock.NewMockTimeSource(time.Now()),
+		logging.NewMockLogger(),
+	)
+
+	var wg sync.WaitGroup
+	responses := make([]*Response, requests)
+	for i := range requests {
+		wg.Go(func() {
+			resp, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+			assert.NoError(t, err)
+			responses[i] = resp
+		})
+	}
+
+	// Once every request has missed the cache, give them a moment to join the one in flight.
+	require.Eventually(t, func() bool {
+		return cache.gets.Load() == requests
+	}, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, resp := range responses {
+		assert.Equal(t, "success", (*resp)["data"])
+	}
+}
+
+func TestService_HandleRequest_CanceledCallerDoesNotFailSharedRequest(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": "success"})
+	}))
+	defer ts.Close()
+
+	cache := newMockCache()
+
+	proxy := NewService(
+		ServiceConfig{
+			URL:                      ts.URL,
+			CacheTTL:                 5 * time.Minute,
+			AllowUnregisteredQueries: true,
+		},
+		&http.Client{},
+		&mockAuth{token: "valid-token"},
+		cache,
+		NewDefaultQueryRegistry(),
+		noCircuitBreaker(),
+		clock.NewMockTimeSource(time.Now()),
+		logging.NewMockLogger(),
+	)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := proxy.HandleRequest(firstCtx, Request{Query: "query() {}"})
+		firstErr <- err
+	}()
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	var second *Response
+	var secondErr error
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		second, secondErr = proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+	}()
+
+	// Once the second request has missed the cache, give it a moment to join the one in flight.
+	require.Eventually(t, func() bool {
+		return cache.gets.Load() == 2
+	}, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	<-secondDone
+
+	require.NoError(t, secondErr)
+	assert.Equal(t, "success", (*second)["data"])
+	assert.Equal(t, int32(1), calls.Load())
+
+	key, err := cacheKey(Request{Query: "query() {}"})
+	require.NoError(t, err)
+	cached, err := cache.Get(context.Background(), key)
+	require.NoError(t, err)
+	assert.NotNil(t, cached, "the shared response is still cached")
+}
+
+func TestDetachedContext(t *testing.T) {
+	t.Run("ends before the caller's deadline but isn't canceled with it", func(t *testing.T) {
+		deadline := time.Now().Add(time.Second)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+
+		detached, cancelDetached := detachedContext(ctx)
+		defer cancelDetached()
+		cancel()
+
+		detachedDeadline, ok := detached.Deadline()
+		require.True(t, ok)
+		assert.Equal(t, deadline.Add(-fetchDeadlineMargin), detachedDeadline)
+		assert.NoError(t, detached.Err())
+	})
+
+	t.Run("caps the deadline at the fetch timeout", func(t *testing.T) {
+		detached, cancel := detachedContext(context.Background())
+		defer cancel()
+
+		deadline, ok := detached.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(fetchTimeout), deadline, time.Second)
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	key := func(req Request) string {
+		t.Helper()
+		k, err := cacheKey(req)
+		require.NoError(t, err)
+		return k
+	}
+
+	base := Request{
+		Query:     "query($id: ID!) { group(id: $id) { name } }",
+		Variables: map[string]any{"id": "1", "filter": map[string]any{"a": 1, "b": 2}},
+	}
+
+	assert.Equal(t, key(base), key(Request{
+		Query:     "  query($id: ID!) {\n\tgroup(id: $id) {\n\t\tname\n\t}\n}\n",
+		Variables: map[string]any{"filter": map[string]any{"b": 2, "a": 1}, "id": "1"},
+	}))
+	assert.Equal(t, key(base), key(Request{
+		Query:     base.Query,
+		Variables: base.Variables,
+		Refresh:   true,
+	}))
+	assert.NotEqual(t, key(base), key(Request{
+		Query:     base.Query,
+		Variables: map[string]any{"id": "2", "filter": map[string]any{"a": 1, "b": 2}},
+	}))
+	assert.NotEqual(t, key(base), key(Request{
+		Query:     "query($id: ID!) { group(id: $id) { urlname } }",
+		Variables: base.Variables,
+	}))
+}
+
+func TestService_HandleRequest_Retries(t *testing.T) {
+	newProxy := func(t *testing.T, handler http.HandlerFunc) *Service {
+		t.Helper()
+
+		ts := httptest.NewServer(handler)
+		t.Cleanup(ts.Close)
+
+		return NewService(
+			ServiceConfig{
+				URL:                      ts.URL,
+				RetryPolicy:              testRetryPolicy,
+				AllowUnregisteredQueries: true,
+			},
+			&http.Client{},
+			&mockAuth{token: "valid-token"},
+			newMockCache(),
+			NewDefaultQueryRegistry(),
+			noCircuitBreaker(),
+			clock.NewMockTimeSource(time.Now()),
+			logging.NewMockLogger(),
+		)
+	}
+
+	// failFirst responds with fail until the request has been made attempts times.
+	failFirst := func(attempts int32, fail http.HandlerFunc) (http.HandlerFunc, *atomic.Int32) {
+		var calls atomic.Int32
+		return func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= attempts {
+				fail(w, r)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": "success"})
+		}, &calls
+	}
+
+	t.Run("retries transient server errors", func(t *testing.T) {
+		handler, calls := failFirst(2, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		proxy := newProxy(t, handler)
+
+		resp, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "success", (*resp)["data"])
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("retries dropped connections", func(t *testing.T) {
+		handler, calls := failFirst(1, func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+		})
+		proxy := newProxy(t, handler)
+
+		resp, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "success", (*resp)["data"])
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("waits for retry after when throttled", func(t *testing.T) {
+		handler, calls := failFirst(1, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		proxy := newProxy(t, handler)
+
+		resp, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "success", (*resp)["data"])
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("returns throttled error when retry after passes the deadline", func(t *testing.T) {
+		handler, calls := failFirst(1, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		proxy := newProxy(t, handler)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+
+		var throttledErr *ThrottledError
+		require.ErrorAs(t, err, &throttledErr)
+		assert.Equal(t, time.Minute, throttledErr.RetryAfter)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("recognizes graphql rate limit errors", func(t *testing.T) {
+		handler, _ := failFirst(1, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"errors": []any{map[string]any{
+					"message": "Too many requests, please try again shortly.",
+					"extensions": map[string]any{
+						"code":    "RATE_LIMITED",
+						"resetAt": time.Now().Add(time.Hour).Format(time.RFC3339),
+					},
+				}},
+			})
+		})
+		proxy := newProxy(t, handler)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+
+		var throttledErr *ThrottledError
+		require.ErrorAs(t, err, &throttledErr)
+		assert.Greater(t, throttledErr.RetryAfter, 59*time.Minute)
+	})
+
+	t.Run("doesn't retry client errors", func(t *testing.T) {
+		handler, calls := failFirst(1, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+		proxy := newProxy(t, handler)
+
+		_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+
+		var upstreamErr *UpstreamError
+		require.ErrorAs(t, err, &upstreamErr)
+		assert.Equal(t, http.StatusBadRequest, upstreamErr.StatusCode)
+		assert.False(t, upstreamErr.Transient)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("returns transient error once out of time", func(t *testing.T) {
+		handler, _ := failFirst(1000, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+		proxy := newProxy(t, handler)
+		proxy.config.RetryPolicy.Timeout = 50 * time.Millisecond
+
+		_, err := proxy.HandleRequest(context.Background(), Request{Query: "query() {}"})
+
+		var upstreamErr *UpstreamError
+		require.ErrorAs(t, err, &upstreamErr)
+		assert.Equal(t, http.StatusBadGateway, upstreamErr.StatusCode)
+		assert.True(t, upstreamErr.Transient)
+	})
+
+	t.Run("returns transient error before the caller's deadline", func(t *testing.T) {
+		handler, _ := failFirst(1000, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+		proxy := newProxy(t, handler)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		_, err := proxy.HandleRequest(ctx, Request{Query: "query() {}"})
+
+		var upstreamErr *UpstreamError
+		require.ErrorAs(t, err, &upstreamErr)
+		assert.Equal(t, http.StatusBadGateway, upstreamErr.StatusCode)
+	})
+}
@@ -0,0 +1,131 @@
+package meetupproxy
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/google/wire"
+)
+
+// TokenCipher encrypts the tokens kept in the token store, so the refresh token can't be used
+// by anyone who can only read the table.
+type TokenCipher interface {
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+// NewTokenCipher encrypts tokens with the configured KMS key. Without one, as when running
+// against DynamoDB Local, tokens are stored unencrypted.
+func NewTokenCipher(
+	config *meetupproxyconfig.Config,
+	awsConfig *aws.Config,
+	logger *slog.Logger,
+) TokenCipher {
+	if config.TokenStoreKMSKeyID == "" {
+		logger.Warn("no token store kms key is set, meetup tokens are stored unencrypted")
+		return plaintextTokenCipher{}
+	}
+
+	return NewKMSTokenCipher(NewKMSTokenCipherConfig(config), kms.NewFromConfig(*awsConfig))
+}
+
+type KMSAPI interface {
+	Encrypt(
+		ctx context.Context,
+		params *kms.EncryptInput,
+		optFns ...func(*kms.Options),
+	) (*kms.EncryptOutput, error)
+	Decrypt(
+		ctx context.Context,
+		params *kms.DecryptInput,
+		optFns ...func(*kms.Options),
+	) (*kms.DecryptOutput, error)
+}
+
+type KMSTokenCipherConfig struct {
+	KeyID string
+	// TokenID is the encryption context, so a token can only be decrypted for the credentials
+	// it was stored for.
+	TokenID string
+}
+
+func NewKMSTokenCipherConfig(config *meetupproxyconfig.Config) KMSTokenCipherConfig {
+	return KMSTokenCipherConfig{
+		KeyID:   config.TokenStoreKMSKeyID,
+		TokenID: NewDynamoDBTokenStoreConfig(config).TokenID,
+	}
+}
+
+// KMSTokenCipher encrypts tokens directly with a KMS key, which is enough for values under its
+// 4KB limit. Ciphertext is base64 encoded so it's stored as a string.
+type KMSTokenCipher struct {
+	config KMSTokenCipherConfig
+	client KMSAPI
+}
+
+func NewKMSTokenCipher(config KMSTokenCipherConfig, client KMSAPI) *KMSTokenCipher {
+	return &KMSTokenCipher{
+		config: config,
+		client: client,
+	}
+}
+
+func (c *KMSTokenCipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	output, err := c.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:             aws.String(c.config.KeyID),
+		Plaintext:         []byte(plaintext),
+		EncryptionContext: c.encryptionContext(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(output.CiphertextBlob), nil
+}
+
+func (c *KMSTokenCipher) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := c.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:             aws.String(c.config.KeyID),
+		CiphertextBlob:    blob,
+		EncryptionContext: c.encryptionContext(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(output.Plaintext), nil
+}
+
+func (c *KMSTokenCipher) encryptionContext() map[string]string {
+	return map[string]string{"tokenId": c.config.TokenID}
+}
+
+type plaintextTokenCipher struct{}
+
+func (plaintextTokenCipher) Encrypt(_ context.Context, plaintext string) (string, error) {
+	return plaintext, nil
+}
+
+func (plaintextTokenCipher) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	return ciphertext, nil
+}
+
+var TokenCipherProviders = wire.NewSet(NewTokenCipher)
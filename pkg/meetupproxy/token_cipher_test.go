@@ -0,0 +1,148 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/logging"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTokenCipher marks encrypted values so tests can tell they weren't stored as plaintext.
+type mockTokenCipher struct{}
+
+const mockCiphertextPrefix = "encrypted:"
+
+func (mockTokenCipher) Encrypt(_ context.Context, plaintext string) (string, error) {
+	return mockCiphertextPrefix + plaintext, nil
+}
+
+func (mockTokenCipher) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	plaintext, ok := strings.CutPrefix(ciphertext, mockCiphertextPrefix)
+	if !ok {
+		return "", errors.New("not encrypted")
+	}
+	return plaintext, nil
+}
+
+// mockKMS "encrypts" by reversing the plaintext and checks the encryption context matches.
+type mockKMS struct {
+	encryptionContexts []map[string]string
+}
+
+func (m *mockKMS) Encrypt(
+	_ context.Context,
+	params *kms.EncryptInput,
+	_ ...func(*kms.Options),
+) (*kms.EncryptOutput, error) {
+	m.encryptionContexts = append(m.encryptionContexts, params.EncryptionContext)
+	return &kms.EncryptOutput{CiphertextBlob: reverse(params.Plaintext)}, nil
+}
+
+func (m *mockKMS) Decrypt(
+	_ context.Context,
+	params *kms.DecryptInput,
+	_ ...func(*kms.Options),
+) (*kms.DecryptOutput, error) {
+	for _, encryptionContext := range m.encryptionContexts {
+		if encryptionContext["tokenId"] != params.EncryptionContext["tokenId"] {
+			return nil, errors.New("invalid ciphertext")
+		}
+	}
+	return &kms.DecryptOutput{Plaintext: reverse(params.CiphertextBlob)}, nil
+}
+
+func reverse(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i, c := range b {
+		reversed[len(b)-1-i] = c
+	}
+	return reversed
+}
+
+func TestNewKMSTokenCipherConfig(t *testing.T) {
+	cfg := &meetupproxyconfig.Config{
+		TokenStoreKMSKeyID: "alias/meetup-tokens",
+		MeetupClientKey:    "client",
+		MeetupUserID:       "user",
+	}
+
+	cipherConfig := NewKMSTokenCipherConfig(cfg)
+
+	assert.Equal(t, "alias/meetup-tokens", cipherConfig.KeyID)
+	assert.Equal(t, "client#user", cipherConfig.TokenID)
+}
+
+func TestNewTokenCipher(t *testing.T) {
+	t.Run("uses kms when a key is set", func(t *testing.T) {
+		cipher := NewTokenCipher(
+			&meetupproxyconfig.Config{TokenStoreKMSKeyID: "alias/meetup-tokens"},
+			&aws.Config{},
+			logging.NewMockLogger(),
+		)
+
+		assert.IsType(t, &KMSTokenCipher{}, cipher)
+	})
+
+	t.Run("stores tokens unencrypted without a key", func(t *testing.T) {
+		cipher := NewTokenCipher(
+			&meetupproxyconfig.Config{},
+			&aws.Config{},
+			logging.NewMockLogger(),
+		)
+
+		ciphertext, err := cipher.Encrypt(context.Background(), "token")
+		require.NoError(t, err)
+		assert.Equal(t, "token", ciphertext)
+	})
+}
+
+func TestKMSTokenCipher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trips tokens", func(t *testing.T) {
+		cipher := NewKMSTokenCipher(
+			KMSTokenCipherConfig{KeyID: "alias/meetup-tokens", TokenID: "client#user"},
+			&mockKMS{},
+		)
+
+		ciphertext, err := cipher.Encrypt(ctx, "refresh-token")
+		require.NoError(t, err)
+		assert.NotContains(t, ciphertext, "refresh-token")
+
+		plaintext, err := cipher.Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "refresh-token", plaintext)
+	})
+
+	t.Run("binds tokens to their credentials", func(t *testing.T) {
+		client := &mockKMS{}
+		cipher := NewKMSTokenCipher(KMSTokenCipherConfig{TokenID: "client#user"}, client)
+		other := NewKMSTokenCipher(KMSTokenCipherConfig{TokenID: "client#other"}, client)
+
+		ciphertext, err := cipher.Encrypt(ctx, "refresh-token")
+		require.NoError(t, err)
+
+		_, err = other.Decrypt(ctx, ciphertext)
+		assert.Error(t, err)
+	})
+
+	t.Run("leaves empty tokens empty", func(t *testing.T) {
+		cipher := NewKMSTokenCipher(KMSTokenCipherConfig{}, &mockKMS{})
+
+		ciphertext, err := cipher.Encrypt(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, ciphertext)
+
+		plaintext, err := cipher.Decrypt(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, plaintext)
+	})
+}
@@ -0,0 +1,234 @@
+package meetupproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/db"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/wire"
+)
+
+// TokenStore shares Meetup access tokens between proxy instances so they survive cold starts.
+// Refreshing the token is guarded by a lock so only one instance refreshes it at a time.
+type TokenStore interface {
+	// Token returns the stored token, or nil if none has been stored.
+	Token(ctx context.Context) (*authToken, error)
+	// Lock claims the right to refresh the token until ttl passes. It reports false if another
+	// owner holds the lock.
+	Lock(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+	// Unlock releases owner's lock, first storing token if it isn't nil.
+	Unlock(ctx context.Context, owner string, token *authToken) error
+}
+
+// ErrTokenLockLost is returned when unlocking after the lock expired and another owner took it.
+// The token isn't stored since the new owner is refreshing it.
+var ErrTokenLockLost = errors.New("token refresh lock is held by another owner")
+
+// tokenItem is the token store's single item for a set of Meetup credentials. The access and
+// refresh tokens are stored encrypted by a TokenCipher. Lock expiry is in unix milliseconds so
+// it can be compared in condition expressions.
+type tokenItem struct {
+	TokenID       string    `dynamodbav:"tokenId"`
+	AccessToken   string    `dynamodbav:"accessToken,omitempty"`
+	RefreshToken  string    `dynamodbav:"refreshToken,omitempty"`
+	TokenType     string    `dynamodbav:"tokenType,omitempty"`
+	ExpiresAt     time.Time `dynamodbav:"expiresAt"`
+	LockOwner     string    `dynamodbav:"lockOwner,omitempty"`
+	LockExpiresAt int64     `dynamodbav:"lockExpiresAt,omitempty"`
+}
+
+type DynamoDBTokenStoreConfig struct {
+	TokenStoreTableName string
+	// TokenID keys the stored token, so proxies signed in as different Meetup users or clients
+	// don't share tokens.
+	TokenID string
+}
+
+func NewDynamoDBTokenStoreConfig(config *meetupproxyconfig.Config) DynamoDBTokenStoreConfig {
+	return DynamoDBTokenStoreConfig{
+		TokenStoreTableName: config.TokenStoreTableName,
+		TokenID:             config.MeetupClientKey + "#" + config.MeetupUserID,
+	}
+}
+
+type DynamoDBTokenStore struct {
+	config     DynamoDBTokenStoreConfig
+	db         *db.Client
+	cipher     TokenCipher
+	timeSource clock.TimeSource
+}
+
+func NewDynamoDBTokenStore(
+	config DynamoDBTokenStoreConfig,
+	db *db.Client,
+	cipher TokenCipher,
+	timeSource clock.TimeSource,
+) *DynamoDBTokenStore {
+	return &DynamoDBTokenStore{
+		config:     config,
+		db:         db,
+		cipher:     cipher,
+		timeSource: timeSource,
+	}
+}
+
+func (s *DynamoDBTokenStore) Token(ctx context.Context) (*authToken, error) {
+	result, err := s.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.config.TokenStoreTableName),
+		Key:            s.key(),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var item tokenItem
+	if err = attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, err
+	}
+
+	if item.AccessToken == "" {
+		return nil, nil
+	}
+
+	accessToken, err := s.cipher.Decrypt(ctx, item.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt access token: %w", err)
+	}
+
+	refreshToken, err := s.cipher.Decrypt(ctx, item.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt refresh token: %w", err)
+	}
+
+	return &authToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    item.ExpiresAt,
+		TokenType:    item.TokenType,
+	}, nil
+}
+
+func (s *DynamoDBTokenStore) Lock(
+	ctx context.Context,
+	owner string,
+	ttl time.Duration,
+) (bool, error) {
+	now := s.timeSource.Now()
+
+	lockOwner := expression.Name("lockOwner")
+	lockExpiresAt := expression.Name("lockExpiresAt")
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Set(lockOwner, expression.Value(owner)).
+			Set(lockExpiresAt, expression.Value(now.Add(ttl).UnixMilli()))).
+		WithCondition(expression.Or(
+			expression.AttributeNotExists(lockOwner),
+			lockOwner.Equal(expression.Value(owner)),
+			lockExpiresAt.LessThan(expression.Value(now.UnixMilli())),
+		)).
+		Build()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = s.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(s.config.TokenStoreTableName),
+		Key:                       s.key(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *DynamoDBTokenStore) Unlock(
+	ctx context.Context,
+	owner string,
+	token *authToken,
+) error {
+	lockOwner := expression.Name("lockOwner")
+
+	update := expression.
+		Remove(lockOwner).
+		Remove(expression.Name("lockExpiresAt"))
+	if token != nil {
+		accessToken, err := s.cipher.Encrypt(ctx, token.AccessToken)
+		if err != nil {
+			return fmt.Errorf("encrypt access token: %w", err)
+		}
+
+		refreshToken, err := s.cipher.Encrypt(ctx, token.RefreshToken)
+		if err != nil {
+			return fmt.Errorf("encrypt refresh token: %w", err)
+		}
+
+		update = update.
+			Set(expression.Name("accessToken"), expression.Value(accessToken)).
+			Set(expression.Name("refreshToken"), expression.Value(refreshToken)).
+			Set(expression.Name("tokenType"), expression.Value(token.TokenType)).
+			Set(expression.Name("expiresAt"), expression.Value(token.ExpiresAt))
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(lockOwner.Equal(expression.Value(owner))).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(s.config.TokenStoreTableName),
+		Key:                       s.key(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return ErrTokenLockLost
+	}
+
+	return err
+}
+
+func (s *DynamoDBTokenStore) key() map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"tokenId": &types.AttributeValueMemberS{Value: s.config.TokenID},
+	}
+}
+
+var TokenStoreProviders = wire.NewSet(
+	TokenCipherProviders,
+	wire.Bind(new(TokenStore), new(*DynamoDBTokenStore)),
+	NewDynamoDBTokenStoreConfig,
+	NewDynamoDBTokenStore,
+)
@@ -0,0 +1,134 @@
+package meetupproxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/infra"
+	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
+	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/db"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDynamoDBTokenStoreConfig(t *testing.T) {
+	cfg := &meetupproxyconfig.Config{
+		TokenStoreTableName: "tokens",
+		MeetupClientKey:     "client",
+		MeetupUserID:        "user",
+	}
+
+	storeConfig := NewDynamoDBTokenStoreConfig(cfg)
+
+	assert.Equal(t, cfg.TokenStoreTableName, storeConfig.TokenStoreTableName)
+	assert.Equal(t, "client#user", storeConfig.TokenID)
+}
+
+func TestDynamoDBTokenStore(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	timeSource := clock.NewMockTimeSource(now)
+
+	store := NewDynamoDBTokenStore(
+		DynamoDBTokenStoreConfig{
+			TokenStoreTableName: *infra.TokenStoreTableProps.TableName,
+			TokenID:             "client#user",
+		},
+		testDB.Client,
+		mockTokenCipher{},
+		timeSource,
+	)
+
+	token := &authToken{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		ExpiresAt:    now.Add(time.Hour),
+	}
+
+	t.Run("returns nil before a token is stored", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		stored, err := store.Token(ctx)
+		require.NoError(t, err)
+
+		assert.Nil(t, stored)
+	})
+
+	t.Run("stores the token when unlocking", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		locked, err := store.Lock(ctx, "owner1", time.Minute)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		require.NoError(t, store.Unlock(ctx, "owner1", token))
+
+		stored, err := store.Token(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, token, stored)
+
+		locked, err = store.Lock(ctx, "owner2", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, locked, "unlocking should release the lock")
+	})
+
+	t.Run("stores the tokens encrypted", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		locked, err := store.Lock(ctx, "owner1", time.Minute)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		require.NoError(t, store.Unlock(ctx, "owner1", token))
+
+		result, err := testDB.Client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: infra.TokenStoreTableProps.TableName,
+			Key: map[string]types.AttributeValue{
+				"tokenId": &types.AttributeValueMemberS{Value: "client#user"},
+			},
+		})
+		require.NoError(t, err)
+
+		var item tokenItem
+		require.NoError(t, attributevalue.UnmarshalMap(result.Item, &item))
+		assert.Equal(t, mockCiphertextPrefix+"access", item.AccessToken)
+		assert.Equal(t, mockCiphertextPrefix+"refresh", item.RefreshToken)
+	})
+
+	t.Run("only one owner holds the lock until it expires", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+		defer timeSource.Reset()
+
+		locked, err := store.Lock(ctx, "owner1", time.Minute)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		locked, err = store.Lock(ctx, "owner2", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, locked)
+
+		timeSource.SetTime(now.Add(2 * time.Minute))
+
+		locked, err = store.Lock(ctx, "owner2", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, locked)
+
+		err = store.Unlock(ctx, "owner1", token)
+		require.ErrorIs(t, err, ErrTokenLockLost)
+
+		stored, err := store.Token(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, stored, "an owner that lost the lock shouldn't store its token")
+	})
+}
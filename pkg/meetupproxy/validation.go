@@ -0,0 +1,169 @@
+package meetupproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// UnmarshalJSON rejects variables that aren't an object with a RequestError, instead of the
+// decoding error they'd otherwise cause.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	type request Request
+	aux := struct {
+		*request
+		Variables json.RawMessage `json:"variables"`
+	}{
+		request: (*request)(r),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	variables := bytes.TrimSpace(aux.Variables)
+	if len(variables) == 0 || bytes.Equal(variables, []byte("null")) {
+		r.Variables = nil
+		return nil
+	}
+
+	if variables[0] != '{' {
+		return &RequestError{
+			Code: RequestErrorInvalidVariables,
+			Err:  errors.New("variables must be an object"),
+		}
+	}
+
+	return json.Unmarshal(variables, &r.Variables)
+}
+
+// validateQuery checks the query text is something the proxy will send to Meetup. The proxy is
+// read-only, so documents containing mutations or subscriptions are rejected.
+func (s *Service) validateQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return &RequestError{Code: RequestErrorEmptyQuery, Err: errors.New("query is empty")}
+	}
+
+	if s.config.MaxQueryBytes > 0 && len(query) > s.config.MaxQueryBytes {
+		return &RequestError{
+			Code: RequestErrorQueryTooLarge,
+			Err: fmt.Errorf(
+				"query is %d bytes, the limit is %d",
+				len(query),
+				s.config.MaxQueryBytes,
+			),
+		}
+	}
+
+	if operation := disallowedOperation(query); operation != "" {
+		return &RequestError{
+			Code: RequestErrorOperationNotAllowed,
+			Err:  fmt.Errorf("%s operations are not allowed, only queries", operation),
+		}
+	}
+
+	return nil
+}
+
+// disallowedOperation returns the type of the first operation in the document that isn't a
+// query, or an empty string if there isn't one. Only the keyword starting each top level
+// definition is read, with strings and comments skipped so their contents can't be mistaken
+// for one.
+func disallowedOperation(document string) string {
+	depth := 0
+	definitionStart := true
+
+	for i := 0; i < len(document); {
+		c := document[i]
+
+		switch {
+		case c == '#':
+			end := strings.IndexAny(document[i:], "\r\n")
+			if end < 0 {
+				return ""
+			}
+			i += end
+		case strings.HasPrefix(document[i:], `"""`):
+			i = skipBlockString(document, i+3)
+		case c == '"':
+			i = skipString(document, i+1)
+		case c == '{' || c == '(' || c == '[':
+			depth++
+			definitionStart = false
+			i++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+			// Definitions end with their selection set, variable definitions don't.
+			if depth == 0 && c == '}' {
+				definitionStart = true
+			}
+			i++
+		case isNameStart(c):
+			start := i
+			for i < len(document) && isNameContinue(document[i]) {
+				i++
+			}
+
+			if depth == 0 && definitionStart {
+				definitionStart = false
+
+				switch name := document[start:i]; name {
+				case "mutation", "subscription":
+					return name
+				}
+			}
+		default:
+			i++
+		}
+	}
+
+	return ""
+}
+
+// skipString returns the index after the string starting before i, or the end of its line when
+// it's unterminated.
+func skipString(document string, i int) int {
+	for i < len(document) {
+		switch document[i] {
+		case '\\':
+			i += 2
+		case '"':
+			return i + 1
+		case '\n', '\r':
+			return i
+		default:
+			i++
+		}
+	}
+
+	return i
+}
+
+// skipBlockString returns the index after the block string starting before i, where \""" is an
+// escaped delimiter.
+func skipBlockString(document string, i int) int {
+	for i < len(document) {
+		end := strings.Index(document[i:], `"""`)
+		if end < 0 {
+			return len(document)
+		}
+
+		i += end
+		if document[i-1] != '\\' {
+			return i + 3
+		}
+		i += 3
+	}
+
+	return i
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,76 @@
+package meetupproxy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_UnmarshalJSON(t *testing.T) {
+	t.Run("decodes object variables", func(t *testing.T) {
+		var req Request
+		err := json.Unmarshal(
+			[]byte(`{"queryId": "groupFutureEvents@v1", "variables": {"urlname": "sgfdevs"}}`),
+			&req,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, GroupFutureEventsQueryID, req.QueryID)
+		assert.Equal(t, map[string]any{"urlname": "sgfdevs"}, req.Variables)
+	})
+
+	t.Run("missing and null variables are empty", func(t *testing.T) {
+		for _, data := range []string{`{"query": "{ self { id } }"}`, `{"variables": null}`} {
+			req := Request{Variables: map[string]any{"stale": true}}
+			require.NoError(t, json.Unmarshal([]byte(data), &req))
+			assert.Nil(t, req.Variables)
+		}
+	})
+
+	t.Run("rejects variables that aren't an object", func(t *testing.T) {
+		for _, variables := range []string{`[1, 2]`, `"urlname=sgfdevs"`, `42`, `true`} {
+			var req Request
+			err := json.Unmarshal([]byte(`{"variables": `+variables+`}`), &req)
+
+			var requestErr *RequestError
+			require.ErrorAs(t, err, &requestErr, variables)
+			assert.Equal(t, RequestErrorInvalidVariables, requestErr.Code)
+		}
+	})
+}
+
+func TestDisallowedOperation(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{name: "shorthand query", document: "{ self { id } }"},
+		{name: "named query", document: "query Events($urlname: String!) { events { id } }"},
+		{name: "persisted query", document: groupFutureEventsQuery.Query},
+		{
+			name:     "fragments",
+			document: "query { ...F } fragment F on Query { mutation: self { id } }",
+		},
+		{
+			name: "keywords in strings and comments",
+			document: "# mutation\nquery { a(b: \"} mutation {\", " +
+				"c: \"\"\"\n} mutation \\\"\"\" {\"\"\") }",
+		},
+		{name: "mutation", document: "mutation { delete { id } }", want: "mutation"},
+		{name: "subscription", document: "subscription { events { id } }", want: "subscription"},
+		{
+			name:     "mutation after a query",
+			document: "query A($x: Int = 1) { a } mutation B { b }",
+			want:     "mutation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, disallowedOperation(tt.document))
+		})
+	}
+}
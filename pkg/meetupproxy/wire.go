@@ -8,6 +8,7 @@ import (
 
 	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
 	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/db"
 	"sgf-meetup-api/pkg/shared/httpclient"
 	"sgf-meetup-api/pkg/shared/logging"
 
@@ -19,13 +20,32 @@ var CommonProviders = wire.NewSet(
 	logging.DefaultLogger,
 	clock.RealClockProvider,
 	httpclient.DefaultClient,
+	db.Providers,
 )
 
 func InitService(ctx context.Context) (*Service, error) {
 	panic(wire.Build(
 		CommonProviders,
 		AuthHandlerProviders,
+		TokenStoreProviders,
+		ResponseCacheProviders,
+		QueryRegistryProviders,
+		CircuitBreakerProviders,
 		NewServiceConfig,
 		NewService,
 	))
 }
+
+func InitHTTPServer(ctx context.Context) (*HTTPServer, error) {
+	panic(wire.Build(
+		CommonProviders,
+		AuthHandlerProviders,
+		TokenStoreProviders,
+		ResponseCacheProviders,
+		QueryRegistryProviders,
+		CircuitBreakerProviders,
+		NewServiceConfig,
+		NewService,
+		HTTPHandlerProviders,
+	))
+}
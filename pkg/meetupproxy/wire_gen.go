@@ -12,6 +12,7 @@ import (
 	"sgf-meetup-api/pkg/meetupproxy/meetupproxyconfig"
 	"sgf-meetup-api/pkg/shared/appconfig"
 	"sgf-meetup-api/pkg/shared/clock"
+	"sgf-meetup-api/pkg/shared/db"
 	"sgf-meetup-api/pkg/shared/httpclient"
 	"sgf-meetup-api/pkg/shared/logging"
 )
@@ -31,11 +32,64 @@ func InitService(ctx context.Context) (*Service, error) {
 	logger := logging.DefaultLogger(ctx, loggingConfig)
 	client := httpclient.DefaultClient(realTimeSource, logger)
 	meetupHttpAuthHandlerConfig := NewMeetupAuthHandlerConfig(config)
-	meetupHttpAuthHandler := NewMeetupHttpAuthHandler(meetupHttpAuthHandlerConfig, client, logger)
-	service := NewService(serviceConfig, client, meetupHttpAuthHandler, logger)
+	dynamoDBTokenStoreConfig := NewDynamoDBTokenStoreConfig(config)
+	dbConfig := common.DynamoDB
+	awsConfig := appconfig.AwsConfigProvider(awsConfigManagerImpl)
+	dbClient, err := db.NewClient(ctx, dbConfig, awsConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	tokenCipher := NewTokenCipher(config, awsConfig, logger)
+	dynamoDBTokenStore := NewDynamoDBTokenStore(dynamoDBTokenStoreConfig, dbClient, tokenCipher, realTimeSource)
+	circuitBreakerConfig := NewCircuitBreakerConfig(config)
+	circuitBreaker := NewCircuitBreaker(circuitBreakerConfig, realTimeSource, logger)
+	meetupHttpAuthHandler := NewMeetupHttpAuthHandler(meetupHttpAuthHandlerConfig, client, dynamoDBTokenStore, circuitBreaker, logger)
+	dynamoDBResponseCacheConfig := NewDynamoDBResponseCacheConfig(config)
+	dynamoDBResponseCache := NewDynamoDBResponseCache(dynamoDBResponseCacheConfig, dbClient, realTimeSource)
+	queryRegistry := NewDefaultQueryRegistry()
+	service := NewService(serviceConfig, client, meetupHttpAuthHandler, dynamoDBResponseCache, queryRegistry, circuitBreaker, realTimeSource, logger)
 	return service, nil
 }
 
+func InitHTTPServer(ctx context.Context) (*HTTPServer, error) {
+	awsConfigManagerImpl := appconfig.NewAwsConfigManager()
+	config, err := meetupproxyconfig.NewConfig(ctx, awsConfigManagerImpl)
+	if err != nil {
+		return nil, err
+	}
+	common := config.Common
+	loggingConfig := common.Logging
+	logger := logging.DefaultLogger(ctx, loggingConfig)
+	httpHandlerConfig := NewHTTPHandlerConfig(config)
+	serviceConfig := NewServiceConfig(config)
+	realTimeSource := clock.NewRealTimeSource()
+	client := httpclient.DefaultClient(realTimeSource, logger)
+	meetupHttpAuthHandlerConfig := NewMeetupAuthHandlerConfig(config)
+	dynamoDBTokenStoreConfig := NewDynamoDBTokenStoreConfig(config)
+	dbConfig := common.DynamoDB
+	awsConfig := appconfig.AwsConfigProvider(awsConfigManagerImpl)
+	dbClient, err := db.NewClient(ctx, dbConfig, awsConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	tokenCipher := NewTokenCipher(config, awsConfig, logger)
+	dynamoDBTokenStore := NewDynamoDBTokenStore(dynamoDBTokenStoreConfig, dbClient, tokenCipher, realTimeSource)
+	circuitBreakerConfig := NewCircuitBreakerConfig(config)
+	circuitBreaker := NewCircuitBreaker(circuitBreakerConfig, realTimeSource, logger)
+	meetupHttpAuthHandler := NewMeetupHttpAuthHandler(meetupHttpAuthHandlerConfig, client, dynamoDBTokenStore, circuitBreaker, logger)
+	dynamoDBResponseCacheConfig := NewDynamoDBResponseCacheConfig(config)
+	dynamoDBResponseCache := NewDynamoDBResponseCache(dynamoDBResponseCacheConfig, dbClient, realTimeSource)
+	queryRegistry := NewDefaultQueryRegistry()
+	service := NewService(serviceConfig, client, meetupHttpAuthHandler, dynamoDBResponseCache, queryRegistry, circuitBreaker, realTimeSource, logger)
+	httpHandler, err := NewHTTPHandler(httpHandlerConfig, service, circuitBreaker, logger)
+	if err != nil {
+		return nil, err
+	}
+	engine := NewRouter(logger, httpHandler)
+	httpServer := NewHTTPServer(config, engine)
+	return httpServer, nil
+}
+
 // wire.go:
 
-var CommonProviders = wire.NewSet(meetupproxyconfig.ConfigProviders, logging.DefaultLogger, clock.RealClockProvider, httpclient.DefaultClient)
+var CommonProviders = wire.NewSet(meetupproxyconfig.ConfigProviders, logging.DefaultLogger, clock.RealClockProvider, httpclient.DefaultClient, db.Providers)
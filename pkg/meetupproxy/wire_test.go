@@ -12,8 +12,29 @@ func TestInitService(t *testing.T) {
 	t.Setenv("MEETUP_USER_ID", "meetupUserId")
 	t.Setenv("MEETUP_CLIENT_KEY", "meetupClientKey")
 	t.Setenv("MEETUP_SIGNING_KEY_ID", "signingKeyId")
+	t.Setenv("RESPONSE_CACHE_TABLE_NAME", "response-cache")
+	t.Setenv("TOKEN_STORE_TABLE_NAME", "token-store")
 
 	_, err := InitService(context.Background())
 
 	require.NoError(t, err)
 }
+
+func TestInitHTTPServer(t *testing.T) {
+	t.Setenv("MEETUP_PRIVATE_KEY_BASE64", "c29tZUJhc2U2NEtleQ==")
+	t.Setenv("MEETUP_USER_ID", "meetupUserId")
+	t.Setenv("MEETUP_CLIENT_KEY", "meetupClientKey")
+	t.Setenv("MEETUP_SIGNING_KEY_ID", "signingKeyId")
+	t.Setenv("RESPONSE_CACHE_TABLE_NAME", "response-cache")
+	t.Setenv("TOKEN_STORE_TABLE_NAME", "token-store")
+
+	_, err := InitHTTPServer(context.Background())
+	require.ErrorIs(t, err, ErrNoTokens)
+
+	t.Setenv("MEETUP_PROXY_TOKENS", "token-a,token-b")
+
+	server, err := InitHTTPServer(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, ":8091", server.Addr)
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxBatchGetSize is the most keys DynamoDB accepts in a single BatchGetItem call.
+const MaxBatchGetSize = 100
+
+// MaxTransactSize is the most actions DynamoDB accepts in a single TransactWriteItems call.
+const MaxTransactSize = 100
+
+var ErrRetryDeadlineExceeded = errors.New("dynamodb retry deadline exceeded")
+
+type BatchWriteAPI interface {
+	BatchWriteItem(
+		ctx context.Context,
+		params *dynamodb.BatchWriteItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+type BatchGetAPI interface {
+	BatchGetItem(
+		ctx context.Context,
+		params *dynamodb.BatchGetItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.BatchGetItemOutput, error)
+}
+
+type TransactWriteAPI interface {
+	TransactWriteItems(
+		ctx context.Context,
+		params *dynamodb.TransactWriteItemsInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+// RetryPolicy controls how the batch helpers retry work DynamoDB hands back. Delays grow
+// exponentially from BaseDelay up to MaxDelay with full jitter, and no retry is started that
+// would end after Timeout has elapsed.
+type RetryPolicy struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Timeout   time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	BaseDelay: 50 * time.Millisecond,
+	MaxDelay:  5 * time.Second,
+	Timeout:   30 * time.Second,
+}
+
+// BatchWriteItems writes requests to a table in chunks of MaxBatchSize, retrying any
+// UnprocessedItems until the policy's deadline.
+func BatchWriteItems(
+	ctx context.Context,
+	api BatchWriteAPI,
+	policy RetryPolicy,
+	tableName string,
+	requests []types.WriteRequest,
+) error {
+	for chunk := range slices.Chunk(requests, MaxBatchSize) {
+		pending := map[string][]types.WriteRequest{tableName: chunk}
+
+		err := policy.retry(ctx, func(ctx context.Context) (bool, error) {
+			output, err := api.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: pending,
+			})
+			if err != nil {
+				return false, err
+			}
+
+			pending = output.UnprocessedItems
+			return len(pending[tableName]) == 0, nil
+		})
+		if errors.Is(err, ErrRetryDeadlineExceeded) {
+			return fmt.Errorf("%w: %d unprocessed writes for %s",
+				err, len(pending[tableName]), tableName)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BatchGetItems reads keys from a table in chunks of MaxBatchGetSize, retrying any
+// UnprocessedKeys until the policy's deadline. Items are not returned in key order.
+func BatchGetItems(
+	ctx context.Context,
+	api BatchGetAPI,
+	policy RetryPolicy,
+	tableName string,
+	keys []map[string]types.AttributeValue,
+) ([]map[string]types.AttributeValue, error) {
+	items := make([]map[string]types.AttributeValue, 0, len(keys))
+
+	for chunk := range slices.Chunk(keys, MaxBatchGetSize) {
+		pending := map[string]types.KeysAndAttributes{tableName: {Keys: chunk}}
+
+		err := policy.retry(ctx, func(ctx context.Context) (bool, error) {
+			output, err := api.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: pending,
+			})
+			if err != nil {
+				return false, err
+			}
+
+			items = append(items, output.Responses[tableName]...)
+			pending = output.UnprocessedKeys
+			return len(pending[tableName].Keys) == 0, nil
+		})
+		if errors.Is(err, ErrRetryDeadlineExceeded) {
+			return nil, fmt.Errorf("%w: %d unprocessed keys for %s",
+				err, len(pending[tableName].Keys), tableName)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// TransactWriteItems writes items atomically, retrying transactions cancelled by throttling or
+// conflicting writes until the policy's deadline.
+func TransactWriteItems(
+	ctx context.Context,
+	api TransactWriteAPI,
+	policy RetryPolicy,
+	items []types.TransactWriteItem,
+) error {
+	var lastErr error
+
+	err := policy.retry(ctx, func(ctx context.Context) (bool, error) {
+		_, lastErr = api.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: items,
+		})
+
+		var cancelled *types.TransactionCanceledException
+		if errors.As(lastErr, &cancelled) && isRetryableCancellation(cancelled) {
+			return false, nil
+		}
+
+		return true, lastErr
+	})
+	if errors.Is(err, ErrRetryDeadlineExceeded) {
+		return fmt.Errorf("%w: %w", err, lastErr)
+	}
+
+	return err
+}
+
+func isRetryableCancellation(err *types.TransactionCanceledException) bool {
+	retryable := false
+
+	for _, reason := range err.CancellationReasons {
+		switch code := aws.ToString(reason.Code); code {
+		case "None", "":
+		case "ThrottlingError", "TransactionConflict", "ProvisionedThroughputExceeded":
+			retryable = true
+		default:
+			// A failed condition or validation error won't succeed on retry.
+			return false
+		}
+	}
+
+	return retryable
+}
+
+// retry calls attempt until it reports done or returns an error, sleeping between attempts.
+func (p RetryPolicy) retry(
+	ctx context.Context,
+	attempt func(ctx context.Context) (bool, error),
+) error {
+	deadline := time.Now().Add(p.Timeout)
+
+	for i := 0; ; i++ {
+		done, err := attempt(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		wait := p.delay(i)
+		if time.Now().Add(wait).After(deadline) {
+			return ErrRetryDeadlineExceeded
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.MaxDelay
+	if attempt < 32 {
+		if exponential := p.BaseDelay << attempt; exponential > 0 && exponential < p.MaxDelay {
+			backoff = exponential
+		}
+	}
+
+	return rand.N(backoff + 1)
+}
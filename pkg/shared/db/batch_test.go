@@ -0,0 +1,369 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"sgf-meetup-api/pkg/infra"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchAPI interface {
+	BatchWriteAPI
+	BatchGetAPI
+	TransactWriteAPI
+}
+
+// faultInjectingClient wraps a client and simulates throttling. For the first faultyCalls calls
+// to each operation, batch calls only forward the first half of their items and hand the rest
+// back as unprocessed, and transactions are cancelled without being forwarded.
+type faultInjectingClient struct {
+	inner       batchAPI
+	faultyCalls int
+
+	writeCalls    int
+	getCalls      int
+	transactCalls int
+}
+
+func (c *faultInjectingClient) BatchWriteItem(
+	ctx context.Context,
+	params *dynamodb.BatchWriteItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.BatchWriteItemOutput, error) {
+	c.writeCalls++
+	if c.writeCalls > c.faultyCalls {
+		return c.inner.BatchWriteItem(ctx, params, optFns...)
+	}
+
+	forwarded := map[string][]types.WriteRequest{}
+	unprocessed := map[string][]types.WriteRequest{}
+	for table, requests := range params.RequestItems {
+		half := (len(requests) + 1) / 2
+		forwarded[table] = requests[:half]
+		if len(requests[half:]) > 0 {
+			unprocessed[table] = requests[half:]
+		}
+	}
+
+	if _, err := c.inner.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: forwarded,
+	}, optFns...); err != nil {
+		return nil, err
+	}
+
+	return &dynamodb.BatchWriteItemOutput{UnprocessedItems: unprocessed}, nil
+}
+
+func (c *faultInjectingClient) BatchGetItem(
+	ctx context.Context,
+	params *dynamodb.BatchGetItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.BatchGetItemOutput, error) {
+	c.getCalls++
+	if c.getCalls > c.faultyCalls {
+		return c.inner.BatchGetItem(ctx, params, optFns...)
+	}
+
+	forwarded := map[string]types.KeysAndAttributes{}
+	unprocessed := map[string]types.KeysAndAttributes{}
+	for table, keys := range params.RequestItems {
+		half := (len(keys.Keys) + 1) / 2
+		forwarded[table] = types.KeysAndAttributes{Keys: keys.Keys[:half]}
+		if len(keys.Keys[half:]) > 0 {
+			unprocessed[table] = types.KeysAndAttributes{Keys: keys.Keys[half:]}
+		}
+	}
+
+	output, err := c.inner.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+		RequestItems: forwarded,
+	}, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	output.UnprocessedKeys = unprocessed
+	return output, nil
+}
+
+func (c *faultInjectingClient) TransactWriteItems(
+	ctx context.Context,
+	params *dynamodb.TransactWriteItemsInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	c.transactCalls++
+	if c.transactCalls > c.faultyCalls {
+		return c.inner.TransactWriteItems(ctx, params, optFns...)
+	}
+
+	reasons := make([]types.CancellationReason, len(params.TransactItems))
+	for i := range reasons {
+		reasons[i] = types.CancellationReason{Code: aws.String("None")}
+	}
+	reasons[0].Code = aws.String("ThrottlingError")
+
+	return nil, &types.TransactionCanceledException{
+		Message:             aws.String("Transaction cancelled"),
+		CancellationReasons: reasons,
+	}
+}
+
+// memoryClient is an in-memory store of items keyed by their "id" attribute.
+type memoryClient struct {
+	tables map[string]map[string]map[string]types.AttributeValue
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{tables: map[string]map[string]map[string]types.AttributeValue{}}
+}
+
+func (c *memoryClient) table(name string) map[string]map[string]types.AttributeValue {
+	if c.tables[name] == nil {
+		c.tables[name] = map[string]map[string]types.AttributeValue{}
+	}
+	return c.tables[name]
+}
+
+func itemID(item map[string]types.AttributeValue) string {
+	return item["id"].(*types.AttributeValueMemberS).Value
+}
+
+func (c *memoryClient) BatchWriteItem(
+	_ context.Context,
+	params *dynamodb.BatchWriteItemInput,
+	_ ...func(*dynamodb.Options),
+) (*dynamodb.BatchWriteItemOutput, error) {
+	for name, requests := range params.RequestItems {
+		for _, request := range requests {
+			if request.PutRequest != nil {
+				c.table(name)[itemID(request.PutRequest.Item)] = request.PutRequest.Item
+			}
+			if request.DeleteRequest != nil {
+				delete(c.table(name), itemID(request.DeleteRequest.Key))
+			}
+		}
+	}
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func (c *memoryClient) BatchGetItem(
+	_ context.Context,
+	params *dynamodb.BatchGetItemInput,
+	_ ...func(*dynamodb.Options),
+) (*dynamodb.BatchGetItemOutput, error) {
+	responses := map[string][]map[string]types.AttributeValue{}
+	for name, keys := range params.RequestItems {
+		for _, key := range keys.Keys {
+			if item, ok := c.table(name)[itemID(key)]; ok {
+				responses[name] = append(responses[name], item)
+			}
+		}
+	}
+	return &dynamodb.BatchGetItemOutput{Responses: responses}, nil
+}
+
+func (c *memoryClient) TransactWriteItems(
+	_ context.Context,
+	params *dynamodb.TransactWriteItemsInput,
+	_ ...func(*dynamodb.Options),
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	for _, item := range params.TransactItems {
+		if item.Put != nil {
+			c.table(*item.Put.TableName)[itemID(item.Put.Item)] = item.Put.Item
+		}
+		if item.Delete != nil {
+			delete(c.table(*item.Delete.TableName), itemID(item.Delete.Key))
+		}
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+var testRetryPolicy = RetryPolicy{
+	BaseDelay: time.Millisecond,
+	MaxDelay:  5 * time.Millisecond,
+	Timeout:   time.Second,
+}
+
+func testItem(id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+}
+
+func testWriteRequests(count int) []types.WriteRequest {
+	requests := make([]types.WriteRequest, count)
+	for i := range requests {
+		requests[i] = types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: testItem(fmt.Sprintf("item-%d", i))},
+		}
+	}
+	return requests
+}
+
+func TestBatchWriteItems(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries unprocessed items until all are written", func(t *testing.T) {
+		memory := newMemoryClient()
+		client := &faultInjectingClient{inner: memory, faultyCalls: 3}
+
+		err := BatchWriteItems(ctx, client, testRetryPolicy, "table", testWriteRequests(10))
+
+		require.NoError(t, err)
+		assert.Len(t, memory.table("table"), 10)
+		assert.Equal(t, 4, client.writeCalls)
+	})
+
+	t.Run("writes in chunks of MaxBatchSize", func(t *testing.T) {
+		memory := newMemoryClient()
+		client := &faultInjectingClient{inner: memory}
+
+		err := BatchWriteItems(
+			ctx, client, testRetryPolicy, "table", testWriteRequests(MaxBatchSize*2+1),
+		)
+
+		require.NoError(t, err)
+		assert.Len(t, memory.table("table"), MaxBatchSize*2+1)
+		assert.Equal(t, 3, client.writeCalls)
+	})
+
+	t.Run("returns an error when the deadline passes", func(t *testing.T) {
+		client := &faultInjectingClient{inner: newMemoryClient(), faultyCalls: 1000}
+		policy := RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+		err := BatchWriteItems(ctx, client, policy, "table", testWriteRequests(10))
+
+		assert.ErrorIs(t, err, ErrRetryDeadlineExceeded)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		client := &faultInjectingClient{inner: newMemoryClient(), faultyCalls: 1000}
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := BatchWriteItems(ctx, client, testRetryPolicy, "table", testWriteRequests(10))
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("handles empty input list", func(t *testing.T) {
+		client := &faultInjectingClient{inner: newMemoryClient()}
+
+		require.NoError(t, BatchWriteItems(ctx, client, testRetryPolicy, "table", nil))
+		assert.Zero(t, client.writeCalls)
+	})
+}
+
+func TestBatchGetItems(t *testing.T) {
+	ctx := context.Background()
+
+	memory := newMemoryClient()
+	require.NoError(t, BatchWriteItems(
+		ctx, memory, testRetryPolicy, "table", testWriteRequests(MaxBatchGetSize+10),
+	))
+
+	keys := make([]map[string]types.AttributeValue, MaxBatchGetSize+10)
+	for i := range keys {
+		keys[i] = testItem(fmt.Sprintf("item-%d", i))
+	}
+
+	t.Run("retries unprocessed keys until all are read", func(t *testing.T) {
+		client := &faultInjectingClient{inner: memory, faultyCalls: 3}
+
+		items, err := BatchGetItems(ctx, client, testRetryPolicy, "table", keys)
+
+		require.NoError(t, err)
+		assert.Len(t, items, len(keys))
+	})
+
+	t.Run("returns an error when the deadline passes", func(t *testing.T) {
+		client := &faultInjectingClient{inner: memory, faultyCalls: 1000}
+		policy := RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+		_, err := BatchGetItems(ctx, client, policy, "table", keys)
+
+		assert.ErrorIs(t, err, ErrRetryDeadlineExceeded)
+	})
+}
+
+func TestTransactWriteItems(t *testing.T) {
+	ctx := context.Background()
+
+	items := []types.TransactWriteItem{
+		{Put: &types.Put{TableName: aws.String("archive"), Item: testItem("1")}},
+		{Delete: &types.Delete{TableName: aws.String("live"), Key: testItem("1")}},
+	}
+
+	t.Run("retries throttled transactions", func(t *testing.T) {
+		memory := newMemoryClient()
+		memory.table("live")["1"] = testItem("1")
+		client := &faultInjectingClient{inner: memory, faultyCalls: 2}
+
+		err := TransactWriteItems(ctx, client, testRetryPolicy, items)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, client.transactCalls)
+		assert.Len(t, memory.table("archive"), 1)
+		assert.Empty(t, memory.table("live"))
+	})
+
+	t.Run("returns an error when the deadline passes", func(t *testing.T) {
+		client := &faultInjectingClient{inner: newMemoryClient(), faultyCalls: 1000}
+		policy := RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+		err := TransactWriteItems(ctx, client, policy, items)
+
+		assert.ErrorIs(t, err, ErrRetryDeadlineExceeded)
+
+		var cancelled *types.TransactionCanceledException
+		assert.ErrorAs(t, err, &cancelled)
+	})
+
+	t.Run("does not retry failed conditions", func(t *testing.T) {
+		client := &conditionFailingClient{}
+
+		err := TransactWriteItems(ctx, client, testRetryPolicy, items)
+
+		var cancelled *types.TransactionCanceledException
+		assert.ErrorAs(t, err, &cancelled)
+		assert.Equal(t, 1, client.calls)
+	})
+}
+
+type conditionFailingClient struct {
+	calls int
+}
+
+func (c *conditionFailingClient) TransactWriteItems(
+	context.Context,
+	*dynamodb.TransactWriteItemsInput,
+	...func(*dynamodb.Options),
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	c.calls++
+	return nil, &types.TransactionCanceledException{
+		CancellationReasons: []types.CancellationReason{
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("ThrottlingError")},
+		},
+	}
+}
+
+func TestBatchWriteItems_DynamoDB(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := NewTestDB(ctx)
+	require.NoError(t, err)
+	defer testDB.Close()
+
+	tableName := *infra.ArchivedEventsTableProps.TableName
+	client := &faultInjectingClient{inner: testDB.Client, faultyCalls: 2}
+
+	err = BatchWriteItems(ctx, client, testRetryPolicy, tableName, testWriteRequests(30))
+
+	require.NoError(t, err)
+	assert.Equal(t, 30, testDB.GetItemCount(ctx, tableName))
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"reflect"
 	"slices"
+	"strings"
 
 	"sgf-meetup-api/pkg/infra"
 	"sgf-meetup-api/pkg/shared/logging"
@@ -89,9 +90,22 @@ func (ctr *TestDB) Reset(ctx context.Context) error {
 	for _, table := range infra.Tables {
 		var deleteRequests []types.WriteRequest
 
+		keyNames := []string{*table.PartitionKey.Name}
+		if table.SortKey != nil {
+			keyNames = append(keyNames, *table.SortKey.Name)
+		}
+
+		projection := make([]string, len(keyNames))
+		attributeNames := make(map[string]string, len(keyNames))
+		for i, keyName := range keyNames {
+			projection[i] = fmt.Sprintf("#k%d", i)
+			attributeNames[projection[i]] = keyName
+		}
+
 		paginator := dynamodb.NewScanPaginator(ctr.Client, &dynamodb.ScanInput{
-			TableName:            table.TableName,
-			ProjectionExpression: table.PartitionKey.Name,
+			TableName:                table.TableName,
+			ProjectionExpression:     aws.String(strings.Join(projection, ", ")),
+			ExpressionAttributeNames: attributeNames,
 		})
 
 		for paginator.HasMorePages() {
@@ -101,12 +115,13 @@ func (ctr *TestDB) Reset(ctx context.Context) error {
 			}
 
 			for _, item := range page.Items {
+				key := make(map[string]types.AttributeValue, len(keyNames))
+				for _, keyName := range keyNames {
+					key[keyName] = item[keyName]
+				}
+
 				deleteRequests = append(deleteRequests, types.WriteRequest{
-					DeleteRequest: &types.DeleteRequest{
-						Key: map[string]types.AttributeValue{
-							*table.PartitionKey.Name: item[*table.PartitionKey.Name],
-						},
-					},
+					DeleteRequest: &types.DeleteRequest{Key: key},
 				})
 			}
 		}
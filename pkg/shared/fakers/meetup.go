@@ -23,6 +23,7 @@ func (m *MeetupFaker) CreateEvent(groupID string, dateTime time.Time) models.Mee
 	_ = m.faker.Struct(&event)
 	event.GroupID = groupID
 	event.DateTime = &models.CustomTime{Time: dateTime}
+	_ = event.NormalizeDuration()
 	return event
 }
 
@@ -31,6 +32,7 @@ func (m *MeetupFaker) CreateEvents(groupID string, count int) []models.MeetupEve
 	m.faker.Slice(&events)
 	for i := range events {
 		events[i].GroupID = groupID
+		_ = events[i].NormalizeDuration()
 	}
 	return events
 }
@@ -46,6 +48,7 @@ func (m *MeetupFaker) CreateEventsWithDates(
 		date := base.Add(d)
 		events[i].GroupID = groupID
 		events[i].DateTime = &models.CustomTime{Time: date}
+		_ = events[i].NormalizeDuration()
 	}
 	return events
 }
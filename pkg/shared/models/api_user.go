@@ -1,6 +1,23 @@
 package models
 
+import "slices"
+
+// APIUserScopeAdmin grants access to the /v1/admin endpoints.
+const APIUserScopeAdmin = "admin"
+
 type APIUser struct {
-	ClientID           string `dynamodbav:"clientId"`
-	HashedClientSecret []byte `dynamodbav:"hashedClientSecret"`
+	ClientID           string   `dynamodbav:"clientId"`
+	HashedClientSecret []byte   `dynamodbav:"hashedClientSecret"`
+	Scopes             []string `dynamodbav:"scopes,omitempty"`
+	// AllowedOrigins narrows the browser origins allowed to use the user's tokens. Without any
+	// the user may use every origin the API allows.
+	AllowedOrigins []string `dynamodbav:"allowedOrigins,omitempty"`
+}
+
+func (u *APIUser) HasScope(scope string) bool {
+	return slices.Contains(u.Scopes, scope)
+}
+
+func (u *APIUser) AllowsOrigin(origin string) bool {
+	return len(u.AllowedOrigins) == 0 || slices.Contains(u.AllowedOrigins, origin)
 }
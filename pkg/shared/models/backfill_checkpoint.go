@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// BackfillCheckpoint tracks how far a group's historical backfill has paged through Meetup's
+// past events, so an interrupted backfill resumes from Cursor instead of starting over.
+type BackfillCheckpoint struct {
+	GroupID   string    `dynamodbav:"groupId"`
+	Cursor    string    `dynamodbav:"cursor"`
+	Pages     int       `dynamodbav:"pages"`
+	Events    int       `dynamodbav:"events"`
+	Completed bool      `dynamodbav:"completed"`
+	UpdatedAt time.Time `dynamodbav:"updatedAt"`
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ParseDuration parses an ISO 8601 duration such as PT1H30M, as returned by Meetup. Go-style
+// durations such as 1h30m are also accepted since older seeded events were stored that way.
+// Years and months are rejected because their length depends on the date they're applied to.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, ErrInvalidDuration
+	}
+
+	if !strings.HasPrefix(s, "P") {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+		}
+		return d, nil
+	}
+
+	d, err := parseISODuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+	}
+	return d, nil
+}
+
+func parseISODuration(s string) (time.Duration, error) {
+	datePart, timePart, hasTime := strings.Cut(s[1:], "T")
+	if datePart == "" && timePart == "" {
+		return 0, ErrInvalidDuration
+	}
+	if hasTime && timePart == "" {
+		return 0, ErrInvalidDuration
+	}
+
+	dateUnits := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	timeUnits := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+
+	dateDuration, err := sumDurationUnits(datePart, dateUnits)
+	if err != nil {
+		return 0, err
+	}
+
+	timeDuration, err := sumDurationUnits(timePart, timeUnits)
+	if err != nil {
+		return 0, err
+	}
+
+	return dateDuration + timeDuration, nil
+}
+
+// sumDurationUnits adds up designator separated values such as 1H30M. Fractions are allowed on
+// any unit, which covers Meetup's occasional PT1.5H.
+func sumDurationUnits(s string, units map[byte]time.Duration) (time.Duration, error) {
+	var total time.Duration
+
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if i <= 0 {
+			return 0, ErrInvalidDuration
+		}
+
+		unit, ok := units[s[i]]
+		if !ok {
+			return 0, ErrInvalidDuration
+		}
+
+		value, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+
+		total += time.Duration(value * float64(unit))
+		s = s[i+1:]
+	}
+
+	return total, nil
+}
+
+// FormatDuration formats d as an ISO 8601 duration, the format the API returns durations in.
+func FormatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	b.WriteString("PT")
+
+	if hours := d / time.Hour; hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "hours", input: "PT2H", expected: 2 * time.Hour},
+		{name: "hours and minutes", input: "PT1H30M", expected: 90 * time.Minute},
+		{name: "fractional hours", input: "PT1.5H", expected: 90 * time.Minute},
+		{name: "days and hours", input: "P1DT2H", expected: 26 * time.Hour},
+		{name: "weeks", input: "P1W", expected: 7 * 24 * time.Hour},
+		{name: "go style", input: "1h30m", expected: 90 * time.Minute},
+		{name: "empty", input: "", expectErr: true},
+		{name: "months", input: "P1M", expectErr: true},
+		{name: "missing time part", input: "PT", expectErr: true},
+		{name: "missing value", input: "PTH", expectErr: true},
+		{name: "garbage", input: "two hours", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDuration(tt.input)
+
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidDuration)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "PT2H", FormatDuration(2*time.Hour))
+	assert.Equal(t, "PT1H30M", FormatDuration(90*time.Minute))
+	assert.Equal(t, "PT45M", FormatDuration(45*time.Minute))
+	assert.Equal(t, "PT1M30S", FormatDuration(90*time.Second))
+	assert.Equal(t, "PT0S", FormatDuration(0))
+}
+
+func TestMeetupEvent_NormalizeDuration(t *testing.T) {
+	t.Run("derives minutes and a UTC end time", func(t *testing.T) {
+		start := time.Date(2025, 4, 25, 18, 0, 0, 0, time.FixedZone("CDT", -5*60*60))
+		event := MeetupEvent{DateTime: &CustomTime{Time: start}, Duration: "2h"}
+
+		require.NoError(t, event.NormalizeDuration())
+
+		assert.Equal(t, "PT2H", event.Duration)
+		assert.Equal(t, 120, event.DurationMinutes)
+		require.NotNil(t, event.EndDateTime)
+		assert.Equal(t, time.Date(2025, 4, 25, 25, 0, 0, 0, time.UTC), event.EndDateTime.Time)
+	})
+
+	t.Run("clears derived fields for a bad duration", func(t *testing.T) {
+		event := MeetupEvent{
+			Duration:        "soon",
+			DurationMinutes: 60,
+			EndDateTime:     &CustomTime{Time: time.Now()},
+		}
+
+		assert.ErrorIs(t, event.NormalizeDuration(), ErrInvalidDuration)
+
+		assert.Equal(t, "soon", event.Duration)
+		assert.Zero(t, event.DurationMinutes)
+		assert.Nil(t, event.EndDateTime)
+	})
+}
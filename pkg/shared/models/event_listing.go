@@ -0,0 +1,90 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// EventListing is stored in the events table for each co-host of an event so the event is also
+// found through the co-host's partition of the group index. It only holds the index keys and the
+// end time filtered on, and points at the single full record of the event.
+type EventListing struct {
+	ID          string      `dynamodbav:"id"`
+	ListingOf   string      `dynamodbav:"listingOf"`
+	GroupID     string      `dynamodbav:"groupId"`
+	DateTime    *CustomTime `dynamodbav:"dateTime"`
+	EndDateTime *CustomTime `dynamodbav:"endDateTime,omitempty"`
+}
+
+// EventListingID returns the key of the listing of an event for a co-hosting group.
+func EventListingID(eventID, groupID string) string {
+	return eventID + "#" + groupID
+}
+
+// ListedEventID returns the ID of the event an item from the events table lists, or false when
+// the item is an event rather than a listing.
+func ListedEventID(item map[string]types.AttributeValue) (string, bool) {
+	listingOf, ok := item["listingOf"].(*types.AttributeValueMemberS)
+	if !ok {
+		return "", false
+	}
+	return listingOf.Value, true
+}
+
+// CoHosts returns the groups hosting the event other than its owner.
+func (e *MeetupEvent) CoHosts() []string {
+	if len(e.GroupIDs) <= 1 {
+		return nil
+	}
+	return e.GroupIDs[1:]
+}
+
+// HostedBy reports whether the group owns or co-hosts the event.
+func (e *MeetupEvent) HostedBy(groupID string) bool {
+	return e.GroupID == groupID || slices.Contains(e.CoHosts(), groupID)
+}
+
+// AddCoHost records groupID as a co-host unless it already hosts the event. Co-hosts are kept
+// sorted so re-importing them in another order doesn't change the event.
+func (e *MeetupEvent) AddCoHost(groupID string) {
+	if e.HostedBy(groupID) {
+		return
+	}
+
+	coHosts := append(slices.Clone(e.CoHosts()), groupID)
+	slices.Sort(coHosts)
+	e.GroupIDs = append([]string{e.GroupID}, coHosts...)
+}
+
+// RemoveCoHost drops groupID from the event's co-hosts, clearing GroupIDs once only the owner
+// is left.
+func (e *MeetupEvent) RemoveCoHost(groupID string) {
+	coHosts := slices.DeleteFunc(slices.Clone(e.CoHosts()), func(g string) bool {
+		return g == groupID
+	})
+
+	if len(coHosts) == 0 {
+		e.GroupIDs = nil
+		return
+	}
+	e.GroupIDs = append([]string{e.GroupID}, coHosts...)
+}
+
+// Listings returns the listing of the event for each of its co-hosts.
+func (e *MeetupEvent) Listings() []EventListing {
+	coHosts := e.CoHosts()
+	listings := make([]EventListing, len(coHosts))
+
+	for i, groupID := range coHosts {
+		listings[i] = EventListing{
+			ID:          EventListingID(e.ID, groupID),
+			ListingOf:   e.ID,
+			GroupID:     groupID,
+			DateTime:    e.DateTime,
+			EndDateTime: e.EndDateTime,
+		}
+	}
+
+	return listings
+}
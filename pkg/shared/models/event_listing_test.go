@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeetupEvent_CoHosts(t *testing.T) {
+	event := MeetupEvent{ID: "1", GroupID: "owner"}
+
+	assert.Empty(t, event.CoHosts())
+	assert.True(t, event.HostedBy("owner"))
+	assert.False(t, event.HostedBy("second"))
+
+	event.AddCoHost("third")
+	event.AddCoHost("second")
+	event.AddCoHost("owner")
+	event.AddCoHost("third")
+
+	assert.Equal(t, []string{"owner", "second", "third"}, event.GroupIDs)
+	assert.Equal(t, []string{"second", "third"}, event.CoHosts())
+	assert.True(t, event.HostedBy("second"))
+
+	event.RemoveCoHost("second")
+	assert.Equal(t, []string{"owner", "third"}, event.GroupIDs)
+
+	event.RemoveCoHost("third")
+	assert.Nil(t, event.GroupIDs)
+}
+
+func TestMeetupEvent_Listings(t *testing.T) {
+	start := &CustomTime{Time: time.Date(2025, 4, 25, 18, 0, 0, 0, time.UTC)}
+	end := &CustomTime{Time: start.Add(time.Hour)}
+	event := MeetupEvent{
+		ID:          "1",
+		GroupID:     "owner",
+		GroupIDs:    []string{"owner", "second"},
+		DateTime:    start,
+		EndDateTime: end,
+	}
+
+	assert.Equal(t, []EventListing{{
+		ID:          "1#second",
+		ListingOf:   "1",
+		GroupID:     "second",
+		DateTime:    start,
+		EndDateTime: end,
+	}}, event.Listings())
+	assert.Empty(t, (&MeetupEvent{ID: "2", GroupID: "owner"}).Listings())
+}
+
+func TestListedEventID(t *testing.T) {
+	id, ok := ListedEventID(map[string]types.AttributeValue{
+		"id":        &types.AttributeValueMemberS{Value: "1#second"},
+		"listingOf": &types.AttributeValueMemberS{Value: "1"},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "1", id)
+
+	_, ok = ListedEventID(map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "1"},
+	})
+	assert.False(t, ok)
+}
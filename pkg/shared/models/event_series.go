@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type EventSeriesSource string
+
+const (
+	// EventSeriesSourceMeetup series are ones Meetup reports as recurring.
+	EventSeriesSourceMeetup EventSeriesSource = "meetup"
+	// EventSeriesSourceDetected series are inferred from events sharing a title, venue and
+	// weekday.
+	EventSeriesSourceDetected EventSeriesSource = "detected"
+)
+
+// EventSeries is a recurring event such as a monthly meetup. Its occurrences are the events
+// sharing its SeriesID, and Title and VenueName follow the most recent occurrence imported.
+type EventSeries struct {
+	SeriesID  string            `dynamodbav:"seriesId"`
+	GroupID   string            `dynamodbav:"groupId"`
+	GroupName string            `dynamodbav:"groupName"`
+	Title     string            `dynamodbav:"title"`
+	VenueName string            `dynamodbav:"venueName,omitempty"`
+	Weekday   string            `dynamodbav:"weekday"`
+	Source    EventSeriesSource `dynamodbav:"source"`
+	UpdatedAt time.Time         `dynamodbav:"updatedAt"`
+}
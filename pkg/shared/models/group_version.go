@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// GroupVersion marks the last import that changed what the API returns for a group, so the API
+// can answer conditional requests without querying the group's events. Version is the RunID of
+// that import, and an import changing a co-hosted event bumps the version of every host.
+type GroupVersion struct {
+	GroupID   string    `dynamodbav:"groupId"`
+	Version   string    `dynamodbav:"version"`
+	UpdatedAt time.Time `dynamodbav:"updatedAt"`
+}
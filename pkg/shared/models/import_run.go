@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// ImportRunScopeAll is the scope of the record summarising a whole import invocation. Per-group
+// records use the group ID as their scope, which never contains a "#".
+const ImportRunScopeAll = "#all"
+
+type ImportAnomalyType string
+
+const ImportAnomalyArchiveSkipped ImportAnomalyType = "archiveSkipped"
+
+type ImportAnomaly struct {
+	Type     ImportAnomalyType `dynamodbav:"type"`
+	Message  string            `dynamodbav:"message"`
+	EventIDs []string          `dynamodbav:"eventIds"`
+}
+
+// ImportRun records what an import invocation did, either overall or for a single group. All
+// records written by one invocation share a RunID, which sorts by start time.
+type ImportRun struct {
+	Scope       string          `dynamodbav:"scope"`
+	RunID       string          `dynamodbav:"runId"`
+	Groups      []string        `dynamodbav:"groups,omitempty"`
+	StartedAt   time.Time       `dynamodbav:"startedAt"`
+	EndedAt     time.Time       `dynamodbav:"endedAt"`
+	Fetched     int             `dynamodbav:"fetched"`
+	Upserted    int             `dynamodbav:"upserted"`
+	Unchanged   int             `dynamodbav:"unchanged"`
+	Archived    int             `dynamodbav:"archived"`
+	MeetupPages int             `dynamodbav:"meetupPages"`
+	Error       string          `dynamodbav:"error,omitempty"`
+	Anomalies   []ImportAnomaly `dynamodbav:"anomalies,omitempty"`
+}
+
+func (r *ImportRun) Succeeded() bool {
+	return r.Error == ""
+}
@@ -1,27 +1,107 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
+// MeetupEvent is an event as imported from Meetup. Capacity is nil when the event has no RSVP
+// limit, and OnlineURL is only set when Meetup shares the link with the importer's account,
+// which it does for public online events.
+//
+// Duration is kept as an ISO 8601 string, with DurationMinutes and EndDateTime derived from it
+// by NormalizeDuration. EndDateTime is stored in UTC so it can be compared in queries.
+//
+// MeetupSeriesID is set for events Meetup reports as part of a recurring series. SeriesID is
+// assigned by the importer and is also set for recurring events Meetup doesn't report as such.
+//
+// GroupID is the group Meetup lists as the event's owner. GroupIDs is only set for co-hosted
+// events and lists every hosting group, owner first.
 type MeetupEvent struct {
-	ID          string        `json:"id"          dynamodbav:"id"          fake:"{uuid}"`
-	GroupID     string        `json:"-"           dynamodbav:"groupId"     fake:"{username}"`
-	GroupName   string        `json:"-"           dynamodbav:"groupName"   fake:"{username}"`
-	Title       string        `json:"title"       dynamodbav:"title"       fake:"{sentence:3}"`
-	EventURL    string        `json:"eventUrl"    dynamodbav:"eventUrl"    fake:"{url}"`
-	Description string        `json:"description" dynamodbav:"description" fake:"{paragraph:3,5,2,}"`
-	DateTime    *CustomTime   `json:"dateTime"    dynamodbav:"dateTime"    fake:"{future_customtime}"`
-	Duration    string        `json:"duration"    dynamodbav:"duration"    fake:"{randomstring:[2h,1h30m,3h]}"`
-	Venue       *MeetupVenue  `json:"venue"       dynamodbav:"venue"`
-	Host        *MeetupHost   `json:"host"        dynamodbav:"host"`
-	Images      []MeetupImage `json:"images"      dynamodbav:"images"                                          fakesize:"1,3"`
+	ID              string          `json:"id"          dynamodbav:"id"                        fake:"{uuid}"`
+	GroupID         string          `json:"-"           dynamodbav:"groupId"                   fake:"{username}"`
+	GroupName       string          `json:"-"           dynamodbav:"groupName"                 fake:"{username}"`
+	Title           string          `json:"title"       dynamodbav:"title"                     fake:"{sentence:3}"`
+	EventURL        string          `json:"eventUrl"    dynamodbav:"eventUrl"                  fake:"{url}"`
+	Description     string          `json:"description" dynamodbav:"description"               fake:"{paragraph:3,5,2,}"`
+	DateTime        *CustomTime     `json:"dateTime"    dynamodbav:"dateTime"                  fake:"{future_customtime}"`
+	Duration        string          `json:"duration"    dynamodbav:"duration"                  fake:"{randomstring:[PT2H,PT1H30M,PT3H]}"`
+	Venue           *MeetupVenue    `json:"venue"       dynamodbav:"venue"`
+	Host            *MeetupHost     `json:"host"        dynamodbav:"host"`
+	Images          []MeetupImage   `json:"images"      dynamodbav:"images"                                                                   fakesize:"1,3"`
+	Tags            []string        `json:"-"           dynamodbav:"tags,omitempty"            fake:"skip"`
+	Hosts           []MeetupHost    `json:"-"           dynamodbav:"hosts,omitempty"                                                          fakesize:"1,3"`
+	Going           int             `json:"going"       dynamodbav:"going"                     fake:"{number:0,100}"`
+	Capacity        *int            `json:"maxTickets"  dynamodbav:"capacity,omitempty"        fake:"{number:10,200}"`
+	EventType       MeetupEventType `json:"eventType"   dynamodbav:"eventType,omitempty"       fake:"{randomstring:[PHYSICAL,ONLINE,HYBRID]}"`
+	OnlineURL       string          `json:"-"           dynamodbav:"onlineUrl,omitempty"       fake:"skip"`
+	Fee             *MeetupFee      `json:"feeSettings" dynamodbav:"fee,omitempty"`
+	Topics          []string        `json:"-"           dynamodbav:"topics,omitempty"                                                         fakesize:"0,3"`
+	CreatedTime     *CustomTime     `json:"createdTime" dynamodbav:"createdTime,omitempty"     fake:"skip"`
+	UpdatedTime     *CustomTime     `json:"updated"     dynamodbav:"updated,omitempty"         fake:"skip"`
+	DurationMinutes int             `json:"-"           dynamodbav:"durationMinutes,omitempty" fake:"skip"`
+	EndDateTime     *CustomTime     `json:"-"           dynamodbav:"endDateTime,omitempty"     fake:"skip"`
+	SeriesID        string          `json:"-"           dynamodbav:"seriesId,omitempty"        fake:"skip"`
+	MeetupSeriesID  string          `json:"-"           dynamodbav:"meetupSeriesId,omitempty"  fake:"skip"`
+	GroupIDs        []string        `json:"-"           dynamodbav:"groupIds,omitempty"        fake:"skip"`
+}
+
+// NormalizeDuration rewrites Duration in ISO 8601 form and derives DurationMinutes and
+// EndDateTime from it. Events with a missing or unparseable duration are left without an end.
+func (e *MeetupEvent) NormalizeDuration() error {
+	e.DurationMinutes = 0
+	e.EndDateTime = nil
+
+	d, err := ParseDuration(e.Duration)
+	if err != nil {
+		return err
+	}
+
+	e.Duration = FormatDuration(d)
+	e.DurationMinutes = int(d.Round(time.Minute) / time.Minute)
+
+	if e.DateTime != nil {
+		e.EndDateTime = &CustomTime{Time: e.DateTime.Add(d).UTC()}
+	}
+
+	return nil
+}
+
+// EventDuration returns the event's length, parsing Duration for events imported before
+// DurationMinutes was recorded.
+func (e *MeetupEvent) EventDuration() (time.Duration, bool) {
+	if e.DurationMinutes > 0 {
+		return time.Duration(e.DurationMinutes) * time.Minute, true
+	}
+
+	d, err := ParseDuration(e.Duration)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+type MeetupEventType string
+
+const (
+	MeetupEventTypePhysical MeetupEventType = "PHYSICAL"
+	MeetupEventTypeOnline   MeetupEventType = "ONLINE"
+	MeetupEventTypeHybrid   MeetupEventType = "HYBRID"
+)
+
+// IsOnline reports whether the event can be attended online, including hybrid events.
+func (e *MeetupEvent) IsOnline() bool {
+	return e.EventType == MeetupEventTypeOnline || e.EventType == MeetupEventTypeHybrid
 }
 
 type MeetupVenue struct {
-	Name       string `json:"name"       dynamodbav:"name"       fake:"{company}"`
-	Address    string `json:"address"    dynamodbav:"address"    fake:"{street}"`
-	City       string `json:"city"       dynamodbav:"city"       fake:"{city}"`
-	State      string `json:"state"      dynamodbav:"state"      fake:"{state}"`
-	PostalCode string `json:"postalCode" dynamodbav:"postalCode" fake:"{zip}"`
+	Name       string   `json:"name"       dynamodbav:"name"          fake:"{company}"`
+	Address    string   `json:"address"    dynamodbav:"address"       fake:"{street}"`
+	City       string   `json:"city"       dynamodbav:"city"          fake:"{city}"`
+	State      string   `json:"state"      dynamodbav:"state"         fake:"{state}"`
+	PostalCode string   `json:"postalCode" dynamodbav:"postalCode"    fake:"{zip}"`
+	Lat        *float64 `json:"lat"        dynamodbav:"lat,omitempty" fake:"{latitude}"`
+	Lng        *float64 `json:"lng"        dynamodbav:"lng,omitempty" fake:"{longitude}"`
 }
 
 type MeetupHost struct {
@@ -29,8 +109,25 @@ type MeetupHost struct {
 }
 
 type MeetupImage struct {
-	BaseUrl string `json:"baseUrl" dynamodbav:"baseUrl" fake:"{url}.{randomstring:[jpg,jpeg,png,svg,webp]}"`
-	Preview string `json:"preview" dynamodbav:"preview" fake:"{url}.{randomstring:[jpg,jpeg,png,svg,webp]}"`
+	BaseUrl string `json:"baseUrl" dynamodbav:"baseUrl"         fake:"{url}.{randomstring:[jpg,jpeg,png,svg,webp]}"`
+	Preview string `json:"preview" dynamodbav:"preview"         fake:"{url}.{randomstring:[jpg,jpeg,png,svg,webp]}"`
+	// Sizes maps the names in MeetupImageSizes to URLs of the image at that size.
+	Sizes map[string]string `json:"sizes"   dynamodbav:"sizes,omitempty" fake:"skip"`
+}
+
+type MeetupFee struct {
+	Amount   float64 `json:"amount"   dynamodbav:"amount"   fake:"{price:1,50}"`
+	Currency string  `json:"currency" dynamodbav:"currency" fake:"{currencyshort}"`
+	Required bool    `json:"required" dynamodbav:"required"`
+}
+
+// MeetupImageSizes are the dimensions requested from Meetup's image service for each image.
+// BaseUrl and Preview stay at the medium size for existing API consumers.
+var MeetupImageSizes = map[string]string{
+	"thumbnail": "224x126",
+	"small":     "448x252",
+	"medium":    "676x380",
+	"large":     "1280x720",
 }
 
 func (e *MeetupEvent) UnmarshalJSON(data []byte) error {
@@ -48,6 +145,19 @@ func (e *MeetupEvent) UnmarshalJSON(data []byte) error {
 			ID      string `json:"id"`
 			BaseUrl string `json:"baseUrl"`
 		} `json:"featuredEventPhoto"`
+		OnlineVenue *struct {
+			URL string `json:"url"`
+		} `json:"onlineVenue"`
+		Topics struct {
+			Edges []struct {
+				Node struct {
+					Name string `json:"name"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"topics"`
+		Series *struct {
+			ID string `json:"id"`
+		} `json:"series"`
 		*Alias
 	}{
 		Alias: (*Alias)(e),
@@ -60,18 +170,50 @@ func (e *MeetupEvent) UnmarshalJSON(data []byte) error {
 	e.GroupName = aux.Group.Name
 	e.GroupID = aux.Group.URLName
 
+	for _, host := range aux.EventHosts {
+		if host.Name != "" {
+			e.Hosts = append(e.Hosts, MeetupHost{Name: host.Name})
+		}
+	}
+
 	// Extract first event host name for backward compatibility
-	if len(aux.EventHosts) > 0 && aux.EventHosts[0].Name != "" {
-		e.Host = &MeetupHost{Name: aux.EventHosts[0].Name}
+	if len(e.Hosts) > 0 {
+		e.Host = &e.Hosts[0]
 	}
 
 	// Map featuredEventPhoto to Images for backward compatibility
 	if aux.FeaturedPhoto != nil && aux.FeaturedPhoto.ID != "" {
-		imageURL := aux.FeaturedPhoto.BaseUrl + aux.FeaturedPhoto.ID + "/676x380.jpg"
+		imageURL := aux.FeaturedPhoto.BaseUrl + aux.FeaturedPhoto.ID + "/"
+
+		sizes := make(map[string]string, len(MeetupImageSizes))
+		for name, dimensions := range MeetupImageSizes {
+			sizes[name] = imageURL + dimensions + ".jpg"
+		}
+
 		e.Images = []MeetupImage{
-			{BaseUrl: imageURL, Preview: imageURL},
+			{BaseUrl: sizes["medium"], Preview: sizes["medium"], Sizes: sizes},
 		}
 	}
 
+	if aux.OnlineVenue != nil {
+		e.OnlineURL = aux.OnlineVenue.URL
+	}
+
+	for _, edge := range aux.Topics.Edges {
+		e.Topics = append(e.Topics, edge.Node.Name)
+	}
+
+	if aux.Series != nil {
+		e.MeetupSeriesID = aux.Series.ID
+	}
+
+	// Meetup reports events without an RSVP limit as having zero tickets
+	if e.Capacity != nil && *e.Capacity == 0 {
+		e.Capacity = nil
+	}
+
+	// A bad duration shouldn't fail the import, the event is kept without an end time
+	_ = e.NormalizeDuration()
+
 	return nil
 }
@@ -17,12 +17,33 @@ func TestMeetupEvent_UnmarshalJSON(t *testing.T) {
   "description": "some description",
   "dateTime": "2024-01-16T18:30-06:00",
   "duration": "PT2H",
+  "createdTime": "2023-12-20T09:15:00-06:00",
+  "updated": "2024-01-10T12:00:00-06:00",
+  "going": 42,
+  "maxTickets": 60,
+  "eventType": "HYBRID",
+  "onlineVenue": {
+    "url": "https://meet.example.com/code-demo"
+  },
+  "feeSettings": {
+    "amount": 5,
+    "currency": "USD",
+    "required": false
+  },
+  "topics": {
+    "edges": [
+      {"node": {"name": "Open Source"}},
+      {"node": {"name": "Civic Tech"}}
+    ]
+  },
   "venue": {
     "name": "efactory",
     "address": "405 N Jefferson Ave",
     "city": "Springfield",
     "state": "MO",
-    "postalCode": "65806"
+    "postalCode": "65806",
+    "lat": 37.2103,
+    "lng": -93.2923
   },
   "group": {
     "name": "Open SGF",
@@ -31,11 +52,17 @@ func TestMeetupEvent_UnmarshalJSON(t *testing.T) {
   "eventHosts": [
     {
       "name": "Levi Zitting"
+    },
+    {
+      "name": "Second Host"
     }
   ],
   "featuredEventPhoto": {
     "id": "501234567",
     "baseUrl": "https://secure-content.meetupstatic.com/images/classic-events/"
+  },
+  "series": {
+    "id": "ptvbqtyhcgbvb"
   }
 }`
 
@@ -57,10 +84,46 @@ func TestMeetupEvent_UnmarshalJSON(t *testing.T) {
 	assert.Equal(t, "Open SGF", event.GroupName)
 	assert.Equal(t, "open-sgf", event.GroupID)
 	assert.Equal(t, "Levi Zitting", event.Host.Name)
+	assert.Equal(t, []MeetupHost{{Name: "Levi Zitting"}, {Name: "Second Host"}}, event.Hosts)
 	assert.Len(t, event.Images, 1)
 	assert.Equal(
 		t,
 		"https://secure-content.meetupstatic.com/images/classic-events/501234567/676x380.jpg",
 		event.Images[0].BaseUrl,
 	)
+	assert.Equal(
+		t,
+		"https://secure-content.meetupstatic.com/images/classic-events/501234567/224x126.jpg",
+		event.Images[0].Sizes["thumbnail"],
+	)
+	assert.Len(t, event.Images[0].Sizes, len(MeetupImageSizes))
+	assert.Equal(t, 42, event.Going)
+	require.NotNil(t, event.Capacity)
+	assert.Equal(t, 60, *event.Capacity)
+	assert.Equal(t, MeetupEventTypeHybrid, event.EventType)
+	assert.True(t, event.IsOnline())
+	assert.Equal(t, "https://meet.example.com/code-demo", event.OnlineURL)
+	assert.Equal(t, &MeetupFee{Amount: 5, Currency: "USD"}, event.Fee)
+	assert.Equal(t, []string{"Open Source", "Civic Tech"}, event.Topics)
+	assert.InDelta(t, 37.2103, *event.Venue.Lat, 0.0001)
+	assert.InDelta(t, -93.2923, *event.Venue.Lng, 0.0001)
+	assert.Equal(t, "2023-12-20T09:15-06:00", event.CreatedTime.String())
+	assert.Equal(t, "2024-01-10T12:00-06:00", event.UpdatedTime.String())
+	assert.Equal(t, "ptvbqtyhcgbvb", event.MeetupSeriesID)
+	assert.Empty(t, event.SeriesID)
+}
+
+func TestMeetupEvent_UnmarshalJSON_Defaults(t *testing.T) {
+	jsonStr := `{"id": "1", "maxTickets": 0, "eventHosts": [], "topics": {"edges": []}}`
+
+	var event MeetupEvent
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &event))
+
+	assert.Nil(t, event.Capacity)
+	assert.Nil(t, event.Host)
+	assert.Empty(t, event.Hosts)
+	assert.Empty(t, event.Topics)
+	assert.Empty(t, event.OnlineURL)
+	assert.Empty(t, event.MeetupSeriesID)
+	assert.False(t, event.IsOnline())
 }
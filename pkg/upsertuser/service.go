@@ -28,7 +28,13 @@ func NewService(config *upsertuserconfig.Config, db *db.Client) *Service {
 	}
 }
 
-func (s *Service) UpsertUser(ctx context.Context, tableName, clientID, clientSecret string) error {
+// UpsertUser creates or replaces the API user, granting it the given scopes and limiting it to
+// the given browser origins. Replacing a user drops any scopes or origins not passed again.
+func (s *Service) UpsertUser(
+	ctx context.Context,
+	tableName, clientID, clientSecret string,
+	scopes, allowedOrigins []string,
+) error {
 	if err := s.validateClientSecret(clientSecret); err != nil {
 		return err
 	}
@@ -41,6 +47,8 @@ func (s *Service) UpsertUser(ctx context.Context, tableName, clientID, clientSec
 	user := models.APIUser{
 		ClientID:           clientID,
 		HashedClientSecret: hash,
+		Scopes:             scopes,
+		AllowedOrigins:     allowedOrigins,
 	}
 
 	av, err := attributevalue.MarshalMap(user)
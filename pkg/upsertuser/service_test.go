@@ -7,8 +7,13 @@ import (
 
 	"sgf-meetup-api/pkg/infra"
 	"sgf-meetup-api/pkg/shared/db"
+	"sgf-meetup-api/pkg/shared/models"
 	"sgf-meetup-api/pkg/upsertuser/upsertuserconfig"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,7 +61,7 @@ func TestService_UpsertUser(t *testing.T) {
 
 		for _, test := range tests {
 			t.Run(test.name, func(t *testing.T) {
-				err := service.UpsertUser(ctx, tableName, "client", test.secret)
+				err := service.UpsertUser(ctx, tableName, "client", test.secret, nil, nil)
 
 				assert.Contains(t, err.Error(), test.name)
 			})
@@ -67,7 +72,7 @@ func TestService_UpsertUser(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 
 		clientID := "test-user"
-		err := service.UpsertUser(ctx, tableName, clientID, "UPPERCASElowercase1234!!")
+		err := service.UpsertUser(ctx, tableName, clientID, "UPPERCASElowercase1234!!", nil, nil)
 		require.NoError(t, err)
 
 		testDB.CheckItemExists(ctx, tableName, "clientId", clientID)
@@ -77,12 +82,39 @@ func TestService_UpsertUser(t *testing.T) {
 		defer func() { _ = testDB.Reset(ctx) }()
 
 		clientID := "test-user"
-		err := service.UpsertUser(ctx, tableName, clientID, "UPPERCASElowercase1234!!")
+		err := service.UpsertUser(ctx, tableName, clientID, "UPPERCASElowercase1234!!", nil, nil)
 		require.NoError(t, err)
 
-		err = service.UpsertUser(ctx, tableName, clientID, "UPPERCASElowercase1!")
+		err = service.UpsertUser(ctx, tableName, clientID, "UPPERCASElowercase1!", nil, nil)
 		require.NoError(t, err)
 
 		require.Equal(t, 1, testDB.GetItemCount(ctx, tableName))
 	})
+	t.Run("stores scopes and origins", func(t *testing.T) {
+		defer func() { _ = testDB.Reset(ctx) }()
+
+		clientID := "admin-user"
+		err := service.UpsertUser(
+			ctx,
+			tableName,
+			clientID,
+			"UPPERCASElowercase1234!!",
+			[]string{models.APIUserScopeAdmin},
+			[]string{"https://opensgf.org"},
+		)
+		require.NoError(t, err)
+
+		result, err := testDB.Client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(tableName),
+			Key: map[string]types.AttributeValue{
+				"clientId": &types.AttributeValueMemberS{Value: clientID},
+			},
+		})
+		require.NoError(t, err)
+
+		var user models.APIUser
+		require.NoError(t, attributevalue.UnmarshalMap(result.Item, &user))
+		assert.True(t, user.HasScope(models.APIUserScopeAdmin))
+		assert.Equal(t, []string{"https://opensgf.org"}, user.AllowedOrigins)
+	})
 }